@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Curt-Park/claudeview/internal/export"
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+var exportFlags struct {
+	format       string
+	output       string
+	project      string
+	noThinking   bool
+	noToolOutput bool
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <session-id | slug>",
+	Short: "Export a session's history as Markdown, HTML or JSON",
+	Long: `Export renders the history of one session, or of every session of a slug
+group, as the history view shows it: user and assistant text, thinking, tool
+calls with their inputs and results, subagents, and compaction markers.
+
+A session ID may be shortened to a unique prefix. The format follows the
+output file's extension unless --format is given; without --output the
+export goes to stdout.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExport,
+}
+
+func init() {
+	f := exportCmd.Flags()
+	f.StringVarP(&exportFlags.format, "format", "f", "", "md, html or json (default: from --output, else md)")
+	f.StringVarP(&exportFlags.output, "output", "o", "", "write to this file instead of stdout")
+	f.StringVarP(&exportFlags.project, "project", "p", "", "look for the session in this project only (hash or directory)")
+	f.BoolVar(&exportFlags.noThinking, "no-thinking", false, "leave out thinking blocks")
+	f.BoolVar(&exportFlags.noToolOutput, "no-tool-output", false, "leave out tool call results")
+	rootCmd.AddCommand(exportCmd)
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format := export.FormatForPath(exportFlags.output)
+	if exportFlags.format != "" {
+		f, err := export.ParseFormat(exportFlags.format)
+		if err != nil {
+			return err
+		}
+		format = f
+	}
+
+	dp, err := newDataProvider()
+	if err != nil {
+		return err
+	}
+	defer closeDataProvider(dp)
+
+	project, sessions, err := findSessions(dp, exportFlags.project, args[0])
+	if err != nil {
+		return err
+	}
+	doc := buildExport(dp, project, sessions, export.Options{
+		Thinking:   !exportFlags.noThinking,
+		ToolOutput: !exportFlags.noToolOutput,
+	})
+
+	if exportFlags.output == "" {
+		return export.Write(cmd.OutOrStdout(), format, doc)
+	}
+	f, err := os.Create(exportFlags.output)
+	if err != nil {
+		return err
+	}
+	if err := export.Write(f, format, doc); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// buildExport loads the sessions' turns and builds their merged history, as
+// the history view does for a slug group.
+func buildExport(dp ui.DataProvider, project *model.Project, sessions []*model.Session, opts export.Options) export.Document {
+	turns, subTurns, subIDs := loadSessionTurns(dp, sessions)
+	ids := make([]string, len(sessions))
+	shortIDs := make([]string, len(sessions))
+	for i, s := range sessions {
+		ids[i] = s.ID
+		shortIDs[i] = s.ShortID()
+	}
+	items := ui.BuildMergedChatItems(turns, subTurns, subIDs, shortIDs)
+
+	title := "Session " + shortIDs[0]
+	for _, s := range sessions {
+		if s.Topic != "" {
+			title = s.Topic
+			break
+		}
+	}
+	return export.Build(title, project.Path, ids, items, opts)
+}
+
+// findSessions returns the sessions arg names, oldest first: the slug group
+// with that slug, or the one session with that ID or unique ID prefix.
+// projectArg, a project hash or path, limits the search to one project.
+func findSessions(dp ui.DataProvider, projectArg, arg string) (*model.Project, []*model.Session, error) {
+	type match struct {
+		project  *model.Project
+		sessions []*model.Session
+	}
+	var matches []match
+	seen := map[string]bool{}
+	for _, p := range dp.GetProjects() {
+		if projectArg != "" && !projectMatches(p, projectArg) {
+			continue
+		}
+		for _, s := range dp.GetSessions(p.Hash) {
+			members := []*model.Session{s}
+			if s.IsGroupRepresentative() {
+				members = s.GroupSessions
+			}
+			if s.Slug != "" && s.Slug == arg {
+				return p, members, nil
+			}
+			for _, m := range members {
+				if m.ID == arg {
+					return p, []*model.Session{m}, nil
+				}
+				if strings.HasPrefix(m.ID, arg) && !seen[m.ID] {
+					seen[m.ID] = true
+					matches = append(matches, match{p, []*model.Session{m}})
+				}
+			}
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil, fmt.Errorf("no session or slug group %q", arg)
+	case 1:
+		return matches[0].project, matches[0].sessions, nil
+	}
+	return nil, nil, fmt.Errorf("%q matches %d sessions; give more of the ID", arg, len(matches))
+}
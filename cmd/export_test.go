@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/export"
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// stubDP serves fixed projects and sessions.
+type stubDP struct {
+	projects []*model.Project
+	sessions map[string][]*model.Session
+	turns    map[string][]model.Turn
+}
+
+func (d *stubDP) GetProjects() []*model.Project                    { return d.projects }
+func (d *stubDP) GetSessions(hash string) []*model.Session         { return d.sessions[hash] }
+func (d *stubDP) GetAgents(string) []*model.Agent                  { return nil }
+func (d *stubDP) GetPlugins(string) []*model.Plugin                { return nil }
+func (d *stubDP) GetPluginItems(*model.Plugin) []*model.PluginItem { return nil }
+func (d *stubDP) GetMemories(string) []*model.Memory               { return nil }
+func (d *stubDP) GetTurns(path string) []model.Turn                { return d.turns[path] }
+
+func exportStub() *stubDP {
+	plan := &model.Session{ID: "aaaa1111-plan", Slug: "brave-fox", FilePath: "plan.jsonl"}
+	exec := &model.Session{ID: "aaaa2222-exec", Slug: "brave-fox", FilePath: "exec.jsonl"}
+	group := &model.Session{ID: exec.ID, Slug: "brave-fox", FilePath: exec.FilePath, GroupSessions: []*model.Session{plan, exec}}
+	solo := &model.Session{ID: "bbbb3333-solo", FilePath: "solo.jsonl", Topic: "Solo work"}
+	return &stubDP{
+		projects: []*model.Project{{Hash: "h1", Path: "/work/app"}},
+		sessions: map[string][]*model.Session{"h1": {group, solo}},
+		turns: map[string][]model.Turn{
+			"plan.jsonl": {{Role: "user", Text: "plan it"}},
+			"exec.jsonl": {{Role: "user", Text: "do it"}},
+			"solo.jsonl": {{Role: "user", Text: "alone"}},
+		},
+	}
+}
+
+func TestFindSessions(t *testing.T) {
+	dp := exportStub()
+	tests := []struct {
+		arg, project string
+		want         string // IDs joined by ","; "" for an error
+	}{
+		{"brave-fox", "", "aaaa1111-plan,aaaa2222-exec"},
+		{"aaaa1111-plan", "", "aaaa1111-plan"},
+		{"bbbb", "/work/app", "bbbb3333-solo"},
+		{"aaaa", "", ""}, // ambiguous prefix
+		{"bbbb", "other", ""},
+	}
+	for _, tt := range tests {
+		_, sessions, err := findSessions(dp, tt.project, tt.arg)
+		var ids []string
+		for _, s := range sessions {
+			ids = append(ids, s.ID)
+		}
+		if got := strings.Join(ids, ","); got != tt.want || (err != nil) != (tt.want == "") {
+			t.Errorf("findSessions(%q, %q) = %q, %v; want %q", tt.project, tt.arg, got, err, tt.want)
+		}
+	}
+}
+
+func TestBuildExportMergesSlugGroup(t *testing.T) {
+	dp := exportStub()
+	project, sessions, err := findSessions(dp, "", "brave-fox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := buildExport(dp, project, sessions, export.Options{})
+	var kinds []string
+	for _, e := range doc.Entries {
+		kinds = append(kinds, e.Kind)
+	}
+	if got := strings.Join(kinds, ","); got != "user,divider,user" {
+		t.Errorf("entries = %s, want the two sessions split by a divider", got)
+	}
+	if doc.Title != "Session aaaa1111" || doc.Project != "/work/app" {
+		t.Errorf("title %q, project %q", doc.Title, doc.Project)
+	}
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/spf13/cobra"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/process"
+	"github.com/Curt-Park/claudeview/internal/ui"
+	"github.com/Curt-Park/claudeview/internal/view"
+)
+
+// listFlags are shared by the projects, sessions, agents, plugins and
+// memories subcommands.
+var listFlags struct {
+	project string
+	filter  string
+	sort    string
+	reverse bool
+	json    bool
+}
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "List projects as the projects view shows them",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return withProvider(func(dp ui.DataProvider) error {
+			return writeList(cmd.OutOrStdout(), view.NewProjectsView(0, 0), dp.GetProjects(), false)
+		})
+	},
+}
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List the sessions of a project, or of every project",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return withProvider(func(dp ui.DataProvider) error {
+			hash := ""
+			if listFlags.project != "" {
+				p, err := findProject(dp, listFlags.project)
+				if err != nil {
+					return err
+				}
+				hash = p.Hash
+			}
+			// Without a project the view is flat, with a PROJECT column.
+			return writeList(cmd.OutOrStdout(), view.NewSessionsView(0, 0), dp.GetSessions(hash), hash == "")
+		})
+	},
+}
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents <session-id | slug>",
+	Short: "List a session's main agent and subagents",
+	Long: `Agents lists the main agent and subagents of a session, as the agents view
+shows them. A session ID may be shortened to a unique prefix; a slug names the
+group's latest session.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withProvider(func(dp ui.DataProvider) error {
+			p, sessions, err := findSessions(dp, listFlags.project, args[0])
+			if err != nil {
+				return err
+			}
+			dp.GetSessions(p.Hash) // GetAgents looks in the last project listed
+			agents := dp.GetAgents(sessions[len(sessions)-1].ID)
+			return writeList(cmd.OutOrStdout(), view.NewAgentsView(0, 0), agents, false)
+		})
+	},
+}
+
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List installed plugins, with a project's own when --project is given",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return withProvider(func(dp ui.DataProvider) error {
+			hash := ""
+			if listFlags.project != "" {
+				p, err := findProject(dp, listFlags.project)
+				if err != nil {
+					return err
+				}
+				hash = p.Hash
+			}
+			return writeList(cmd.OutOrStdout(), view.NewPluginsView(0, 0), dp.GetPlugins(hash), false)
+		})
+	},
+}
+
+var memoriesCmd = &cobra.Command{
+	Use:   "memories",
+	Short: "List a project's memory files (default: the current directory's project)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return withProvider(func(dp ui.DataProvider) error {
+			arg := listFlags.project
+			if arg == "" {
+				dir, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				arg = dir
+			}
+			p, err := findProject(dp, arg)
+			if err != nil {
+				return fmt.Errorf("%w; pass --project", err)
+			}
+			return writeList(cmd.OutOrStdout(), view.NewMemoriesView(0, 0), dp.GetMemories(p.Hash), false)
+		})
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{projectsCmd, sessionsCmd, agentsCmd, pluginsCmd, memoriesCmd} {
+		f := c.Flags()
+		if c != projectsCmd {
+			f.StringVarP(&listFlags.project, "project", "p", "", "project hash or directory")
+		}
+		f.StringVar(&listFlags.filter, "filter", "", `filter query, as typed after / (e.g. "cost>1 -status:done")`)
+		f.StringVar(&listFlags.sort, "sort", "", "sort by this column, largest or newest first (e.g. cost, last-active)")
+		f.BoolVarP(&listFlags.reverse, "reverse", "r", false, "reverse the --sort order")
+		f.BoolVar(&listFlags.json, "json", false, "print the rows' query fields as a JSON array")
+		rootCmd.AddCommand(c)
+	}
+}
+
+// withProvider runs fn with a data provider, closed afterwards.
+func withProvider(fn func(dp ui.DataProvider) error) error {
+	dp, err := newDataProvider()
+	if err != nil {
+		return err
+	}
+	defer closeDataProvider(dp)
+	return fn(dp)
+}
+
+// findProject returns the project arg names; see projectMatches.
+func findProject(dp ui.DataProvider, arg string) (*model.Project, error) {
+	for _, p := range dp.GetProjects() {
+		if projectMatches(p, arg) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no project %q", arg)
+}
+
+// projectMatches reports whether arg names p: by its hash, its directory
+// under ~/.claude/projects, or the working directory Claude Code ran in.
+func projectMatches(p *model.Project, arg string) bool {
+	if p.Hash == arg || p.Path == arg {
+		return true
+	}
+	dir, err := filepath.Abs(arg)
+	return err == nil && p.Hash == process.ProjectHash(dir)
+}
+
+// writeList renders items through v, applying the --filter, --sort and
+// --reverse flags, and writes the rows as plain aligned columns or, with
+// --json, as one object of query fields per row.
+func writeList[T any](w io.Writer, v *view.ResourceView[T], items []T, flat bool) error {
+	v.FlatMode = flat
+	v.Table.Filter = listFlags.filter
+	if err := v.Table.FilterErr(); err != nil {
+		return fmt.Errorf("--filter: %w", err)
+	}
+	v.SetData(items) // publishes the sortable columns
+	if listFlags.sort != "" {
+		col, err := sortColumn(v.Table.Sortable, listFlags.sort)
+		if err != nil {
+			return err
+		}
+		v.Table.Sort = ui.SortState{Column: col, Desc: !listFlags.reverse}
+		v.SetData(items)
+	}
+	rows := v.Table.FilteredRows()
+	if listFlags.json {
+		return writeListJSON(w, rows, v.Table.Fields)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	titles := make([]string, len(v.Table.Columns))
+	for i, c := range v.Table.Columns {
+		titles[i] = c.Title
+	}
+	fmt.Fprintln(tw, strings.Join(titles, "\t"))
+	for _, r := range rows {
+		cells := make([]string, len(r.Cells))
+		for i, c := range r.Cells {
+			if cells[i] = strings.Join(strings.Fields(ansi.Strip(c)), " "); cells[i] == "" {
+				cells[i] = "-" // keep columns countable for awk and cut
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// sortColumn returns the sortable column name refers to, matching titles
+// case-insensitively with - or _ for spaces, or by a unique prefix.
+func sortColumn(sortable []string, name string) (string, error) {
+	norm := strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToUpper(name))
+	var prefixed []string
+	for _, c := range sortable {
+		if c == norm {
+			return c, nil
+		}
+		if strings.HasPrefix(c, norm) {
+			prefixed = append(prefixed, c)
+		}
+	}
+	if len(prefixed) == 1 {
+		return prefixed[0], nil
+	}
+	if len(sortable) == 0 {
+		return "", fmt.Errorf("--sort: this list has no sortable columns")
+	}
+	return "", fmt.Errorf("--sort: no column %q (sortable: %s)", name, strings.ToLower(strings.Join(sortable, ", ")))
+}
+
+// writeListJSON writes each row's query fields as a JSON object: the names
+// --filter tests, with durations such as age in seconds.
+func writeListJSON(w io.Writer, rows []ui.Row, fields map[string]ui.Field) error {
+	out := make([]map[string]any, len(rows))
+	for i, r := range rows {
+		obj := make(map[string]any, len(fields))
+		for name, f := range fields {
+			switch v := f(r.Data).(type) {
+			case time.Duration:
+				obj[name] = v.Seconds()
+			default:
+				obj[name] = v
+			}
+		}
+		out[i] = obj
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
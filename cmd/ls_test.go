@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/view"
+)
+
+func lsSessions() []*model.Session {
+	cost := func(usd float64) map[string]model.TokenCount {
+		return map[string]model.TokenCount{"claude-opus-4-6": {InputTokens: 10, CostUSD: usd}}
+	}
+	return []*model.Session{
+		{ID: "aaaa1111", Topic: "cheap", NumTurns: 3, TokensByModel: cost(0.5)},
+		{ID: "bbbb2222", Topic: "pricey", NumTurns: 9, TokensByModel: cost(4)},
+		{ID: "cccc3333", Topic: "middle", NumTurns: 5, TokensByModel: cost(2)},
+	}
+}
+
+// withListFlags sets listFlags for one test.
+func withListFlags(t *testing.T, filter, sort string, reverse, asJSON bool) {
+	t.Helper()
+	saved := listFlags
+	t.Cleanup(func() { listFlags = saved })
+	listFlags.filter, listFlags.sort, listFlags.reverse, listFlags.json = filter, sort, reverse, asJSON
+}
+
+func TestWriteListFilterSortColumns(t *testing.T) {
+	withListFlags(t, "cost>1", "cost", true, false)
+	var out bytes.Buffer
+	if err := writeList(&out, view.NewSessionsView(0, 0), lsSessions(), false); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("want header and 2 rows, got:\n%s", out.String())
+	}
+	if f := strings.Fields(lines[0]); f[0] != "SLUG" || f[1] != "SESSION_IDs" {
+		t.Errorf("header = %q", lines[0])
+	}
+	// Ascending cost; the empty SLUG cell prints as "-".
+	if !strings.HasPrefix(lines[1], "-") || !strings.Contains(lines[1], "middle") || !strings.Contains(lines[2], "pricey") {
+		t.Errorf("rows:\n%s", out.String())
+	}
+
+	withListFlags(t, "", "colour", false, false)
+	if err := writeList(&out, view.NewSessionsView(0, 0), lsSessions(), false); err == nil || !strings.Contains(err.Error(), "last active") {
+		t.Errorf("unknown sort column: err = %v", err)
+	}
+	withListFlags(t, "nosuch:1", "", false, false)
+	if err := writeList(&out, view.NewSessionsView(0, 0), lsSessions(), false); err == nil {
+		t.Error("bad filter accepted")
+	}
+}
+
+func TestWriteListJSON(t *testing.T) {
+	withListFlags(t, "", "turns", false, true)
+	var out bytes.Buffer
+	if err := writeList(&out, view.NewSessionsView(0, 0), lsSessions(), false); err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("%v:\n%s", err, out.String())
+	}
+	if len(rows) != 3 || rows[0]["topic"] != "pricey" || rows[0]["turns"] != 9.0 || rows[0]["cost"] != 4.0 {
+		t.Errorf("rows = %v", rows)
+	}
+	if _, ok := rows[0]["age"]; !ok {
+		t.Error("missing age field")
+	}
+}
+
+func TestSortColumn(t *testing.T) {
+	sortable := []string{"TURNS", "MODEL:IN+CACHE/OUT", "COST", "LAST ACTIVE"}
+	for in, want := range map[string]string{"cost": "COST", "last-active": "LAST ACTIVE", "last_active": "LAST ACTIVE", "model": "MODEL:IN+CACHE/OUT", "t": "TURNS"} {
+		if got, err := sortColumn(sortable, in); got != want || err != nil {
+			t.Errorf("sortColumn(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := sortColumn([]string{"COST", "COMMANDS"}, "co"); err == nil {
+		t.Error("ambiguous prefix accepted")
+	}
+}
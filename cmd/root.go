@@ -3,10 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,11 +18,14 @@ import (
 	"github.com/Curt-Park/claudeview/internal/demo"
 	"github.com/Curt-Park/claudeview/internal/model"
 	"github.com/Curt-Park/claudeview/internal/parallel"
+	"github.com/Curt-Park/claudeview/internal/pricing"
 	"github.com/Curt-Park/claudeview/internal/provider"
+	"github.com/Curt-Park/claudeview/internal/search"
 	"github.com/Curt-Park/claudeview/internal/transcript"
 	"github.com/Curt-Park/claudeview/internal/ui"
 	"github.com/Curt-Park/claudeview/internal/usage"
 	"github.com/Curt-Park/claudeview/internal/view"
+	"github.com/Curt-Park/claudeview/internal/watch"
 )
 
 // AppVersion is set from main.go via the build-time Version variable.
@@ -42,32 +47,62 @@ var rootCmd = &cobra.Command{
 	Long: `claudeview is a terminal UI for monitoring Claude Code sessions,
 tool calls, plugins, and MCP servers.
 
-Navigate with j/k, Enter to drill down, / to filter, p for plugins, m for memories.`,
+Navigate with j/k, Enter to drill down, / to filter, p for plugins, m for memories,
+F to search every session.`,
 	RunE: run,
+	// Execute prints a failed run's error once, without the usage text.
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 func init() {
-	rootCmd.Flags().BoolVar(&demoMode, "demo", false, "Run with synthetic demo data")
+	rootCmd.PersistentFlags().BoolVar(&demoMode, "demo", false, "Run with synthetic demo data")
 }
 
-func run(cmd *cobra.Command, args []string) error {
-	var dp ui.DataProvider
+// newDataProvider returns the demo or the live data provider; the caller
+// closes it with closeDataProvider.
+func newDataProvider() (ui.DataProvider, error) {
 	if demoMode {
-		dp = demo.NewProvider()
-	} else {
-		dp = provider.NewLive(config.ClaudeDir())
+		return demo.NewProvider(), nil
+	}
+	prices, err := pricing.Load(config.ConfigDir())
+	if err != nil {
+		return nil, fmt.Errorf("loading pricing overrides: %w", err)
+	}
+	return provider.NewLive(config.ClaudeDir(), prices), nil
+}
+
+// closeDataProvider releases what a data provider holds open.
+func closeDataProvider(dp ui.DataProvider) {
+	if c, ok := dp.(io.Closer); ok {
+		_ = c.Close()
 	}
+}
+
+func run(cmd *cobra.Command, args []string) error {
+	dp, err := newDataProvider()
+	if err != nil {
+		return err
+	}
+	defer closeDataProvider(dp)
 
 	appModel := ui.NewAppModel(dp, model.ResourceProjects)
 
 	// Create top-level model that wraps AppModel with actual view data
 	root := newRootModel(appModel, dp)
+	if !demoMode {
+		if w := startWatcher(dp, config.ClaudeDir()); w != nil {
+			defer func() { _ = w.Close() }()
+			root.changes = w.Changes()
+		}
+	}
 
 	p := tea.NewProgram(root,
+		tea.WithOutput(ui.Stdout), // shared with the OSC 52 clipboard
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
-	_, err := p.Run()
+	_, err = p.Run()
 	return err
 }
 
@@ -81,27 +116,48 @@ type usageLoadedMsg struct {
 type dataLoadedMsg struct {
 	projects      []*model.Project
 	sessions      []*model.Session
+	agents        []*model.Agent
+	toolCalls     []ui.ToolCallRow
 	plugins       []*model.Plugin
 	pluginItems   []*model.PluginItem
 	memories      []*model.Memory
 	turns         []model.Turn
 	subagentTurns [][]model.Turn
-	subagentTypes []model.AgentType
+	subagentIDs   []string
 	resource      model.ResourceType
 
 	// Slug group reload data
 	slugGroupSessions []*model.Session // refreshed slug group membership
 	slugGroupTurns    [][]model.Turn
 	slugGroupSubTurns [][][]model.Turn
-	slugGroupSubTypes [][]model.AgentType
+	slugGroupSubIDs   [][]string
+}
+
+// searchIndexedMsg reports that a background search index update finished.
+type searchIndexedMsg struct{}
+
+// searchSnippetsMsg carries the hits of a search run with their partial
+// snippets filled in from the transcripts.
+type searchSnippetsMsg struct {
+	run  int
+	hits []search.Hit
 }
 
+// searchLimit caps the hits shown in the search view.
+const searchLimit = 500
+
+// searchReindexTicks is how often (in ticks) the search view re-indexes
+// transcripts that changed.
+const searchReindexTicks = 30
+
 // rootModel wraps AppModel and manages actual resource data.
 type rootModel struct {
 	app         ui.AppModel
 	dp          ui.DataProvider
 	projects    []*model.Project
 	sessions    []*model.Session
+	agents      []*model.Agent
+	toolCalls   []ui.ToolCallRow
 	plugins     []*model.Plugin
 	pluginItems []*model.PluginItem
 	memories    []*model.Memory
@@ -109,10 +165,25 @@ type rootModel struct {
 	// Resource views (eagerly initialized in newRootModel)
 	projectsView    *view.ResourceView[*model.Project]
 	sessionsView    *view.ResourceView[*model.Session]
+	agentsView      *view.ResourceView[*model.Agent]
+	toolCallsView   *view.ResourceView[ui.ToolCallRow]
 	pluginsView     *view.ResourceView[*model.Plugin]
 	pluginItemsView *view.ResourceView[*model.PluginItem]
 	memoriesView    *view.ResourceView[*model.Memory]
 	chatView        *view.ResourceView[ui.ChatItem]
+	searchView      *view.ResourceView[search.Hit]
+
+	// Global search: the index is built in the background while the search
+	// view is open; searchQuery and searchGen are what searchHits reflect.
+	// searchRun counts searches; snippetRun is the last one whose partial
+	// snippets are being filled in.
+	searchIndex *search.Index
+	searchHits  []search.Hit
+	searchQuery string
+	searchGen   int
+	searchRun   int
+	snippetRun  int
+	indexing    bool
 
 	// Cached chat items for the chat table
 	chatItems []ui.ChatItem
@@ -122,7 +193,11 @@ type rootModel struct {
 	claudeVersion string
 
 	// Async loading state
-	loading bool
+	loading       bool
+	reloadPending bool // a change arrived while loading; reload once it finishes
+
+	// Filesystem change notifications; nil when reloading on every tick.
+	changes <-chan watch.Change
 
 	// Per-resource cursor state: each view remembers its own Selected/Offset.
 	cursor       map[model.ResourceType]struct{ sel, off int }
@@ -149,10 +224,14 @@ func newRootModel(app ui.AppModel, dp ui.DataProvider) *rootModel {
 		claudeVersion:   detectClaudeVersion(),
 		projectsView:    view.NewProjectsView(0, 0),
 		sessionsView:    view.NewSessionsView(0, 0),
+		agentsView:      view.NewAgentsView(0, 0),
+		toolCallsView:   view.NewToolCallsView(0, 0),
 		pluginsView:     view.NewPluginsView(0, 0),
 		pluginItemsView: view.NewPluginItemsView(0, 0),
 		memoriesView:    view.NewMemoriesView(0, 0),
 		chatView:        view.NewChatView(0, 0),
+		searchView:      view.NewSearchView(0, 0),
+		searchIndex:     search.NewIndex(),
 		cursor:          make(map[model.ResourceType]struct{ sel, off int }),
 		lastResource:    app.Resource,
 	}
@@ -196,6 +275,9 @@ func detectClaudeVersion() string {
 }
 
 func (rm *rootModel) Init() tea.Cmd {
+	if rm.changes != nil {
+		return tea.Batch(rm.app.Init(), waitForChange(rm.changes))
+	}
 	return rm.app.Init()
 }
 
@@ -205,6 +287,10 @@ func (rm *rootModel) loadData() {
 		rm.projects = rm.dp.GetProjects()
 	case model.ResourceSessions:
 		rm.sessions = rm.dp.GetSessions(rm.app.SelectedProjectHash)
+	case model.ResourceAgents:
+		rm.agents = rm.dp.GetAgents(rm.app.SelectedSessionID)
+	case model.ResourceToolCalls:
+		rm.toolCalls = loadToolCalls(rm.dp, rm.app.SelectedSessionID)
 	case model.ResourcePlugins:
 		rm.plugins = rm.dp.GetPlugins(rm.app.SelectedProjectHash)
 	case model.ResourcePluginDetail:
@@ -215,6 +301,8 @@ func (rm *rootModel) loadData() {
 		rm.memories = rm.dp.GetMemories(rm.app.SelectedProjectHash)
 	case model.ResourceHistory:
 		rm.app.RebuildChatItems()
+	case model.ResourceSearch:
+		rm.runSearch()
 	}
 	rm.syncView()
 }
@@ -263,18 +351,37 @@ func (rm *rootModel) syncView() {
 
 	switch rt {
 	case model.ResourceProjects:
-		rm.app.Table = rm.projectsView.Sync(rm.projects, w, h, cur.sel, cur.off, flt, false)
+		rm.app.Table = rm.projectsView.Sync(rm.projects, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
 	case model.ResourceSessions:
-		rm.app.Table = rm.sessionsView.Sync(rm.sessions, w, h, cur.sel, cur.off, flt, rm.app.SelectedProjectHash == "")
+		rm.app.Table = rm.sessionsView.Sync(rm.sessions, w, h, cur.sel, cur.off, flt, rm.app.SelectedProjectHash == "", rm.app.SortFor(rt))
+	case model.ResourceAgents:
+		rm.app.Table = rm.agentsView.Sync(rm.agents, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
+	case model.ResourceToolCalls:
+		rm.app.Table = rm.toolCallsView.Sync(rm.toolCalls, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
 	case model.ResourcePlugins:
-		rm.app.Table = rm.pluginsView.Sync(rm.plugins, w, h, cur.sel, cur.off, flt, false)
+		rm.app.Table = rm.pluginsView.Sync(rm.plugins, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
 	case model.ResourcePluginDetail:
-		rm.app.Table = rm.pluginItemsView.Sync(rm.pluginItems, w, h, cur.sel, cur.off, flt, false)
+		rm.app.Table = rm.pluginItemsView.Sync(rm.pluginItems, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
 	case model.ResourceMemory:
-		rm.app.Table = rm.memoriesView.Sync(rm.memories, w, h, cur.sel, cur.off, flt, false)
+		rm.app.Table = rm.memoriesView.Sync(rm.memories, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
+	case model.ResourceSearch:
+		rm.app.SearchStatus = rm.searchStatus()
+		rm.app.Table = rm.searchView.Sync(rm.searchHits, w, h, cur.sel, cur.off, flt, false, rm.app.SortFor(rt))
 	case model.ResourceHistory:
 		rm.chatItems = rm.app.ChatItems
 
+		// A search hit was just opened: select its item.
+		if key := rm.app.TakeSearchJump(); key != "" {
+			rm.historyCursorKey = key
+			rm.historyToolCallID = ""
+			for i, item := range rm.chatItems {
+				if ui.ChatItemKey(item) == key {
+					cur.sel = i
+					break
+				}
+			}
+		}
+
 		// Resolve the base-table cursor index from the key-based anchor when
 		// expansion is active, to avoid the expanded-index being clamped to last row.
 		sel := cur.sel
@@ -287,8 +394,9 @@ func (rm *rootModel) syncView() {
 			}
 		}
 
-		rm.app.Table = rm.chatView.Sync(rm.chatItems, w, h, sel, cur.off, flt, false)
+		rm.app.Table = rm.chatView.Sync(rm.chatItems, w, h, sel, cur.off, flt, false, ui.SortState{})
 		rm.app.ApplyExpansion()
+		rm.app.ApplySearchHighlight()
 		// If cursor was on a tool call sub-row, restore to that specific sub-row.
 		if rm.historyToolCallID != "" {
 			for i, row := range rm.app.Table.FilteredRows() {
@@ -309,6 +417,8 @@ func (rm *rootModel) syncView() {
 		}
 		if rm.app.ChatFollow {
 			rm.app.Table.GotoBottom()
+		} else {
+			rm.app.Table.EnsureVisible()
 		}
 		rm.app.RefreshMenu()
 	}
@@ -324,6 +434,32 @@ func (rm *rootModel) updateInfo() {
 	rm.app.Info.AppVersion = AppVersion
 	rm.app.Info.MemoriesActive = rm.app.SelectedProjectHash != ""
 	rm.app.Info.Resource = rm.app.Resource
+	rm.app.Info.Process = rm.processSummary()
+}
+
+// processSummary describes the Claude Code process of the selected session,
+// or how many sessions have one when none is selected.
+func (rm *rootModel) processSummary() string {
+	running := 0
+	for _, s := range rm.sessions {
+		members := s.GroupSessions // includes the representative
+		if len(members) == 0 {
+			members = []*model.Session{s}
+		}
+		for _, m := range members {
+			if m.Process == nil {
+				continue
+			}
+			if m.ID == rm.app.SelectedSessionID {
+				return m.Process.Summary()
+			}
+			running++
+		}
+	}
+	if running == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d claude running", running)
 }
 
 func (rm *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -338,14 +474,33 @@ func (rm *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ui.TickMsg:
 		rm.syncView()
 		rm.usageTick++
-		if !rm.loading {
-			rm.loading = true
-			extraCmd = rm.loadDataAsync()
+		// With a filesystem watcher, reloads are driven by fsChangedMsg and
+		// the tick only runs an occasional full reload as a fallback.
+		if rm.changes == nil {
+			if !rm.loading {
+				rm.loading = true
+				extraCmd = rm.loadDataAsync()
+			}
+		} else if rm.usageTick%watchFallbackTicks == 0 {
+			if tracker, ok := rm.dp.(changeTracker); ok {
+				tracker.Invalidate(nil)
+			}
+			extraCmd = rm.requestReload()
 		}
 		// Refresh usage every 60 ticks (≈60 seconds).
 		if rm.usageTick%60 == 0 {
 			extraCmd = tea.Batch(extraCmd, rm.loadUsageAsync())
 		}
+		if rm.app.Resource == model.ResourceSearch {
+			// Show files as they are indexed, and pick up changed ones.
+			if rm.searchIndex.Generation() != rm.searchGen {
+				rm.runSearch()
+				rm.syncView()
+			}
+			if rm.usageTick%searchReindexTicks == 0 {
+				extraCmd = tea.Batch(extraCmd, rm.indexSearchAsync())
+			}
+		}
 
 	case ui.SyncViewMsg:
 		rm.syncView()
@@ -356,14 +511,40 @@ func (rm *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		rm.usageStale = msg.stale
 		rm.syncView()
 
+	case fsChangedMsg:
+		extraCmd = rm.handleChange(msg)
+
+	case searchIndexedMsg:
+		rm.indexing = false
+		if rm.app.Resource == model.ResourceSearch {
+			rm.runSearch()
+			rm.syncView()
+		}
+
+	case searchSnippetsMsg:
+		if msg.run == rm.searchRun {
+			rm.searchHits = msg.hits
+			if rm.app.Resource == model.ResourceSearch {
+				rm.syncView()
+			}
+		}
+
 	case dataLoadedMsg:
 		rm.loading = false
+		if rm.reloadPending {
+			rm.reloadPending = false
+			extraCmd = rm.requestReload()
+		}
 		if msg.resource == rm.app.Resource {
 			switch msg.resource {
 			case model.ResourceProjects:
 				rm.projects = msg.projects
 			case model.ResourceSessions:
 				rm.sessions = msg.sessions
+			case model.ResourceAgents:
+				rm.agents = msg.agents
+			case model.ResourceToolCalls:
+				rm.toolCalls = msg.toolCalls
 			case model.ResourcePlugins:
 				rm.plugins = msg.plugins
 			case model.ResourcePluginDetail:
@@ -376,11 +557,11 @@ func (rm *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					rm.app.SlugSessions = msg.slugGroupSessions
 				}
 				if len(msg.slugGroupTurns) > 1 {
-					rm.app.SetSlugGroupData(msg.slugGroupTurns, msg.slugGroupSubTurns, msg.slugGroupSubTypes)
+					rm.app.SetSlugGroupData(msg.slugGroupTurns, msg.slugGroupSubTurns, msg.slugGroupSubIDs)
 				} else {
 					rm.app.SelectedTurns = msg.turns
 					rm.app.SubagentTurns = msg.subagentTurns
-					rm.app.SubagentTypes = msg.subagentTypes
+					rm.app.SubagentIDs = msg.subagentIDs
 				}
 				rm.app.RebuildChatItems()
 				rm.chatItems = rm.app.ChatItems
@@ -398,6 +579,16 @@ func (rm *rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Only reload data when resource changes
 	if rm.app.Resource != prevResource {
 		rm.loadData()
+		if rm.app.Resource == model.ResourceSearch {
+			extraCmd = tea.Batch(extraCmd, rm.indexSearchAsync())
+		}
+	} else if rm.app.Resource == model.ResourceSearch && rm.app.SearchQuery != rm.searchQuery {
+		rm.runSearch()
+		rm.syncView()
+	}
+	if rm.snippetRun != rm.searchRun {
+		rm.snippetRun = rm.searchRun
+		extraCmd = tea.Batch(extraCmd, rm.fillSnippetsAsync())
 	}
 
 	if extraCmd != nil {
@@ -415,6 +606,7 @@ func (rm *rootModel) loadDataAsync() tea.Cmd {
 	sessionFilePath := rm.app.SelectedSessionFilePath
 	subagentDir := rm.app.SelectedSessionSubagentDir
 	slugSessions := rm.app.SlugSessions
+	agent := rm.app.SelectedAgent
 	dp := rm.dp
 	return func() tea.Msg {
 		msg := dataLoadedMsg{resource: resource}
@@ -423,6 +615,10 @@ func (rm *rootModel) loadDataAsync() tea.Cmd {
 			msg.projects = dp.GetProjects()
 		case model.ResourceSessions:
 			msg.sessions = dp.GetSessions(projectHash)
+		case model.ResourceAgents:
+			msg.agents = dp.GetAgents(sessionID)
+		case model.ResourceToolCalls:
+			msg.toolCalls = loadToolCalls(dp, sessionID)
 		case model.ResourcePlugins:
 			msg.plugins = dp.GetPlugins(projectHash)
 		case model.ResourcePluginDetail:
@@ -432,35 +628,20 @@ func (rm *rootModel) loadDataAsync() tea.Cmd {
 		case model.ResourceMemory:
 			msg.memories = dp.GetMemories(projectHash)
 		case model.ResourceHistory, model.ResourceHistoryDetail:
+			if agent != nil && agent.IsSubagent {
+				// A single subagent's own history.
+				msg.turns = dp.GetTurns(agent.FilePath)
+				break
+			}
 			// Re-scan sessions to detect newly created sessions in the slug group.
-			freshSlug := refreshSlugGroup(dp, projectHash, sessionID, slugSessions)
+			// History opened from the agents view stays on its one session.
+			var freshSlug []*model.Session
+			if agent == nil {
+				freshSlug = refreshSlugGroup(dp, projectHash, sessionID, slugSessions)
+			}
 			if len(freshSlug) > 1 {
 				msg.slugGroupSessions = freshSlug
-				type slugResult struct {
-					turns    []model.Turn
-					subTurns [][]model.Turn
-					subTypes []model.AgentType
-				}
-				results := parallel.Map(freshSlug, func(s *model.Session) slugResult {
-					turns := dp.GetTurns(s.FilePath)
-					var subTurns [][]model.Turn
-					if s.SubagentDir != "" {
-						subInfos, _ := transcript.ScanSubagents(s.SubagentDir)
-						subTurns = parallel.Map(subInfos, func(si transcript.SessionInfo) []model.Turn {
-							return dp.GetTurns(si.FilePath)
-						})
-					}
-					return slugResult{
-						turns:    turns,
-						subTurns: subTurns,
-						subTypes: model.ExtractSubagentTypes(turns),
-					}
-				})
-				for _, r := range results {
-					msg.slugGroupTurns = append(msg.slugGroupTurns, r.turns)
-					msg.slugGroupSubTurns = append(msg.slugGroupSubTurns, r.subTurns)
-					msg.slugGroupSubTypes = append(msg.slugGroupSubTypes, r.subTypes)
-				}
+				msg.slugGroupTurns, msg.slugGroupSubTurns, msg.slugGroupSubIDs = loadSessionTurns(dp, freshSlug)
 			} else {
 				if sessionFilePath != "" {
 					msg.turns = dp.GetTurns(sessionFilePath)
@@ -470,14 +651,144 @@ func (rm *rootModel) loadDataAsync() tea.Cmd {
 					msg.subagentTurns = parallel.Map(subInfos, func(si transcript.SessionInfo) []model.Turn {
 						return dp.GetTurns(si.FilePath)
 					})
+					msg.subagentIDs = subagentIDs(subInfos)
 				}
-				msg.subagentTypes = model.ExtractSubagentTypes(msg.turns)
 			}
 		}
 		return msg
 	}
 }
 
+// runSearch runs the search view's query against the index.
+func (rm *rootModel) runSearch() {
+	rm.searchQuery = rm.app.SearchQuery
+	rm.searchGen = rm.searchIndex.Generation()
+	rm.searchHits = rm.searchIndex.Search(rm.searchQuery, searchLimit)
+	rm.searchRun++
+}
+
+// fillSnippetsAsync returns a tea.Cmd that fills in the snippets of hits
+// matching past their stored text, or nil when there are none.
+func (rm *rootModel) fillSnippetsAsync() tea.Cmd {
+	if !slices.ContainsFunc(rm.searchHits, func(h search.Hit) bool { return h.Partial }) {
+		return nil
+	}
+	ix, hits, run := rm.searchIndex, rm.searchHits, rm.searchRun
+	return func() tea.Msg {
+		return searchSnippetsMsg{run: run, hits: ix.FillSnippets(hits)}
+	}
+}
+
+// searchStatus describes the search index for the search view's title.
+func (rm *rootModel) searchStatus() string {
+	if done, total := rm.searchIndex.Progress(); rm.indexing && done < total {
+		return fmt.Sprintf("indexing %d/%d", done, total)
+	}
+	return fmt.Sprintf("%d transcripts", rm.searchIndex.Files())
+}
+
+// indexSearchAsync returns a tea.Cmd that brings the search index up to date
+// in a background goroutine, or nil while an update is already running.
+func (rm *rootModel) indexSearchAsync() tea.Cmd {
+	if rm.indexing {
+		return nil
+	}
+	rm.indexing = true
+	ix := rm.searchIndex
+	dp := rm.dp
+	load := dp.GetTurns
+	if r, ok := dp.(turnReader); ok {
+		load = r.ReadTurns
+	}
+	return func() tea.Msg {
+		ix.Update(searchSources(dp), load)
+		return searchIndexedMsg{}
+	}
+}
+
+// turnReader is implemented by data providers that can parse a transcript
+// without keeping it cached (provider.Live), so indexing every transcript
+// does not hold all of them in memory.
+type turnReader interface {
+	ReadTurns(filePath string) []model.Turn
+}
+
+// searchSources lists every session transcript, and the transcripts of its
+// subagents, across all projects.
+func searchSources(dp ui.DataProvider) []search.Source {
+	var sources []search.Source
+	for _, p := range dp.GetProjects() {
+		for _, s := range p.Sessions {
+			sources = append(sources, search.Source{
+				ProjectHash: p.Hash,
+				SessionID:   s.ID,
+				FilePath:    s.FilePath,
+				ModTime:     s.ModTime,
+			})
+			if s.SubagentDir == "" {
+				continue
+			}
+			infos, _ := transcript.ScanSubagents(s.SubagentDir)
+			for _, si := range infos {
+				sources = append(sources, search.Source{
+					ProjectHash: p.Hash,
+					SessionID:   s.ID,
+					FilePath:    si.FilePath,
+					SubagentID:  transcript.SubagentID(si),
+					ModTime:     si.ModTime,
+				})
+			}
+		}
+	}
+	return sources
+}
+
+// loadToolCalls returns every tool call made by a session's main agent and
+// subagents.
+func loadToolCalls(dp ui.DataProvider, sessionID string) []ui.ToolCallRow {
+	agents := dp.GetAgents(sessionID)
+	turns := parallel.Map(agents, func(a *model.Agent) []model.Turn {
+		return dp.GetTurns(a.FilePath)
+	})
+	return ui.BuildToolCallRows(agents, turns)
+}
+
+// loadSessionTurns loads the turns of each session with its subagents' turns
+// and IDs, in parallel.
+func loadSessionTurns(dp ui.DataProvider, sessions []*model.Session) (turns [][]model.Turn, subTurns [][][]model.Turn, subIDs [][]string) {
+	type sessionResult struct {
+		turns    []model.Turn
+		subTurns [][]model.Turn
+		subIDs   []string
+	}
+	results := parallel.Map(sessions, func(s *model.Session) sessionResult {
+		r := sessionResult{turns: dp.GetTurns(s.FilePath)}
+		if s.SubagentDir != "" {
+			subInfos, _ := transcript.ScanSubagents(s.SubagentDir)
+			r.subTurns = parallel.Map(subInfos, func(si transcript.SessionInfo) []model.Turn {
+				return dp.GetTurns(si.FilePath)
+			})
+			r.subIDs = subagentIDs(subInfos)
+		}
+		return r
+	})
+	for _, r := range results {
+		turns = append(turns, r.turns)
+		subTurns = append(subTurns, r.subTurns)
+		subIDs = append(subIDs, r.subIDs)
+	}
+	return turns, subTurns, subIDs
+}
+
+// subagentIDs returns the agent ID of each subagent transcript.
+func subagentIDs(infos []transcript.SessionInfo) []string {
+	ids := make([]string, len(infos))
+	for i, si := range infos {
+		ids[i] = transcript.SubagentID(si)
+	}
+	return ids
+}
+
 // loadUsageAsync returns a tea.Cmd that fetches usage data in a background goroutine.
 func (rm *rootModel) loadUsageAsync() tea.Cmd {
 	if rm.usageClient == nil {
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Curt-Park/claudeview/internal/config"
+	"github.com/Curt-Park/claudeview/internal/demo"
+	"github.com/Curt-Park/claudeview/internal/server"
+	"github.com/Curt-Park/claudeview/internal/usage"
+)
+
+var serveFlags struct {
+	addr     string
+	cors     string
+	interval time.Duration
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve projects, sessions, agents, turns, plugins, memories and usage as a read-only JSON API",
+	Long: `Serve exposes what the TUI shows as a read-only JSON REST API, parsed the
+same way, for dashboards and editor integrations. GET /api lists the
+endpoints. A session's /events endpoint streams its turns, tool calls and
+subagent activity as server-sent events while Claude Code writes them, in
+the same event form as tail --json.
+
+The API has no authentication and transcripts can hold secrets, so it
+listens on localhost unless --addr says otherwise, and on a loopback address
+it refuses requests naming another host, which keeps web pages from reaching
+it through DNS rebinding.`,
+	Example: `  claudeview serve
+  claudeview serve --addr :7878 --cors '*'
+  curl -N localhost:7878/api/projects/<hash>/sessions/<id>/events?backlog=20`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	f := serveCmd.Flags()
+	f.StringVar(&serveFlags.addr, "addr", "127.0.0.1:7878", "address to listen on")
+	f.StringVar(&serveFlags.cors, "cors", "", "Access-Control-Allow-Origin to send, e.g. '*'")
+	f.DurationVar(&serveFlags.interval, "interval", time.Second, "how often event streams check for new lines")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	dp, err := newDataProvider()
+	if err != nil {
+		return err
+	}
+	defer closeDataProvider(dp)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ln, err := net.Listen("tcp", serveFlags.addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		// Requests share ctx, so event streams end when serve is stopped.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+		Handler: server.New(dp, server.Options{
+			Usage:        serveUsage(),
+			Interval:     serveFlags.interval,
+			AllowOrigin:  serveFlags.cors,
+			AllowedHosts: server.LoopbackHosts(ln.Addr()),
+		}).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "claudeview: serving on http://%s/api\n", ln.Addr())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if srv.Shutdown(shutdown) != nil {
+			_ = srv.Close()
+		}
+	}()
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-done // let handlers finish before the provider closes
+	return nil
+}
+
+// serveUsage returns the usage source for the API: synthetic data in demo
+// mode, the usage API when there are credentials, otherwise nil.
+func serveUsage() func(context.Context) (*usage.Data, bool, error) {
+	if demoMode {
+		data := demo.GenerateUsage()
+		return func(context.Context) (*usage.Data, bool, error) { return data, false, nil }
+	}
+	token, err := usage.ReadToken(filepath.Join(config.ClaudeDir(), ".credentials.json"))
+	if err != nil {
+		return nil
+	}
+	return usage.NewClient(token, "").Fetch
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/parallel"
+	"github.com/Curt-Park/claudeview/internal/stats"
+	"github.com/Curt-Park/claudeview/internal/transcript"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+var statsFlags struct {
+	by      string
+	since   string
+	until   string
+	project string
+	format  string
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Report token usage and cost grouped by project, model, day and more",
+	Long: `Stats adds up the tokens, turns, tool calls and estimated cost of every
+assistant turn, subagents included, and groups them by one or more of:
+
+  project, session, model, day, week, branch, agent
+
+--since and --until take a date (2026-01-31) or a duration back from now
+(24h, 7d, 2w); --until is inclusive of the day it names. The report prints as
+a table, or as CSV or JSON for scripts and spreadsheets.`,
+	Example: `  claudeview stats --by day --since 7d
+  claudeview stats --by project,model --since 2026-01-01 -f csv
+  claudeview stats --by agent -p ~/work/app -f json`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	f := statsCmd.Flags()
+	f.StringVar(&statsFlags.by, "by", "project", "comma-separated group keys: project, session, model, day, week, branch, agent")
+	f.StringVar(&statsFlags.since, "since", "", "only turns on or after this date or duration ago (2026-01-31, 7d)")
+	f.StringVar(&statsFlags.until, "until", "", "only turns up to this date or duration ago, inclusive")
+	f.StringVarP(&statsFlags.project, "project", "p", "", "only this project (hash or directory)")
+	f.StringVarP(&statsFlags.format, "format", "f", "table", "table, csv or json")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, _ []string) error {
+	keys, err := stats.ParseKeys(statsFlags.by)
+	if err != nil {
+		return err
+	}
+	format, err := stats.ParseFormat(statsFlags.format)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var r stats.Range
+	if r.From, err = parseStatsTime(statsFlags.since, now, false); err != nil {
+		return fmt.Errorf("--since: %w", err)
+	}
+	if r.To, err = parseStatsTime(statsFlags.until, now, true); err != nil {
+		return fmt.Errorf("--until: %w", err)
+	}
+
+	dp, err := newDataProvider()
+	if err != nil {
+		return err
+	}
+	defer closeDataProvider(dp)
+
+	transcripts, err := collectTranscripts(dp, statsFlags.project, r)
+	if err != nil {
+		return err
+	}
+	return stats.Write(cmd.OutOrStdout(), format, stats.Aggregate(transcripts, keys, r))
+}
+
+// parseStatsTime parses a --since/--until value: a YYYY-MM-DD date in local
+// time, or a duration back from now in h, d or w. An until date means the end
+// of that day.
+func parseStatsTime(s string, now time.Time, until bool) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		if until {
+			d = d.AddDate(0, 0, 1)
+		}
+		return d, nil
+	}
+	unit := map[byte]time.Duration{'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}[s[len(s)-1]]
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if unit == 0 || err != nil || n < 0 {
+		return time.Time{}, fmt.Errorf("%q is not a date (2006-01-02) or duration (24h, 7d, 2w)", s)
+	}
+	return now.Add(-time.Duration(n) * unit), nil
+}
+
+// collectTranscripts loads the main and subagent transcripts of every session
+// that may have turns inside r, optionally in one project only.
+func collectTranscripts(dp ui.DataProvider, projectArg string, r stats.Range) ([]stats.Transcript, error) {
+	type work struct {
+		project string
+		session *model.Session
+	}
+	var sessions []work
+	found := projectArg == ""
+	for _, p := range dp.GetProjects() {
+		if projectArg != "" && !projectMatches(p, projectArg) {
+			continue
+		}
+		found = true
+		for _, s := range dp.GetSessions(p.Hash) {
+			members := []*model.Session{s}
+			if s.IsGroupRepresentative() {
+				members = s.GroupSessions
+			}
+			for _, m := range members {
+				if !r.From.IsZero() && !m.ModTime.IsZero() && m.ModTime.Before(r.From) {
+					continue // last written before the range starts
+				}
+				if !r.To.IsZero() && !m.StartTime.IsZero() && !m.StartTime.Before(r.To) {
+					continue
+				}
+				sessions = append(sessions, work{p.Hash, m})
+			}
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no project %q", projectArg)
+	}
+
+	loaded := parallel.Map(sessions, func(w work) []stats.Transcript {
+		return sessionTranscripts(dp, w.project, w.session)
+	})
+	var out []stats.Transcript
+	for _, ts := range loaded {
+		out = append(out, ts...)
+	}
+	return out, nil
+}
+
+// sessionTranscripts returns a session's main transcript and its subagents',
+// each subagent typed by the Agent/Task call that spawned it.
+func sessionTranscripts(dp ui.DataProvider, project string, s *model.Session) []stats.Transcript {
+	turns := dp.GetTurns(s.FilePath)
+	out := []stats.Transcript{{Project: project, SessionID: s.ID, Branch: s.Branch, Agent: string(model.AgentTypeMain), Turns: turns}}
+	if s.SubagentDir == "" {
+		return out
+	}
+	subInfos, _ := transcript.ScanSubagents(s.SubagentDir)
+	if len(subInfos) == 0 {
+		return out
+	}
+	types := make([]model.AgentType, len(subInfos))
+	for i := range types {
+		types[i] = model.AgentTypeGeneral
+	}
+	calls := model.SubagentCalls(turns)
+	callTypes := model.ExtractAgentTypesFromCalls(calls)
+	byCall, _ := model.MatchSubagents(calls, subagentIDs(subInfos))
+	for k, i := range byCall {
+		if i >= 0 && callTypes[k] != "" {
+			types[i] = callTypes[k]
+		}
+	}
+	for i, si := range subInfos {
+		out = append(out, stats.Transcript{
+			Project:   project,
+			SessionID: s.ID,
+			Branch:    s.Branch,
+			Agent:     string(types[i]),
+			Turns:     dp.GetTurns(si.FilePath),
+		})
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/stats"
+)
+
+func TestParseStatsTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.Local)
+	tests := []struct {
+		in    string
+		until bool
+		want  time.Time
+	}{
+		{"", false, time.Time{}},
+		{"2026-03-01", false, time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"2026-03-01", true, time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)},
+		{"7d", false, now.AddDate(0, 0, -7)},
+		{"24h", true, now.Add(-24 * time.Hour)},
+		{"2w", false, now.AddDate(0, 0, -14)},
+	}
+	for _, tt := range tests {
+		got, err := parseStatsTime(tt.in, now, tt.until)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseStatsTime(%q, %v) = %v, %v; want %v", tt.in, tt.until, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"7", "d", "yesterday", "-3d"} {
+		if _, err := parseStatsTime(bad, now, false); err == nil {
+			t.Errorf("parseStatsTime(%q) accepted", bad)
+		}
+	}
+}
+
+func TestCollectTranscriptsFlattensGroups(t *testing.T) {
+	dp := exportStub()
+	transcripts, err := collectTranscripts(dp, "", stats.Range{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for _, tr := range transcripts {
+		if tr.Project != "h1" || tr.Agent != "main" {
+			t.Errorf("transcript = %+v", tr)
+		}
+		seen[tr.SessionID] = true
+	}
+	if len(transcripts) != 3 || !seen["aaaa1111-plan"] || !seen["aaaa2222-exec"] || !seen["bbbb3333-solo"] {
+		t.Errorf("sessions = %v", seen)
+	}
+	if _, err := collectTranscripts(dp, "/elsewhere", stats.Range{}); err == nil {
+		t.Error("unknown project accepted")
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/tail"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+var tailFlags struct {
+	project  string
+	lines    int
+	noFollow bool
+	json     bool
+	color    string
+	interval time.Duration
+}
+
+var tailCmd = &cobra.Command{
+	Use:   "tail [session-id | slug]",
+	Short: "Follow a session's turns, tool calls and subagents as plain lines or NDJSON",
+	Long: `Tail prints the last lines of a session — prompts, replies, tool calls and
+their results, subagent activity — then follows it, printing what Claude Code
+appends as it is written. Every line starts with the time and who acted, so
+the output can be piped into grep; --json prints one event object per line.
+
+Without an argument, tail follows the most recently active session of the
+project (--project, or the current directory's) and moves to a newer session
+when one starts. A slug follows the group's latest session.`,
+	Example: `  claudeview tail
+  claudeview tail 3f2a9c1e -n 50
+  claudeview tail -p ~/work/app --json | jq 'select(.type == "tool_call")'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTail,
+}
+
+func init() {
+	f := tailCmd.Flags()
+	f.StringVarP(&tailFlags.project, "project", "p", "", "project hash or directory (default: the current directory's)")
+	f.IntVarP(&tailFlags.lines, "lines", "n", 10, "print this many earlier events first; -1 for all")
+	f.BoolVar(&tailFlags.noFollow, "no-follow", false, "print the earlier events and exit")
+	f.BoolVar(&tailFlags.json, "json", false, "print events as NDJSON")
+	f.StringVar(&tailFlags.color, "color", "auto", "auto, always or never")
+	f.DurationVar(&tailFlags.interval, "interval", 500*time.Millisecond, "how often to check for new lines")
+	rootCmd.AddCommand(tailCmd)
+}
+
+// tailSwitchPolls is how many polls pass between checks for a newer session
+// when following a project's latest.
+const tailSwitchPolls = 10
+
+func runTail(cmd *cobra.Command, args []string) error {
+	var color *bool
+	switch tailFlags.color {
+	case "auto":
+	case "always", "never":
+		on := tailFlags.color == "always"
+		color = &on
+	default:
+		return fmt.Errorf("--color: want auto, always or never, not %q", tailFlags.color)
+	}
+	var w tail.Writer = tail.NewTextWriter(cmd.OutOrStdout(), color)
+	if tailFlags.json {
+		w = tail.NewJSONWriter(cmd.OutOrStdout())
+	}
+
+	dp, err := newDataProvider()
+	if err != nil {
+		return err
+	}
+	defer closeDataProvider(dp)
+
+	var hash string
+	var session *model.Session
+	if len(args) == 1 {
+		_, sessions, err := findSessions(dp, tailFlags.project, args[0])
+		if err != nil {
+			return err
+		}
+		session = sessions[len(sessions)-1]
+	} else {
+		if hash, err = tailProject(dp); err != nil {
+			return err
+		}
+		if session = latestSession(dp, hash); session == nil {
+			return fmt.Errorf("no sessions in project %q", hash)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return followSession(ctx, dp, w, session, hash)
+}
+
+// followSession writes the last --lines events of session, then, unless
+// --no-follow, new events until ctx is done. When hash is set it moves on to
+// a newer session of that project once one appears.
+func followSession(ctx context.Context, dp ui.DataProvider, w tail.Writer, session *model.Session, hash string) error {
+	f := tail.NewFollower(session, dp.GetTurns)
+	events := tailBacklog(session, f)
+
+	ticker := time.NewTicker(tailFlags.interval)
+	defer ticker.Stop()
+	for polls := 1; ; polls++ {
+		for _, ev := range events {
+			if err := w.Write(ev); err != nil {
+				return err // e.g. the reader of a pipe went away
+			}
+		}
+		if tailFlags.noFollow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		events = f.Poll()
+		if hash != "" && polls%tailSwitchPolls == 0 {
+			if latest := latestSession(dp, hash); latest != nil && latest.ID != session.ID {
+				session = latest
+				f = tail.NewFollower(session, dp.GetTurns)
+				events = append(events, tailBacklog(session, f)...)
+			}
+		}
+	}
+}
+
+// tailBacklog returns the session event and the last --lines events already
+// written to session, from f's first Poll.
+func tailBacklog(session *model.Session, f *tail.Follower) []tail.Event {
+	events := f.Poll()
+	if n := tailFlags.lines; n >= 0 && len(events) > n {
+		events = events[len(events)-n:]
+	}
+	return append([]tail.Event{tail.SessionEvent(session)}, events...)
+}
+
+// tailProject returns the hash of the --project flag's project, or of the
+// project of the current directory.
+func tailProject(dp ui.DataProvider) (string, error) {
+	arg := tailFlags.project
+	if arg == "" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		arg = dir
+	}
+	p, err := findProject(dp, arg)
+	if err != nil {
+		return "", fmt.Errorf("%w; pass a session or --project", err)
+	}
+	return p.Hash, nil
+}
+
+// latestSession returns the most recently written session of a project,
+// looking inside slug groups, or nil when it has none.
+func latestSession(dp ui.DataProvider, hash string) *model.Session {
+	var latest *model.Session
+	for _, s := range dp.GetSessions(hash) {
+		members := []*model.Session{s}
+		if s.IsGroupRepresentative() {
+			members = s.GroupSessions
+		}
+		for _, m := range members {
+			if latest == nil || m.ModTime.After(latest.ModTime) {
+				latest = m
+			}
+		}
+	}
+	return latest
+}
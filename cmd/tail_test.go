@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/tail"
+)
+
+func TestFollowSessionBacklog(t *testing.T) {
+	saved := tailFlags
+	t.Cleanup(func() { tailFlags = saved })
+	tailFlags.lines, tailFlags.noFollow = 1, true
+
+	dp := exportStub()
+	s := latestSession(dp, "h1")
+	if s == nil {
+		t.Fatal("no latest session")
+	}
+	dp.turns[s.FilePath] = append(dp.turns[s.FilePath], dp.turns["plan.jsonl"]...)
+
+	var out bytes.Buffer
+	never := false
+	if err := followSession(context.Background(), dp, tail.NewTextWriter(&out, &never), s, ""); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "session "+s.ID) || !strings.HasSuffix(lines[1], "you          "+dp.turns[s.FilePath][1].Text) {
+		t.Errorf("output:\n%s", out.String())
+	}
+}
+
+func TestLatestSessionLooksInsideGroups(t *testing.T) {
+	dp := exportStub()
+	now := time.Now()
+	group, solo := dp.sessions["h1"][0], dp.sessions["h1"][1]
+	group.GroupSessions[0].ModTime = now // the plan session, not the representative
+	group.ModTime, solo.ModTime = now.Add(-time.Hour), now.Add(-time.Minute)
+	if got := latestSession(dp, "h1"); got == nil || got.ID != "aaaa1111-plan" {
+		t.Errorf("latestSession = %v", got)
+	}
+	if got := latestSession(dp, "none"); got != nil {
+		t.Errorf("empty project gave %v", got)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Curt-Park/claudeview/internal/ui"
+	"github.com/Curt-Park/claudeview/internal/watch"
+)
+
+// watchDepth covers projects/<hash>/<session-id>/subagents below projects/.
+const watchDepth = 3
+
+// watchFallbackTicks is how often (in ticks ≈ seconds) a full reload still
+// runs while the filesystem watcher is active, in case events were missed.
+const watchFallbackTicks = 10
+
+// changeTracker is implemented by data providers that can skip re-reading
+// unchanged files when told which paths changed (provider.Live).
+type changeTracker interface {
+	TrackChanges()
+	Invalidate(paths []string)
+}
+
+// fsChangedMsg carries a batch of filesystem changes into the Bubble Tea loop.
+type fsChangedMsg watch.Change
+
+// startWatcher watches the projects directory under claudeDir and switches dp
+// to change-driven refresh. It returns nil when dp cannot track changes or no
+// watcher is available on this platform; the tick-based reload is used then.
+func startWatcher(dp ui.DataProvider, claudeDir string) *watch.Watcher {
+	tracker, ok := dp.(changeTracker)
+	if !ok {
+		return nil
+	}
+	w, err := watch.New(filepath.Join(claudeDir, "projects"), watchDepth)
+	if err != nil {
+		return nil
+	}
+	tracker.TrackChanges()
+	return w
+}
+
+// waitForChange returns a tea.Cmd that blocks until the watcher delivers the
+// next batch of changes.
+func waitForChange(changes <-chan watch.Change) tea.Cmd {
+	return func() tea.Msg {
+		c, ok := <-changes
+		if !ok {
+			return nil
+		}
+		return fsChangedMsg(c)
+	}
+}
+
+// handleChange invalidates the changed paths and schedules a reload.
+func (rm *rootModel) handleChange(c fsChangedMsg) tea.Cmd {
+	if tracker, ok := rm.dp.(changeTracker); ok {
+		if c.Overflow {
+			tracker.Invalidate(nil)
+		} else {
+			tracker.Invalidate(c.Paths)
+		}
+	}
+	return tea.Batch(rm.requestReload(), waitForChange(rm.changes))
+}
+
+// requestReload starts an async data load, or queues one if a load is
+// already running so changes arriving mid-load are not lost.
+func (rm *rootModel) requestReload() tea.Cmd {
+	if rm.loading {
+		rm.reloadPending = true
+		return nil
+	}
+	rm.loading = true
+	return rm.loadDataAsync()
+}
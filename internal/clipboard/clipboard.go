@@ -0,0 +1,42 @@
+// Package clipboard copies text to the system clipboard with an OSC 52 escape
+// sequence. The terminal carries it out, so it works over SSH; inside tmux
+// (with allow-passthrough on) and screen the sequence is wrapped to pass
+// through to the outer terminal.
+package clipboard
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// MaxBytes is the largest text copied; terminals drop longer sequences.
+const MaxBytes = 1 << 20
+
+// ErrTooLarge is returned for text over MaxBytes.
+var ErrTooLarge = errors.New("too large for the clipboard")
+
+// Sequence returns the escape sequence that copies text, wrapped for the
+// terminal multiplexer getenv shows (TMUX, or a TERM of screen).
+func Sequence(text string, getenv func(string) string) string {
+	seq := osc52.New(text)
+	switch {
+	case getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+	return seq.String()
+}
+
+// Copy writes the sequence copying text to w, the terminal.
+func Copy(w io.Writer, text string) error {
+	if len(text) > MaxBytes {
+		return ErrTooLarge
+	}
+	_, err := io.WriteString(w, Sequence(text, os.Getenv))
+	return err
+}
@@ -0,0 +1,44 @@
+package clipboard_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/clipboard"
+)
+
+func env(vars map[string]string) func(string) string {
+	return func(k string) string { return vars[k] }
+}
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"plain terminal", map[string]string{"TERM": "xterm-256color"}, "\x1b]52;c;aGVsbG8=\x07"},
+		{"tmux passthrough", map[string]string{"TMUX": "/tmp/tmux-1000/default,1,0", "TERM": "screen"}, "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\x07\x1b\\"},
+	}
+	for _, tt := range tests {
+		if got := clipboard.Sequence("hello", env(tt.env)); got != tt.want {
+			t.Errorf("%s: Sequence = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := clipboard.Sequence("hello", env(map[string]string{"TERM": "screen.xterm"})); !strings.HasPrefix(got, "\x1bP") {
+		t.Errorf("screen: Sequence = %q, want a DCS passthrough", got)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	var buf bytes.Buffer
+	if err := clipboard.Copy(&buf, "hello"); err != nil || !strings.Contains(buf.String(), "aGVsbG8=") {
+		t.Errorf("Copy wrote %q, err %v", buf.String(), err)
+	}
+	buf.Reset()
+	if err := clipboard.Copy(&buf, strings.Repeat("x", clipboard.MaxBytes+1)); !errors.Is(err, clipboard.ErrTooLarge) || buf.Len() != 0 {
+		t.Errorf("oversized Copy: err %v, wrote %d bytes", err, buf.Len())
+	}
+}
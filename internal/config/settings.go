@@ -37,3 +37,25 @@ func ClaudeDir() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, ".claude")
 }
+
+// CacheDir returns claudeview's cache directory under the user cache
+// directory ($XDG_CACHE_HOME, falling back to ~/.cache on Linux).
+func CacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "claudeview")
+}
+
+// ConfigDir returns claudeview's configuration directory under the user
+// config directory ($XDG_CONFIG_HOME, falling back to ~/.config on Linux).
+func ConfigDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "claudeview")
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/pricing"
 	"github.com/Curt-Park/claudeview/internal/usage"
 )
 
@@ -64,14 +65,16 @@ func GenerateTurns() []model.Turn {
 		{Name: "Edit", Input: []byte(`{"file_path": "src/auth.py", "old_string": "import jwt", "new_string": "from authlib.integrations.starlette_client import OAuth"}`), Result: []byte(`"success"`), Timestamp: now.Add(-90 * time.Second), Duration: 60 * time.Millisecond},
 		{Name: "Bash", Input: []byte(`{"command": "python -m pytest tests/test_auth.py -v"}`), Result: []byte(`"5 passed in 1.23s"`), Timestamp: now.Add(-45 * time.Second), Duration: 1400 * time.Millisecond},
 	}
-	return []model.Turn{
+	turns := []model.Turn{
 		{
 			Role:      "user",
+			UUID:      "demo-turn-1",
 			Text:      "Refactor the authentication module to use OAuth2 with the authlib library.",
 			Timestamp: now.Add(-5 * time.Minute),
 		},
 		{
 			Role:         "assistant",
+			UUID:         "demo-turn-2",
 			Text:         "I'll start by reading the current auth implementation and exploring suitable OAuth2 libraries.",
 			Thinking:     "The user wants OAuth2. I should check the existing auth code first, then evaluate library options via a subagent before making changes.",
 			ToolCalls:    calls[:3],
@@ -81,21 +84,26 @@ func GenerateTurns() []model.Turn {
 			Timestamp:    now.Add(-4 * time.Minute),
 		},
 		{
-			Role:         "assistant",
-			Text:         "The subagent recommends authlib for its async support. I'll update the import and refactor the token verification logic now.",
-			ToolCalls:    calls[3:5],
-			ModelName:    "claude-opus-4-6",
-			InputTokens:  8400,
-			OutputTokens: 1150,
-			Timestamp:    now.Add(-90 * time.Second),
+			Role:             "assistant",
+			UUID:             "demo-turn-3",
+			Text:             "The subagent recommends authlib for its async support. I'll update the import and refactor the token verification logic now.",
+			ToolCalls:        calls[3:5],
+			ModelName:        "claude-opus-4-6",
+			InputTokens:      400,
+			CacheWriteTokens: 8000,
+			CacheReadTokens:  41000,
+			OutputTokens:     1150,
+			Timestamp:        now.Add(-90 * time.Second),
 		},
 		{
 			Role:      "user",
+			UUID:      "demo-turn-4",
 			Text:      "Looks good! Can you also add a refresh token endpoint?",
 			Timestamp: now.Add(-30 * time.Second),
 		},
 		{
 			Role:         "assistant",
+			UUID:         "demo-turn-5",
 			Text:         "All 5 auth tests pass after the refactor. I'll add the refresh token endpoint next — I'll write the route handler and a corresponding test.",
 			ModelName:    "claude-opus-4-6",
 			InputTokens:  2100,
@@ -103,6 +111,12 @@ func GenerateTurns() []model.Turn {
 			Timestamp:    now.Add(-20 * time.Second),
 		},
 	}
+	prices := pricing.Default()
+	for i := range turns {
+		t := &turns[i]
+		t.CostUSD = prices.Cost(t.ModelName, pricing.Tokens{Input: t.InputTokens, CacheWrite: t.CacheWriteTokens, CacheRead: t.CacheReadTokens, Output: t.OutputTokens})
+	}
+	return turns
 }
 
 // GeneratePluginItems creates synthetic plugin items for a named demo plugin.
@@ -152,12 +166,13 @@ func GenerateProjects() []*model.Project {
 			Topic:         "Refactor authentication module to use OAuth2",
 			Branch:        "feat/auth-refactor",
 			FileSize:      1363149,
-			TokensByModel: map[string]model.TokenCount{"claude-opus-4-6": {InputTokens: 120000, OutputTokens: 25200}},
+			TokensByModel: map[string]model.TokenCount{"claude-opus-4-6": {InputTokens: 12000, CacheWriteTokens: 108000, CacheReadTokens: 1850000, OutputTokens: 25200}},
 			AgentCount:    4,
 			ToolCallCount: 18,
 			NumTurns:      12,
 			StartTime:     now.Add(-5 * time.Minute),
 			ModTime:       now.Add(-30 * time.Second),
+			Activity:      model.Activity{OpenTools: []string{"Agent"}},
 			Agents:        generateAgents("abc12345"),
 		},
 		{
@@ -197,6 +212,14 @@ func GenerateProjects() []*model.Project {
 		},
 	}
 
+	prices := pricing.Default()
+	for _, s := range append(sessions1, sessions2...) {
+		for m, tc := range s.TokensByModel {
+			tc.CostUSD = prices.Cost(m, pricing.Tokens{Input: tc.InputTokens, CacheWrite: tc.CacheWriteTokens, CacheRead: tc.CacheReadTokens, Output: tc.OutputTokens})
+			s.TokensByModel[m] = tc
+		}
+	}
+
 	return []*model.Project{
 		{
 			Hash:     "-Users-mac-Repositories-my-awesome-app",
@@ -230,6 +253,11 @@ func generateAgents(sessionID string) []*model.Agent {
 			LastActivity: "Edit src/app.py",
 			StartTime:    now.Add(-5 * time.Minute),
 			IsSubagent:   false,
+			CostUSD:      0.92,
+			TokensByModel: map[string]model.TokenCount{
+				"claude-opus-4-6": {InputTokens: 42000, CacheReadTokens: 910000, OutputTokens: 18000, CostUSD: 0.92},
+			},
+			ModTime: now.Add(-10 * time.Second),
 		},
 		{
 			ID:           fmt.Sprintf("agent-%s-sub1", sessionID[:4]),
@@ -240,6 +268,11 @@ func generateAgents(sessionID string) []*model.Agent {
 			LastActivity: "Read src/config.py",
 			StartTime:    now.Add(-4 * time.Minute),
 			IsSubagent:   true,
+			CostUSD:      0.18,
+			TokensByModel: map[string]model.TokenCount{
+				"claude-haiku-4-5": {InputTokens: 31000, CacheReadTokens: 240000, OutputTokens: 6200, CostUSD: 0.18},
+			},
+			ModTime: now.Add(-15 * time.Second),
 		},
 		{
 			ID:           fmt.Sprintf("agent-%s-sub2", sessionID[:4]),
@@ -250,6 +283,11 @@ func generateAgents(sessionID string) []*model.Agent {
 			LastActivity: "Read CLAUDE.md",
 			StartTime:    now.Add(-3 * time.Minute),
 			IsSubagent:   true,
+			CostUSD:      0.07,
+			TokensByModel: map[string]model.TokenCount{
+				"claude-opus-4-6": {InputTokens: 9000, CacheReadTokens: 52000, OutputTokens: 2100, CostUSD: 0.07},
+			},
+			ModTime: now.Add(-2 * time.Minute),
 		},
 		{
 			ID:           fmt.Sprintf("agent-%s-sub3", sessionID[:4]),
@@ -260,6 +298,11 @@ func generateAgents(sessionID string) []*model.Agent {
 			LastActivity: "Bash: npm test",
 			StartTime:    now.Add(-2 * time.Minute),
 			IsSubagent:   true,
+			CostUSD:      0.03,
+			TokensByModel: map[string]model.TokenCount{
+				"claude-haiku-4-5": {InputTokens: 4000, CacheReadTokens: 18000, OutputTokens: 900, CostUSD: 0.03},
+			},
+			ModTime: now.Add(-5 * time.Second),
 		},
 	}
 }
@@ -0,0 +1,231 @@
+// Package export renders a session's history — the ChatItems the history view
+// shows — to Markdown, a self-contained HTML page, or normalized JSON.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+// Format names an export format.
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatJSON     Format = "json"
+)
+
+// ParseFormat returns the format a name or file extension stands for:
+// md/markdown, html/htm or json.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "md", "markdown":
+		return FormatMarkdown, nil
+	case "html", "htm":
+		return FormatHTML, nil
+	case "json":
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (want md, html or json)", s)
+}
+
+// FormatForPath returns the format of an output file's extension, Markdown
+// when it has none that names one.
+func FormatForPath(path string) Format {
+	if f, err := ParseFormat(filepath.Ext(path)); err == nil {
+		return f
+	}
+	return FormatMarkdown
+}
+
+// Options select what an export includes.
+type Options struct {
+	Thinking   bool // thinking blocks
+	ToolOutput bool // tool call results; inputs are always kept
+}
+
+// Document is a history prepared for export.
+type Document struct {
+	Title    string    `json:"title"`
+	Project  string    `json:"project,omitempty"`
+	Sessions []string  `json:"sessions"`
+	Exported time.Time `json:"exported"`
+	Entries  []Entry   `json:"entries"`
+}
+
+// Entry kinds, one per history row.
+const (
+	KindUser      = "user"
+	KindAssistant = "assistant"
+	KindSystem    = "system" // compaction markers
+	KindSubagent  = "subagent"
+	KindDivider   = "divider" // session boundaries and conversation branches
+)
+
+// Entry is one history row: a divider, or the turns of one message.
+type Entry struct {
+	Kind  string `json:"kind"`
+	Label string `json:"label,omitempty"` // divider text; subagent type
+	Turns []Turn `json:"turns,omitempty"`
+}
+
+// Turn is one API turn of an entry.
+type Turn struct {
+	Role      string     `json:"role"`
+	Model     string     `json:"model,omitempty"`
+	Timestamp time.Time  `json:"timestamp"`
+	Text      string     `json:"text,omitempty"`
+	Thinking  string     `json:"thinking,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	Tokens    *Tokens    `json:"tokens,omitempty"`
+	CostUSD   float64    `json:"cost_usd,omitempty"`
+}
+
+// Tokens is a turn's token usage.
+type Tokens struct {
+	Input      int `json:"input"`
+	CacheWrite int `json:"cache_write"`
+	CacheRead  int `json:"cache_read"`
+	Output     int `json:"output"`
+}
+
+// ToolCall is a tool call of a turn.
+type ToolCall struct {
+	ID         string          `json:"id,omitempty"`
+	Name       string          `json:"name"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	Result     string          `json:"result,omitempty"`
+	IsError    bool            `json:"is_error,omitempty"`
+	DurationMS int64           `json:"duration_ms,omitempty"`
+}
+
+// Build prepares the history items for export. Subagent turns are put back
+// in the order they were made.
+func Build(title, project string, sessionIDs []string, items []ui.ChatItem, opts Options) Document {
+	doc := Document{Title: title, Project: project, Sessions: sessionIDs, Exported: time.Now()}
+	for _, item := range items {
+		if item.IsDivider {
+			doc.Entries = append(doc.Entries, Entry{Kind: KindDivider, Label: strings.Trim(item.DividerLabel, "─ ")})
+			continue
+		}
+		turns := append([]model.Turn{item.Turn}, item.ExtraTurns...)
+		e := Entry{Kind: item.Turn.Role}
+		if item.IsSubagent {
+			e.Kind, e.Label = KindSubagent, item.AgentType.DisplayLabel()
+			sort.SliceStable(turns, func(i, j int) bool { return turns[i].Timestamp.Before(turns[j].Timestamp) })
+		}
+		for _, t := range turns {
+			e.Turns = append(e.Turns, NewTurn(t, opts))
+		}
+		doc.Entries = append(doc.Entries, e)
+	}
+	return doc
+}
+
+// NewTurn converts a model turn to its export form.
+func NewTurn(t model.Turn, opts Options) Turn {
+	out := Turn{Role: t.Role, Model: t.ModelName, Timestamp: t.Timestamp, Text: t.Text, CostUSD: t.CostUSD}
+	if opts.Thinking {
+		out.Thinking = t.Thinking
+	}
+	if t.InputTokens > 0 || t.CacheWriteTokens > 0 || t.CacheReadTokens > 0 || t.OutputTokens > 0 {
+		out.Tokens = &Tokens{Input: t.InputTokens, CacheWrite: t.CacheWriteTokens, CacheRead: t.CacheReadTokens, Output: t.OutputTokens}
+	}
+	for _, tc := range t.ToolCalls {
+		call := ToolCall{ID: tc.ID, Name: tc.Name, Input: tc.Input, IsError: tc.IsError, DurationMS: tc.Duration.Milliseconds()}
+		if opts.ToolOutput {
+			call.Result = tc.ResultText()
+		}
+		out.ToolCalls = append(out.ToolCalls, call)
+	}
+	return out
+}
+
+// Write writes doc to w in format f.
+func Write(w io.Writer, f Format, doc Document) error {
+	switch f {
+	case FormatHTML:
+		return WriteHTML(w, doc)
+	case FormatJSON:
+		return WriteJSON(w, doc)
+	}
+	return WriteMarkdown(w, doc)
+}
+
+// WriteJSON writes doc as indented JSON.
+func WriteJSON(w io.Writer, doc Document) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// Who returns the author an entry is shown under.
+func (e Entry) Who() string {
+	switch e.Kind {
+	case KindUser:
+		return "You"
+	case KindAssistant:
+		return "Claude"
+	case KindSystem:
+		return "System"
+	case KindSubagent:
+		return "↳ " + e.Label
+	}
+	return e.Kind
+}
+
+// Meta returns the "model · date time · tokens · cost" line of a turn.
+func (t Turn) Meta() string {
+	var parts []string
+	if m := model.ShortModelName(t.Model); m != "" {
+		parts = append(parts, m)
+	}
+	if !t.Timestamp.IsZero() {
+		parts = append(parts, t.Timestamp.Local().Format("2006-01-02 15:04:05"))
+	}
+	if t.Tokens != nil {
+		parts = append(parts, model.FormatTokenInOutCache(t.Tokens.Input, t.Tokens.CacheWrite, t.Tokens.CacheRead, t.Tokens.Output)+" tok")
+	}
+	if t.CostUSD > 0 {
+		parts = append(parts, model.FormatCost(t.CostUSD))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// Summary returns a tool call's one-line input summary.
+func (c ToolCall) Summary() string {
+	tc := model.ToolCall{Name: c.Name, Input: c.Input}
+	return tc.InputSummary()
+}
+
+// Status returns ✓ or ✗ and the call's duration.
+func (c ToolCall) Status() string {
+	s := "✓"
+	if c.IsError {
+		s = "✗ error"
+	}
+	if c.DurationMS > 0 {
+		tc := model.ToolCall{Duration: time.Duration(c.DurationMS) * time.Millisecond}
+		s += " " + tc.DurationString()
+	}
+	return s
+}
+
+// IndentedInput returns a tool call's input as indented JSON.
+func (c ToolCall) IndentedInput() string {
+	var buf bytes.Buffer
+	if json.Indent(&buf, c.Input, "", "  ") != nil {
+		return string(c.Input)
+	}
+	return buf.String()
+}
@@ -0,0 +1,110 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/export"
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+func sampleItems() []ui.ChatItem {
+	t0 := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	call := &model.ToolCall{ID: "t1", Name: "Bash", Input: json.RawMessage(`{"command":"echo ` + "```" + `"}`), Result: json.RawMessage(`"out <b>"`)}
+	return []ui.ChatItem{
+		{Turn: model.Turn{Role: "user", Text: "hello", Timestamp: t0}, SubagentIdx: -1},
+		{Turn: model.Turn{Role: "assistant", Text: "hi", Thinking: "secret plan", ModelName: "claude-opus-4-6", Timestamp: t0.Add(time.Second), ToolCalls: []*model.ToolCall{call}}, SubagentIdx: -1},
+		{
+			Turn:       model.Turn{Role: "assistant", Text: "second", Timestamp: t0.Add(3 * time.Second)},
+			ExtraTurns: []model.Turn{{Role: "assistant", Thinking: "first", Timestamp: t0.Add(2 * time.Second)}},
+			IsSubagent: true, AgentType: model.AgentTypeExplore, SubagentIdx: 0,
+		},
+		{Turn: model.Turn{Role: "system", Text: "Conversation compacted (120k tokens)", Timestamp: t0.Add(4 * time.Second)}, SubagentIdx: -1},
+		{IsDivider: true, DividerLabel: "── session 2/2 (abcd1234) ──", SubagentIdx: -1},
+	}
+}
+
+func TestBuildEntriesAndOptions(t *testing.T) {
+	doc := export.Build("T", "/p", []string{"s1"}, sampleItems(), export.Options{Thinking: true, ToolOutput: true})
+	kinds := make([]string, len(doc.Entries))
+	for i, e := range doc.Entries {
+		kinds[i] = e.Kind
+	}
+	if got := strings.Join(kinds, ","); got != "user,assistant,subagent,system,divider" {
+		t.Fatalf("kinds = %s", got)
+	}
+	if sub := doc.Entries[2]; sub.Turns[0].Thinking != "first" || sub.Turns[1].Text != "second" {
+		t.Errorf("subagent turns not in time order: %+v", sub.Turns)
+	}
+	if l := doc.Entries[4].Label; l != "session 2/2 (abcd1234)" {
+		t.Errorf("divider label = %q", l)
+	}
+	if r := doc.Entries[1].Turns[0].ToolCalls[0].Result; r != "out <b>" {
+		t.Errorf("result = %q", r)
+	}
+
+	doc = export.Build("T", "", nil, sampleItems(), export.Options{})
+	a := doc.Entries[1].Turns[0]
+	if a.Thinking != "" || a.ToolCalls[0].Result != "" || len(a.ToolCalls[0].Input) == 0 {
+		t.Errorf("options off: thinking %q, result %q, input %s; want only the input", a.Thinking, a.ToolCalls[0].Result, a.ToolCalls[0].Input)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	doc := export.Build("My session", "/p", []string{"s1"}, sampleItems(), export.Options{Thinking: true, ToolOutput: true})
+	var buf bytes.Buffer
+	if err := export.WriteMarkdown(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# My session\n",
+		"## You\n",
+		"> secret plan",
+		"### ↳ Explorer\n",
+		"> ⟳ Conversation compacted (120k tokens)",
+		"*session 2/2 (abcd1234)*",
+		"````json\n{\n  \"command\": \"echo ```\"\n}\n````",
+		"```\nout <b>\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteHTMLEscapesAndIsSelfContained(t *testing.T) {
+	doc := export.Build("<script>", "", []string{"s1"}, sampleItems(), export.Options{Thinking: true, ToolOutput: true})
+	var buf bytes.Buffer
+	if err := export.WriteHTML(&buf, doc); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "<script>") || !strings.Contains(out, "&lt;script&gt;") {
+		t.Error("title not escaped")
+	}
+	if !strings.Contains(out, "out &lt;b&gt;") {
+		t.Error("tool result not escaped")
+	}
+	if strings.Contains(out, "<link") || strings.Contains(out, "src=") {
+		t.Error("page loads external resources")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]export.Format{"md": export.FormatMarkdown, "Markdown": export.FormatMarkdown, ".htm": export.FormatHTML, "json": export.FormatJSON} {
+		if got, err := export.ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := export.ParseFormat("pdf"); err == nil {
+		t.Error("ParseFormat(pdf) succeeded")
+	}
+	if got := export.FormatForPath("out/x.html"); got != export.FormatHTML {
+		t.Errorf("FormatForPath = %q", got)
+	}
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"html/template"
+	"io"
+)
+
+// WriteHTML writes doc as one self-contained HTML page: styles inline, no
+// scripts, thinking and tool results in collapsed <details> blocks.
+func WriteHTML(w io.Writer, doc Document) error {
+	return htmlPage.Execute(w, doc)
+}
+
+var htmlPage = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font: 15px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; max-width: 960px; margin: 2em auto; padding: 0 1em; color: #1f2328; background: #fff; }
+h1 { font-size: 1.5em; margin-bottom: .2em; }
+.doc-meta, .meta { color: #656d76; font-size: .85em; }
+code, pre { font: 13px/1.45 ui-monospace, SFMono-Regular, Menlo, monospace; }
+pre { background: #f6f8fa; padding: .6em .8em; border-radius: 6px; overflow-x: auto; white-space: pre-wrap; word-break: break-word; }
+.entry { margin: 1.2em 0; padding: .6em 1em; border-left: 4px solid #d0d7de; }
+.entry.user { border-color: #0969da; }
+.entry.assistant { border-color: #8250df; }
+.entry.subagent { border-color: #bf8700; margin-left: 2em; }
+.who { font-weight: 600; }
+.text { white-space: pre-wrap; word-break: break-word; margin: .4em 0; }
+.thinking { color: #656d76; font-style: italic; }
+.tool { margin: .5em 0; }
+.tool-name { font-weight: 600; color: #0550ae; }
+.ok { color: #1a7f37; }
+.err { color: #cf222e; }
+.divider { text-align: center; color: #656d76; margin: 1.5em 0; }
+.system { text-align: center; color: #9a6700; margin: 1em 0; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="doc-meta">
+{{- if .Project}}<div>Project: <code>{{.Project}}</code></div>{{end}}
+<div>Sessions: {{range $i, $s := .Sessions}}{{if $i}}, {{end}}<code>{{$s}}</code>{{end}}</div>
+<div>Exported: {{.Exported.Local.Format "2006-01-02 15:04:05"}}</div>
+</div>
+{{range .Entries}}
+{{- if eq .Kind "divider"}}
+<div class="divider">── {{.Label}} ──</div>
+{{- else if eq .Kind "system"}}
+{{- range .Turns}}
+<div class="system">⟳ {{.Text}}</div>
+{{- end}}
+{{- else}}
+<div class="entry {{.Kind}}">
+<div class="who">{{.Who}}</div>
+{{- range .Turns}}
+{{- with .Meta}}
+<div class="meta">{{.}}</div>
+{{- end}}
+{{- if .Thinking}}
+<details class="thinking"><summary>Thinking</summary><div class="text">{{.Thinking}}</div></details>
+{{- end}}
+{{- if .Text}}
+<div class="text">{{.Text}}</div>
+{{- end}}
+{{- range .ToolCalls}}
+<div class="tool">
+<div><span class="tool-name">▸ {{.Name}}</span> <code>{{.Summary}}</code> {{if .IsError}}<span class="err">{{.Status}}</span>{{else}}<span class="ok">{{.Status}}</span>{{end}}</div>
+<pre>{{.IndentedInput}}</pre>
+{{- if .Result}}
+<details><summary>Result</summary><pre>{{.Result}}</pre></details>
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
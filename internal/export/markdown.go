@@ -0,0 +1,110 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes doc as Markdown: a heading per entry, thinking in a
+// collapsed <details> block, and tool inputs and results in code fences.
+func WriteMarkdown(w io.Writer, doc Document) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", doc.Title)
+	if doc.Project != "" {
+		fmt.Fprintf(&sb, "- Project: `%s`\n", doc.Project)
+	}
+	fmt.Fprintf(&sb, "- Sessions: %s\n", strings.Join(wrapCode(doc.Sessions), ", "))
+	fmt.Fprintf(&sb, "- Exported: %s\n", doc.Exported.Local().Format("2006-01-02 15:04:05"))
+
+	for _, e := range doc.Entries {
+		switch e.Kind {
+		case KindDivider:
+			fmt.Fprintf(&sb, "\n---\n\n*%s*\n", e.Label)
+			continue
+		case KindSystem:
+			for _, t := range e.Turns {
+				fmt.Fprintf(&sb, "\n> ⟳ %s\n", t.Text)
+			}
+			continue
+		}
+		level := "##"
+		if e.Kind == KindSubagent {
+			level = "###"
+		}
+		for i, t := range e.Turns {
+			if i == 0 {
+				fmt.Fprintf(&sb, "\n%s %s\n", level, e.Who())
+			}
+			if meta := t.Meta(); meta != "" {
+				fmt.Fprintf(&sb, "\n*%s*\n", meta)
+			}
+			if t.Thinking != "" {
+				fmt.Fprintf(&sb, "\n<details><summary>Thinking</summary>\n\n%s\n\n</details>\n", quoteLines(t.Thinking))
+			}
+			if t.Text != "" {
+				fmt.Fprintf(&sb, "\n%s\n", strings.TrimRight(t.Text, "\n"))
+			}
+			for _, c := range t.ToolCalls {
+				header := "**▸ " + c.Name + "**"
+				if s := c.Summary(); s != "" {
+					header += " " + codeSpan(s)
+				}
+				fmt.Fprintf(&sb, "\n%s %s\n", header, c.Status())
+				sb.WriteString("\n" + fence(c.IndentedInput(), "json"))
+				if c.Result != "" {
+					sb.WriteString("\n" + fence(c.Result, ""))
+				}
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// fence returns s in a code fence longer than any backtick run inside it.
+func fence(s, lang string) string {
+	ticks := strings.Repeat("`", max(3, longestRun(s, '`')+1))
+	return ticks + lang + "\n" + strings.TrimRight(s, "\n") + "\n" + ticks + "\n"
+}
+
+// codeSpan returns s as inline code, on one line.
+func codeSpan(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	ticks := strings.Repeat("`", longestRun(s, '`')+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return ticks + " " + s + " " + ticks
+	}
+	return ticks + s + ticks
+}
+
+func wrapCode(ss []string) []string {
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = codeSpan(s)
+	}
+	return out
+}
+
+// quoteLines prefixes every line of s with "> ".
+func quoteLines(s string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight("> "+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// longestRun returns the length of the longest run of c in s.
+func longestRun(s string, c byte) int {
+	longest, run := 0, 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
@@ -65,7 +65,30 @@ type Agent struct {
 	FilePath     string
 	StartTime    time.Time
 	IsSubagent   bool
-	Depth        int // tree depth for display
+	Depth        int     // tree depth for display
+	CostUSD      float64 // estimated cost of the agent's turns
+	Unmatched    bool    // subagent with no spawning Agent/Task call in the session
+
+	TokensByModel map[string]TokenCount // the agent's own transcript, subagents excluded
+	ModTime       time.Time             // last write to the agent's transcript
+}
+
+// TokenString returns a compact per-model token string (e.g. "opus:125k/3k").
+func (a *Agent) TokenString() string {
+	return tokenString(a.TokensByModel)
+}
+
+// TotalTokens returns the agent's tokens across all models.
+func (a *Agent) TotalTokens() int {
+	return totalTokens(a.TokensByModel)
+}
+
+// LastActive returns a human-friendly time since the agent's transcript was written.
+func (a *Agent) LastActive() string {
+	if a.ModTime.IsZero() {
+		return "-"
+	}
+	return FormatAge(time.Since(a.ModTime))
 }
 
 // ShortID returns a display-friendly ID.
@@ -88,6 +111,12 @@ func (a *Agent) ShortID() string {
 	return a.ID
 }
 
+// AgentID returns the ID Claude Code assigned to a subagent, which its
+// transcript file name carries after the "agent-" prefix.
+func (a *Agent) AgentID() string {
+	return strings.TrimPrefix(a.ID, "agent-")
+}
+
 // DisplayName returns a human-friendly name for the agent.
 func (a *Agent) DisplayName() string {
 	switch a.Type {
@@ -2,6 +2,7 @@ package model_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Curt-Park/claudeview/internal/model"
 )
@@ -68,3 +69,20 @@ func TestAgentTreePrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestAgentTokensAndLastActive(t *testing.T) {
+	a := &model.Agent{}
+	if a.TokenString() != "-" || a.LastActive() != "-" {
+		t.Errorf("empty agent: TokenString %q, LastActive %q, want -", a.TokenString(), a.LastActive())
+	}
+	a = &model.Agent{
+		TokensByModel: map[string]model.TokenCount{"claude-haiku-4-5": {InputTokens: 2000, OutputTokens: 300}},
+		ModTime:       time.Now().Add(-2 * time.Minute),
+	}
+	if got := a.TokenString(); got != "haiku:2k/300" {
+		t.Errorf("TokenString() = %q", got)
+	}
+	if got := a.LastActive(); got != "2m" {
+		t.Errorf("LastActive() = %q, want 2m", got)
+	}
+}
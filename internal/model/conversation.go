@@ -0,0 +1,111 @@
+package model
+
+// ConversationTree is the message tree of a transcript at turn granularity.
+// Rewinds, edited prompts and retried responses give a turn more than one
+// continuation; every leaf ends one branch. The branch ending at the most
+// recently written turn is the active one, the others are abandoned.
+type ConversationTree struct {
+	turns    []Turn
+	parent   []int   // index of each turn's parent, -1 for the root
+	children [][]int // continuations of each turn in file order
+	leaves   []int   // leaf indices in file order
+}
+
+// Fork is a branch point on a branch: the turn with UUID has Count
+// continuations and the branch follows the Choice-th (0-based) of them.
+// Abandoned is true when that continuation is not on the active branch.
+type Fork struct {
+	UUID      string
+	Choice    int
+	Count     int
+	Abandoned bool
+}
+
+// BuildConversationTree links turns by ParentUUID. A turn whose parent is
+// missing or unknown continues the turn before it, so transcripts without tree
+// links yield a single linear branch. Sidechain turns are dropped unless the
+// transcript consists only of them (subagent transcripts).
+func BuildConversationTree(turns []Turn) *ConversationTree {
+	mainline := turns[:0:0]
+	for _, t := range turns {
+		if !t.Sidechain {
+			mainline = append(mainline, t)
+		}
+	}
+	if len(mainline) > 0 {
+		turns = mainline
+	}
+
+	t := &ConversationTree{
+		turns:    turns,
+		parent:   make([]int, len(turns)),
+		children: make([][]int, len(turns)),
+	}
+	byUUID := make(map[string]int, len(turns))
+	for i, turn := range turns {
+		p, ok := byUUID[turn.ParentUUID]
+		if turn.ParentUUID == "" || !ok {
+			p = i - 1
+		}
+		t.parent[i] = p
+		if p >= 0 {
+			t.children[p] = append(t.children[p], i)
+		}
+		if _, dup := byUUID[turn.UUID]; turn.UUID != "" && !dup {
+			byUUID[turn.UUID] = i
+		}
+	}
+	for i := range turns {
+		if len(t.children[i]) == 0 {
+			t.leaves = append(t.leaves, i)
+		}
+	}
+	return t
+}
+
+// Turns returns every turn in the tree in file order.
+func (t *ConversationTree) Turns() []Turn {
+	return t.turns
+}
+
+// BranchCount returns the number of branches (at least 1 for a non-empty tree).
+func (t *ConversationTree) BranchCount() int {
+	return len(t.leaves)
+}
+
+// ActiveBranch returns the index of the branch ending at the latest turn.
+func (t *ConversationTree) ActiveBranch() int {
+	return max(len(t.leaves)-1, 0)
+}
+
+// Branch returns the turns on branch i from the root to its leaf, and the
+// forks along the way. Out-of-range indices return nil.
+func (t *ConversationTree) Branch(i int) ([]Turn, []Fork) {
+	if i < 0 || i >= len(t.leaves) {
+		return nil, nil
+	}
+	var path []int
+	for n := t.leaves[i]; n >= 0; n = t.parent[n] {
+		path = append(path, n)
+	}
+	active := make(map[int]bool)
+	for n := t.leaves[t.ActiveBranch()]; n >= 0; n = t.parent[n] {
+		active[n] = true
+	}
+
+	turns := make([]Turn, 0, len(path))
+	var forks []Fork
+	for k := len(path) - 1; k >= 0; k-- {
+		n := path[k]
+		turns = append(turns, t.turns[n])
+		if kids := t.children[n]; len(kids) > 1 && k > 0 {
+			next := path[k-1]
+			for c, kid := range kids {
+				if kid == next {
+					forks = append(forks, Fork{UUID: t.turns[n].UUID, Choice: c, Count: len(kids), Abandoned: !active[next]})
+				}
+			}
+		}
+	}
+	return turns, forks
+}
@@ -0,0 +1,118 @@
+package model_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+func texts(turns []model.Turn) []string {
+	out := make([]string, len(turns))
+	for i, t := range turns {
+		out[i] = t.Text
+	}
+	return out
+}
+
+func TestConversationTreeLinearWithoutLinks(t *testing.T) {
+	turns := []model.Turn{{Text: "a"}, {Text: "b"}, {Text: "c"}}
+	tree := model.BuildConversationTree(turns)
+	if tree.BranchCount() != 1 {
+		t.Fatalf("BranchCount() = %d, want 1", tree.BranchCount())
+	}
+	got, forks := tree.Branch(tree.ActiveBranch())
+	if !slices.Equal(texts(got), []string{"a", "b", "c"}) || len(forks) != 0 {
+		t.Errorf("Branch = %v %v, want linear a,b,c without forks", texts(got), forks)
+	}
+}
+
+func TestConversationTreeBranches(t *testing.T) {
+	// u1 → a1 → u2 → a2       (abandoned)
+	//          └→ u3 → a3     (edited prompt, abandoned retry)
+	//                └→ a4    (retry, active)
+	turns := []model.Turn{
+		{Text: "u1", UUID: "u1"},
+		{Text: "a1", UUID: "a1", ParentUUID: "u1"},
+		{Text: "u2", UUID: "u2", ParentUUID: "a1"},
+		{Text: "a2", UUID: "a2", ParentUUID: "u2"},
+		{Text: "u3", UUID: "u3", ParentUUID: "a1"},
+		{Text: "a3", UUID: "a3", ParentUUID: "u3"},
+		{Text: "a4", UUID: "a4", ParentUUID: "u3"},
+	}
+	tree := model.BuildConversationTree(turns)
+	if tree.BranchCount() != 3 {
+		t.Fatalf("BranchCount() = %d, want 3", tree.BranchCount())
+	}
+	if tree.ActiveBranch() != 2 {
+		t.Errorf("ActiveBranch() = %d, want 2", tree.ActiveBranch())
+	}
+
+	tests := []struct {
+		branch int
+		want   []string
+		forks  []model.Fork
+	}{
+		{0, []string{"u1", "a1", "u2", "a2"}, []model.Fork{{UUID: "a1", Choice: 0, Count: 2, Abandoned: true}}},
+		{1, []string{"u1", "a1", "u3", "a3"}, []model.Fork{
+			{UUID: "a1", Choice: 1, Count: 2},
+			{UUID: "u3", Choice: 0, Count: 2, Abandoned: true},
+		}},
+		{2, []string{"u1", "a1", "u3", "a4"}, []model.Fork{
+			{UUID: "a1", Choice: 1, Count: 2},
+			{UUID: "u3", Choice: 1, Count: 2},
+		}},
+	}
+	for _, tt := range tests {
+		got, forks := tree.Branch(tt.branch)
+		if !slices.Equal(texts(got), tt.want) {
+			t.Errorf("Branch(%d) turns = %v, want %v", tt.branch, texts(got), tt.want)
+		}
+		if len(forks) != len(tt.forks) {
+			t.Errorf("Branch(%d) forks = %+v, want %+v", tt.branch, forks, tt.forks)
+			continue
+		}
+		for i := range forks {
+			if forks[i] != tt.forks[i] {
+				t.Errorf("Branch(%d) fork %d = %+v, want %+v", tt.branch, i, forks[i], tt.forks[i])
+			}
+		}
+	}
+	if got, _ := tree.Branch(5); got != nil {
+		t.Errorf("Branch(5) = %v, want nil", got)
+	}
+}
+
+func TestConversationTreeUnknownParentContinuesPreviousTurn(t *testing.T) {
+	turns := []model.Turn{
+		{Text: "a", UUID: "a"},
+		{Text: "b", UUID: "b", ParentUUID: "missing"},
+		{Text: "c"},
+	}
+	tree := model.BuildConversationTree(turns)
+	got, _ := tree.Branch(0)
+	if tree.BranchCount() != 1 || !slices.Equal(texts(got), []string{"a", "b", "c"}) {
+		t.Errorf("expected a single linear branch, got %d branches %v", tree.BranchCount(), texts(got))
+	}
+}
+
+func TestConversationTreeSidechains(t *testing.T) {
+	mixed := []model.Turn{
+		{Text: "main", UUID: "m1"},
+		{Text: "side", UUID: "s1", Sidechain: true},
+		{Text: "main2", UUID: "m2", ParentUUID: "m1"},
+	}
+	got, _ := model.BuildConversationTree(mixed).Branch(0)
+	if !slices.Equal(texts(got), []string{"main", "main2"}) {
+		t.Errorf("sidechain turns should be dropped from the main conversation, got %v", texts(got))
+	}
+
+	subagent := []model.Turn{
+		{Text: "task", UUID: "s1", Sidechain: true},
+		{Text: "answer", UUID: "s2", ParentUUID: "s1", Sidechain: true},
+	}
+	got, _ = model.BuildConversationTree(subagent).Branch(0)
+	if !slices.Equal(texts(got), []string{"task", "answer"}) {
+		t.Errorf("all-sidechain transcripts should be kept, got %v", texts(got))
+	}
+}
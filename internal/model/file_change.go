@@ -0,0 +1,163 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// FileEdit is one string replacement made by an Edit or MultiEdit call.
+type FileEdit struct {
+	Old        string `json:"old_string"`
+	New        string `json:"new_string"`
+	ReplaceAll bool   `json:"replace_all"`
+}
+
+// FileChange is what an Edit, MultiEdit or Write call does to a file: either
+// Edits applied in order or, for Write, the whole new Content.
+type FileChange struct {
+	Path    string
+	Edits   []FileEdit
+	Content string
+	IsWrite bool
+}
+
+// FileChange returns the change an Edit, MultiEdit or Write call makes. ok is
+// false for other tools and for input that does not parse.
+func (tc *ToolCall) FileChange() (FileChange, bool) {
+	var in struct {
+		FilePath string     `json:"file_path"`
+		Content  *string    `json:"content"`
+		Edits    []FileEdit `json:"edits"`
+		FileEdit
+	}
+	if tc.Input == nil || json.Unmarshal(tc.Input, &in) != nil || in.FilePath == "" {
+		return FileChange{}, false
+	}
+	switch tc.Name {
+	case "Edit":
+		return FileChange{Path: in.FilePath, Edits: []FileEdit{in.FileEdit}}, true
+	case "MultiEdit":
+		return FileChange{Path: in.FilePath, Edits: in.Edits}, len(in.Edits) > 0
+	case "Write":
+		if in.Content == nil {
+			return FileChange{}, false
+		}
+		return FileChange{Path: in.FilePath, Content: *in.Content, IsWrite: true}, true
+	}
+	return FileChange{}, false
+}
+
+// Apply returns content after the change. ok is false when an edit's old
+// string is not in the content, so the result would not be the real file.
+func (c FileChange) Apply(content string) (string, bool) {
+	if c.IsWrite {
+		return c.Content, true
+	}
+	for _, e := range c.Edits {
+		if e.Old == "" || !strings.Contains(content, e.Old) {
+			return "", false
+		}
+		n := 1
+		if e.ReplaceAll {
+			n = -1
+		}
+		content = strings.Replace(content, e.Old, e.New, n)
+	}
+	return content, true
+}
+
+// FilePath returns the file_path input of a call, "" when it has none.
+func (tc *ToolCall) FilePath() string {
+	var in struct {
+		FilePath string `json:"file_path"`
+	}
+	if tc.Input == nil || json.Unmarshal(tc.Input, &in) != nil {
+		return ""
+	}
+	return in.FilePath
+}
+
+// FileLine returns the line of its file a call is about, 0 when unknown: a
+// Read's offset, or for an Edit or MultiEdit the line its first new string
+// starts at in the file as it is now.
+func (tc *ToolCall) FileLine() int {
+	if tc.Input == nil {
+		return 0
+	}
+	if tc.Name == "Read" {
+		var in struct {
+			Offset int `json:"offset"`
+		}
+		if json.Unmarshal(tc.Input, &in) == nil && in.Offset > 0 {
+			return in.Offset
+		}
+		return 0
+	}
+	change, ok := tc.FileChange()
+	if !ok || change.IsWrite || change.Edits[0].New == "" {
+		return 0
+	}
+	data, err := os.ReadFile(change.Path)
+	if err != nil {
+		return 0
+	}
+	i := strings.Index(string(data), change.Edits[0].New)
+	if i < 0 {
+		return 0
+	}
+	return strings.Count(string(data[:i]), "\n") + 1
+}
+
+// readLineLimit is how many lines Read returns without a limit; a result that
+// long may have cut the file short.
+const readLineLimit = 2000
+
+// ReadContent returns the file a Read call returned, without the line
+// numbers the result carries. ok is false for other tools, failed calls, and
+// reads of part of a file (offset or limit).
+func (tc *ToolCall) ReadContent() (path, content string, ok bool) {
+	if tc.Name != "Read" || tc.IsError || tc.Input == nil {
+		return "", "", false
+	}
+	var in struct {
+		FilePath string `json:"file_path"`
+		Offset   *int   `json:"offset"`
+		Limit    *int   `json:"limit"`
+	}
+	if json.Unmarshal(tc.Input, &in) != nil || in.FilePath == "" || in.Offset != nil || in.Limit != nil {
+		return "", "", false
+	}
+	var lines []string
+	for _, l := range strings.Split(tc.ResultText(), "\n") {
+		text, ok := stripLineNumber(l)
+		if !ok {
+			// Anything else, such as an appended reminder, ends the file.
+			break
+		}
+		lines = append(lines, text)
+	}
+	if len(lines) == 0 || len(lines) >= readLineLimit {
+		return "", "", false
+	}
+	return in.FilePath, strings.Join(lines, "\n"), true
+}
+
+// stripLineNumber returns a Read result line without its line number: the
+// number is right-aligned and followed by a tab (cat -n) or an arrow.
+func stripLineNumber(l string) (string, bool) {
+	l = strings.TrimLeft(l, " ")
+	i := 0
+	for i < len(l) && l[i] >= '0' && l[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return "", false
+	}
+	for _, sep := range []string{"\t", "→"} {
+		if text, ok := strings.CutPrefix(l[i:], sep); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
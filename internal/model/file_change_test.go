@@ -0,0 +1,97 @@
+package model_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+func TestToolCallFileChange(t *testing.T) {
+	edit := &model.ToolCall{Name: "Edit", Input: mustJSON(map[string]any{
+		"file_path": "/a.go", "old_string": "x", "new_string": "y", "replace_all": true,
+	})}
+	c, ok := edit.FileChange()
+	if !ok || c.Path != "/a.go" || c.IsWrite || len(c.Edits) != 1 || c.Edits[0] != (model.FileEdit{Old: "x", New: "y", ReplaceAll: true}) {
+		t.Errorf("Edit: FileChange() = %+v, %v", c, ok)
+	}
+
+	multi := &model.ToolCall{Name: "MultiEdit", Input: mustJSON(map[string]any{
+		"file_path": "/a.go",
+		"edits":     []map[string]any{{"old_string": "a", "new_string": "b"}, {"old_string": "c", "new_string": "d"}},
+	})}
+	if c, ok := multi.FileChange(); !ok || len(c.Edits) != 2 || c.Edits[1].New != "d" {
+		t.Errorf("MultiEdit: FileChange() = %+v, %v", c, ok)
+	}
+
+	write := &model.ToolCall{Name: "Write", Input: mustJSON(map[string]any{"file_path": "/a.go", "content": ""})}
+	if c, ok := write.FileChange(); !ok || !c.IsWrite || c.Content != "" {
+		t.Errorf("Write of an empty file: FileChange() = %+v, %v", c, ok)
+	}
+
+	bash := &model.ToolCall{Name: "Bash", Input: mustJSON(map[string]any{"command": "ls"})}
+	if _, ok := bash.FileChange(); ok {
+		t.Error("Bash: FileChange() ok, want false")
+	}
+}
+
+func TestFileChangeApply(t *testing.T) {
+	c := model.FileChange{Edits: []model.FileEdit{{Old: "a", New: "b"}, {Old: "x", New: "y", ReplaceAll: true}}}
+	if got, ok := c.Apply("a a x x"); !ok || got != "b a y y" {
+		t.Errorf("Apply = %q, %v, want %q", got, ok, "b a y y")
+	}
+	if _, ok := c.Apply("no match"); ok {
+		t.Error("Apply with a missing old string: ok, want false")
+	}
+	w := model.FileChange{IsWrite: true, Content: "new"}
+	if got, ok := w.Apply("old"); !ok || got != "new" {
+		t.Errorf("Write Apply = %q, %v", got, ok)
+	}
+}
+
+func TestToolCallReadContent(t *testing.T) {
+	read := func(input map[string]any, result string) *model.ToolCall {
+		return &model.ToolCall{Name: "Read", Input: mustJSON(input), Result: mustJSON(result)}
+	}
+	tests := []struct {
+		name   string
+		call   *model.ToolCall
+		want   string
+		wantOK bool
+	}{
+		{"cat -n tabs", read(map[string]any{"file_path": "/a"}, "     1\tpackage a\n     2\t\n     3\tfunc f() {}"), "package a\n\nfunc f() {}", true},
+		{"arrows", read(map[string]any{"file_path": "/a"}, "     1→one\n     2→two\n\n<system-reminder>x</system-reminder>"), "one\ntwo", true},
+		{"partial read", read(map[string]any{"file_path": "/a", "offset": 10}, "    10\tten"), "", false},
+		{"no numbered lines", read(map[string]any{"file_path": "/a"}, "File does not exist."), "", false},
+	}
+	for _, tt := range tests {
+		path, got, ok := tt.call.ReadContent()
+		if ok != tt.wantOK || got != tt.want || (ok && path != "/a") {
+			t.Errorf("%s: ReadContent() = %q, %q, %v; want %q, %v", tt.name, path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestToolCallFileLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.go")
+	if err := os.WriteFile(path, []byte("package a\n\nfunc f() {\n\treturn\n}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		call *model.ToolCall
+		want int
+	}{
+		{"read offset", &model.ToolCall{Name: "Read", Input: mustJSON(map[string]any{"file_path": path, "offset": 40})}, 40},
+		{"whole read", &model.ToolCall{Name: "Read", Input: mustJSON(map[string]any{"file_path": path})}, 0},
+		{"edit", &model.ToolCall{Name: "Edit", Input: mustJSON(map[string]any{"file_path": path, "old_string": "x", "new_string": "func f() {"})}, 3},
+		{"edit gone", &model.ToolCall{Name: "Edit", Input: mustJSON(map[string]any{"file_path": path, "old_string": "x", "new_string": "func g()"})}, 0},
+		{"write", &model.ToolCall{Name: "Write", Input: mustJSON(map[string]any{"file_path": path, "content": "x"})}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.call.FileLine(); got != tt.want {
+			t.Errorf("%s: FileLine() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -37,14 +38,42 @@ func FormatTokenInOut(in, out int) string {
 	return FormatTokenCount(in) + "/" + FormatTokenCount(out)
 }
 
-// FormatTokenInOutCache formats input/cache-read/output token counts.
-// When cache is zero, omits the +cache section (e.g. "50k/26k").
-// When cache is non-zero, returns "50k+7.4M/26k".
-func FormatTokenInOutCache(in, cache, out int) string {
-	if cache == 0 {
-		return FormatTokenCount(in) + "/" + FormatTokenCount(out)
+// FormatTokenInOutCache formats input/cache-write/cache-read/output token
+// counts. Zero cache sections are omitted (e.g. "50k/26k"); cache reads follow
+// the input ("50k+7.4M/26k") and cache writes are marked with "w"
+// ("50k+w120k+7.4M/26k").
+func FormatTokenInOutCache(in, cacheWrite, cacheRead, out int) string {
+	s := FormatTokenCount(in)
+	if cacheWrite > 0 {
+		s += "+w" + FormatTokenCount(cacheWrite)
+	}
+	if cacheRead > 0 {
+		s += "+" + FormatTokenCount(cacheRead)
+	}
+	return s + "/" + FormatTokenCount(out)
+}
+
+// FormatCost formats an estimated USD cost (e.g. "$0.42", "$12.30", "$1,204").
+// Zero renders as "-" and sub-cent costs as "<$0.01".
+func FormatCost(usd float64) string {
+	switch {
+	case usd <= 0:
+		return "-"
+	case usd < 0.01:
+		return "<$0.01"
+	case usd < 1000:
+		return fmt.Sprintf("$%.2f", usd)
+	default:
+		digits := strconv.Itoa(int(usd + 0.5))
+		var b strings.Builder
+		for i, d := range digits {
+			if i > 0 && (len(digits)-i)%3 == 0 {
+				b.WriteByte(',')
+			}
+			b.WriteRune(d)
+		}
+		return "$" + b.String()
 	}
-	return FormatTokenCount(in) + "+" + FormatTokenCount(cache) + "/" + FormatTokenCount(out)
 }
 
 // ShortModelName extracts a short identifier from a model name.
@@ -8,18 +8,40 @@ import (
 
 func TestFormatTokenInOutCache(t *testing.T) {
 	tests := []struct {
-		in, cache, out int
-		want           string
+		in, write, read, out int
+		want                 string
 	}{
-		{50000, 7400000, 26000, "50k+7.4M/26k"},
-		{243000, 0, 26000, "243k/26k"}, // no cache: omit +section
-		{0, 0, 0, "0/0"},
+		{50000, 0, 7400000, 26000, "50k+7.4M/26k"},
+		{243000, 0, 0, 26000, "243k/26k"}, // no cache: omit +section
+		{0, 0, 0, 0, "0/0"},
+		{50000, 120000, 7400000, 26000, "50k+w120k+7.4M/26k"},
+		{3000, 8000, 0, 500, "3k+w8k/500"},
 	}
 	for _, tt := range tests {
-		got := model.FormatTokenInOutCache(tt.in, tt.cache, tt.out)
+		got := model.FormatTokenInOutCache(tt.in, tt.write, tt.read, tt.out)
 		if got != tt.want {
-			t.Errorf("FormatTokenInOutCache(%d,%d,%d) = %q, want %q",
-				tt.in, tt.cache, tt.out, got, tt.want)
+			t.Errorf("FormatTokenInOutCache(%d,%d,%d,%d) = %q, want %q",
+				tt.in, tt.write, tt.read, tt.out, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCost(t *testing.T) {
+	tests := []struct {
+		usd  float64
+		want string
+	}{
+		{0, "-"},
+		{0.004, "<$0.01"},
+		{0.42, "$0.42"},
+		{12.3, "$12.30"},
+		{999.99, "$999.99"},
+		{1204.4, "$1,204"},
+		{2_500_000, "$2,500,000"},
+	}
+	for _, tt := range tests {
+		if got := model.FormatCost(tt.usd); got != tt.want {
+			t.Errorf("FormatCost(%v) = %q, want %q", tt.usd, got, tt.want)
 		}
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -255,6 +256,74 @@ func ReadPluginItemContent(item *PluginItem) string {
 	}
 }
 
+// PluginItemFile returns the file a plugin item is defined in and the line
+// its definition starts at (0 when the whole file is the item). path is ""
+// for items without a file, such as pre-filled demo content.
+func PluginItemFile(item *PluginItem) (path string, line int) {
+	if item.Content != "" {
+		return "", 0
+	}
+	cd := contentDir(item.CacheDir)
+	switch item.Category {
+	case "skill":
+		skillDir := filepath.Join(cd, "skills", item.Name)
+		if p := filepath.Join(skillDir, "SKILL.md"); fileExists(p) {
+			return p, 0
+		}
+		entries, _ := os.ReadDir(skillDir)
+		for _, e := range entries {
+			if !e.IsDir() && filepath.Ext(e.Name()) == ".md" {
+				return filepath.Join(skillDir, e.Name()), 0
+			}
+		}
+	case "command", "agent":
+		if p := filepath.Join(cd, item.Category+"s", item.Name+".md"); fileExists(p) {
+			return p, 0
+		}
+	case "hook":
+		hooksDir := filepath.Join(cd, "hooks")
+		if _, ok := loadHooksMap(item.CacheDir)[item.Name]; ok {
+			p := filepath.Join(hooksDir, "hooks.json")
+			return p, keyLine(p, item.Name)
+		}
+		entries, _ := os.ReadDir(hooksDir)
+		for _, e := range entries {
+			if !e.IsDir() && strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())) == item.Name {
+				return filepath.Join(hooksDir, e.Name()), 0
+			}
+		}
+	case "mcp":
+		for _, p := range []string{
+			filepath.Join(cd, ".mcp.json"),
+			filepath.Join(item.CacheDir, ".claude-plugin", "plugin.json"),
+		} {
+			if line := keyLine(p, item.Name); line > 0 {
+				return p, line
+			}
+		}
+	}
+	return "", 0
+}
+
+// keyLine returns the 1-based line of the first "key" in a JSON file, 0 when
+// it is not there.
+func keyLine(path, key string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	i := bytes.Index(data, []byte(strconv.Quote(key)))
+	if i < 0 {
+		return 0
+	}
+	return bytes.Count(data[:i], []byte("\n")) + 1
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
 // listDirNames returns names of subdirectories in dir.
 func listDirNames(dir string) []string {
 	entries, err := os.ReadDir(dir)
@@ -390,6 +390,35 @@ func TestReadPluginItemContent_MCPNormalizesIndentation(t *testing.T) {
 	}
 }
 
+func TestPluginItemFile(t *testing.T) {
+	base := makeTempDir(t)
+	if err := os.MkdirAll(filepath.Join(base, "commands"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(base, "commands", "commit.md"))
+	mcp := "{\n  \"mcpServers\": {\n    \"a\": {},\n    \"b\": {}\n  }\n}"
+	if err := os.WriteFile(filepath.Join(base, ".mcp.json"), []byte(mcp), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		item     *model.PluginItem
+		wantPath string
+		wantLine int
+	}{
+		{&model.PluginItem{Name: "commit", Category: "command", CacheDir: base}, filepath.Join(base, "commands", "commit.md"), 0},
+		{&model.PluginItem{Name: "b", Category: "mcp", CacheDir: base}, filepath.Join(base, ".mcp.json"), 4},
+		{&model.PluginItem{Name: "missing", Category: "agent", CacheDir: base}, "", 0},
+		{&model.PluginItem{Name: "demo", Category: "skill", Content: "x"}, "", 0},
+	}
+	for _, tt := range tests {
+		path, line := model.PluginItemFile(tt.item)
+		if path != tt.wantPath || line != tt.wantLine {
+			t.Errorf("PluginItemFile(%s %s) = %q, %d; want %q, %d", tt.item.Category, tt.item.Name, path, line, tt.wantPath, tt.wantLine)
+		}
+	}
+}
+
 func TestCountMCPs(t *testing.T) {
 	t.Run("missing file returns 0", func(t *testing.T) {
 		if got := model.CountMCPs("/nonexistent/path"); got != 0 {
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Process is a running Claude Code process attached to a session.
+type Process struct {
+	PID        int
+	StartTime  time.Time
+	CPUPercent float64
+}
+
+// Uptime returns how long the process has been running (e.g. "2h").
+func (p *Process) Uptime() string {
+	return FormatAge(time.Since(p.StartTime))
+}
+
+// CPU returns the CPU usage as a percentage (e.g. "3.2%").
+func (p *Process) CPU() string {
+	return fmt.Sprintf("%.1f%%", p.CPUPercent)
+}
+
+// Summary returns a one-line description, e.g. "pid 4242 · up 2h · cpu 3.2%".
+func (p *Process) Summary() string {
+	return fmt.Sprintf("pid %d · up %s · cpu %s", p.PID, p.Uptime(), p.CPU())
+}
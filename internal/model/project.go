@@ -10,6 +10,15 @@ type Project struct {
 	LastSeen time.Time
 }
 
+// Cost returns the estimated USD cost of all sessions in the project.
+func (p *Project) Cost() float64 {
+	var total float64
+	for _, s := range p.Sessions {
+		total += s.Cost()
+	}
+	return total
+}
+
 // SessionCount returns the number of sessions in this project.
 func (p *Project) SessionCount() int {
 	return len(p.Sessions)
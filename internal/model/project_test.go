@@ -25,3 +25,17 @@ func TestProjectSessionCount(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectCost(t *testing.T) {
+	p := &model.Project{Sessions: []*model.Session{
+		{TokensByModel: map[string]model.TokenCount{"claude-opus-4-6": {CostUSD: 1.5}}},
+		{TokensByModel: map[string]model.TokenCount{
+			"claude-opus-4-6":   {CostUSD: 0.25},
+			"claude-sonnet-4-6": {CostUSD: 0.25},
+		}},
+		{},
+	}}
+	if got := p.Cost(); got != 2 {
+		t.Errorf("Cost() = %v, want 2", got)
+	}
+}
@@ -6,6 +6,8 @@ type ResourceType string
 const (
 	ResourceProjects         ResourceType = "projects"
 	ResourceSessions         ResourceType = "sessions"
+	ResourceAgents           ResourceType = "agents"
+	ResourceToolCalls        ResourceType = "tool-calls"
 	ResourcePlugins          ResourceType = "plugins"
 	ResourceMemory           ResourceType = "memories"
 	ResourcePluginDetail     ResourceType = "plugin-detail"
@@ -14,4 +16,5 @@ const (
 	ResourceHistory          ResourceType = "history"
 	ResourceHistoryDetail    ResourceType = "history-detail"
 	ResourceToolCallDetail   ResourceType = "tool-call-detail"
+	ResourceSearch           ResourceType = "search"
 )
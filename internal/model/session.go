@@ -7,11 +7,28 @@ import (
 	"time"
 )
 
-// TokenCount holds per-model token usage.
+// TokenCount holds per-model token usage. InputTokens excludes cache writes
+// and cache reads, which are billed at their own rates.
 type TokenCount struct {
-	InputTokens     int
-	CacheReadTokens int
-	OutputTokens    int
+	InputTokens      int
+	CacheWriteTokens int
+	CacheReadTokens  int
+	OutputTokens     int
+	CostUSD          float64 // estimated cost of these tokens
+}
+
+// Total returns every input-side and output token.
+func (tc TokenCount) Total() int {
+	return tc.InputTokens + tc.CacheWriteTokens + tc.CacheReadTokens + tc.OutputTokens
+}
+
+// totalTokens sums Total across models.
+func totalTokens(byModel map[string]TokenCount) int {
+	var n int
+	for _, tc := range byModel {
+		n += tc.Total()
+	}
+	return n
 }
 
 // Session represents a Claude Code session.
@@ -32,6 +49,8 @@ type Session struct {
 	StartTime     time.Time
 	EndTime       time.Time
 	ModTime       time.Time
+	Activity      Activity // state at the end of the transcript, for Status
+	Process       *Process // running Claude Code process writing this session; nil if none
 
 	// GroupSessions holds all sessions in the slug group (oldest-first).
 	// nil for solo sessions (no slug or single-member group).
@@ -59,25 +78,71 @@ func (s *Session) LastActive() string {
 	return FormatAge(time.Since(s.ModTime))
 }
 
+// Status returns the session's live status, inferred at call time so it
+// decays (e.g. thinking → pending → ended) without re-reading the transcript.
+// A session whose process is still running never counts as ended.
+func (s *Session) Status() Status {
+	st := s.Activity.Status(s.ModTime, time.Now(), false)
+	if st == StatusEnded && s.Process != nil {
+		return StatusActive
+	}
+	return st
+}
+
+// PIDString returns the PID of the session's process, or "-".
+func (s *Session) PIDString() string {
+	if s.Process == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", s.Process.PID)
+}
+
+// CPUString returns the CPU usage of the session's process, or "-".
+func (s *Session) CPUString() string {
+	if s.Process == nil {
+		return "-"
+	}
+	return s.Process.CPU()
+}
+
 // TokenString returns a compact per-model token string (e.g. "opus:125k sonnet:50k").
 func (s *Session) TokenString() string {
-	if len(s.TokensByModel) == 0 {
+	return tokenString(s.TokensByModel)
+}
+
+// tokenString formats per-model token counts sorted by model name, or "-".
+func tokenString(byModel map[string]TokenCount) string {
+	if len(byModel) == 0 {
 		return "-"
 	}
-	models := make([]string, 0, len(s.TokensByModel))
-	for m := range s.TokensByModel {
+	models := make([]string, 0, len(byModel))
+	for m := range byModel {
 		models = append(models, m)
 	}
 	sort.Strings(models)
 
 	var parts []string
 	for _, m := range models {
-		tc := s.TokensByModel[m]
-		parts = append(parts, fmt.Sprintf("%s:%s", ShortModelName(m), FormatTokenInOutCache(tc.InputTokens, tc.CacheReadTokens, tc.OutputTokens)))
+		tc := byModel[m]
+		parts = append(parts, fmt.Sprintf("%s:%s", ShortModelName(m), FormatTokenInOutCache(tc.InputTokens, tc.CacheWriteTokens, tc.CacheReadTokens, tc.OutputTokens)))
 	}
 	return strings.Join(parts, " ")
 }
 
+// TotalTokens returns the session's tokens across all models.
+func (s *Session) TotalTokens() int {
+	return totalTokens(s.TokensByModel)
+}
+
+// Cost returns the estimated USD cost of the session across all models.
+func (s *Session) Cost() float64 {
+	var total float64
+	for _, tc := range s.TokensByModel {
+		total += tc.CostUSD
+	}
+	return total
+}
+
 // TopicShort returns a normalized, truncated topic string.
 // Newlines are replaced with spaces (matching claude -r style) so the full
 // content is visible on a single line.
@@ -93,13 +158,33 @@ func (s *Session) TopicShort(maxLen int) string {
 	return topic
 }
 
-// MetaLine returns a compact metadata string: "branch · size".
+// CacheHitRatio returns the share of input-side tokens served from the prompt
+// cache across all models, and false when the session has no input tokens.
+func (s *Session) CacheHitRatio() (float64, bool) {
+	var read, total int
+	for _, tc := range s.TokensByModel {
+		read += tc.CacheReadTokens
+		total += tc.InputTokens + tc.CacheWriteTokens + tc.CacheReadTokens
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(read) / float64(total), true
+}
+
+// MetaLine returns a compact metadata string: "branch · size · cache 87% · up 2h".
 func (s *Session) MetaLine() string {
-	size := FormatSize(s.FileSize)
-	if s.Branch == "" {
-		return size
+	parts := []string{FormatSize(s.FileSize)}
+	if s.Branch != "" {
+		parts = append([]string{s.Branch}, parts...)
+	}
+	if r, ok := s.CacheHitRatio(); ok {
+		parts = append(parts, fmt.Sprintf("cache %d%%", int(r*100+0.5)))
+	}
+	if s.Process != nil {
+		parts = append(parts, "up "+s.Process.Uptime())
 	}
-	return s.Branch + " · " + size
+	return strings.Join(parts, " · ")
 }
 
 // ShortID returns the first 8 chars of the session ID.
@@ -59,6 +59,85 @@ func TestSessionTokenStringWithCache(t *testing.T) {
 	}
 }
 
+func TestSessionTokenStringWithCacheWrites(t *testing.T) {
+	s := &model.Session{
+		TokensByModel: map[string]model.TokenCount{
+			"claude-opus-4-6": {InputTokens: 12000, CacheWriteTokens: 108000, CacheReadTokens: 1850000, OutputTokens: 25200},
+		},
+	}
+	if got, want := s.TokenString(), "opus:12k+w108k+1.9M/25k"; got != want {
+		t.Errorf("TokenString() = %q, want %q", got, want)
+	}
+}
+
+func TestSessionCacheHitRatio(t *testing.T) {
+	s := &model.Session{
+		TokensByModel: map[string]model.TokenCount{
+			"claude-opus-4-6":   {InputTokens: 100, CacheWriteTokens: 300, CacheReadTokens: 1200},
+			"claude-sonnet-4-6": {InputTokens: 100, CacheReadTokens: 300},
+		},
+	}
+	r, ok := s.CacheHitRatio()
+	if !ok || r != 0.75 {
+		t.Errorf("CacheHitRatio() = %v, %v; want 0.75, true", r, ok)
+	}
+	if _, ok := (&model.Session{}).CacheHitRatio(); ok {
+		t.Error("CacheHitRatio() on empty session should report false")
+	}
+}
+
+func TestSessionMetaLine(t *testing.T) {
+	s := &model.Session{
+		Branch:   "main",
+		FileSize: 2048,
+		TokensByModel: map[string]model.TokenCount{
+			"claude-opus-4-6": {InputTokens: 100, CacheReadTokens: 900},
+		},
+	}
+	if got, want := s.MetaLine(), "main · 2.0KB · cache 90%"; got != want {
+		t.Errorf("MetaLine() = %q, want %q", got, want)
+	}
+	s = &model.Session{FileSize: 2048}
+	if got, want := s.MetaLine(), "2.0KB"; got != want {
+		t.Errorf("MetaLine() = %q, want %q", got, want)
+	}
+}
+
+func TestSessionProcess(t *testing.T) {
+	s := &model.Session{ModTime: time.Now().Add(-time.Hour), FileSize: 2048}
+	if s.Status() != model.StatusEnded || s.PIDString() != "-" || s.CPUString() != "-" {
+		t.Errorf("without process: status %q, pid %q, cpu %q", s.Status(), s.PIDString(), s.CPUString())
+	}
+	s.Process = &model.Process{PID: 4242, StartTime: time.Now().Add(-2 * time.Hour), CPUPercent: 12.34}
+	if got := s.Status(); got != model.StatusActive {
+		t.Errorf("Status() with process = %q, want active", got)
+	}
+	if got := s.PIDString(); got != "4242" {
+		t.Errorf("PIDString() = %q", got)
+	}
+	if got := s.CPUString(); got != "12.3%" {
+		t.Errorf("CPUString() = %q", got)
+	}
+	if got, want := s.MetaLine(), "2.0KB · up 2h"; got != want {
+		t.Errorf("MetaLine() = %q, want %q", got, want)
+	}
+}
+
+func TestSessionCost(t *testing.T) {
+	s := &model.Session{
+		TokensByModel: map[string]model.TokenCount{
+			"claude-opus-4-6":   {InputTokens: 1000, CostUSD: 0.5},
+			"claude-sonnet-4-6": {InputTokens: 1000, CostUSD: 0.25},
+		},
+	}
+	if got := s.Cost(); got != 0.75 {
+		t.Errorf("Cost() = %v, want 0.75", got)
+	}
+	if got := (&model.Session{}).Cost(); got != 0 {
+		t.Errorf("Cost() empty = %v, want 0", got)
+	}
+}
+
 func TestSessionLastActive(t *testing.T) {
 	s := &model.Session{ModTime: time.Now().Add(-5 * time.Minute)}
 	got := s.LastActive()
@@ -4,7 +4,7 @@ import "sort"
 
 // GroupSessionsBySlug collapses sessions sharing a slug into a single representative row.
 // The representative is the newest session (last in asc-sorted group).
-// Aggregated fields: NumTurns, AgentCount, FileSize, TokensByModel.
+// Aggregated fields: NumTurns, AgentCount, FileSize, TokensByModel (all token kinds and cost).
 // Representative.GroupSessions = all sessions in the group (oldest-first).
 // Solo-slug sessions (group of 1) keep GroupSessions = nil.
 // The returned list is sorted by latest ModTime descending.
@@ -63,7 +63,10 @@ func GroupSessionsBySlug(sessions []*Session) []*Session {
 				for m, tc := range s.TokensByModel {
 					cur := merged[m]
 					cur.InputTokens += tc.InputTokens
+					cur.CacheWriteTokens += tc.CacheWriteTokens
+					cur.CacheReadTokens += tc.CacheReadTokens
 					cur.OutputTokens += tc.OutputTokens
+					cur.CostUSD += tc.CostUSD
 					merged[m] = cur
 				}
 			}
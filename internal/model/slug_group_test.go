@@ -76,15 +76,15 @@ func TestGroupSessionsBySlug_Aggregation(t *testing.T) {
 			ID: "s1", Slug: "grp", ModTime: time.Unix(100, 0),
 			NumTurns: 5, AgentCount: 1, FileSize: 1000,
 			TokensByModel: map[string]TokenCount{
-				"opus": {InputTokens: 100, OutputTokens: 200},
+				"opus": {InputTokens: 100, CacheWriteTokens: 10, CacheReadTokens: 1000, OutputTokens: 200, CostUSD: 0.5},
 			},
 		},
 		{
 			ID: "s2", Slug: "grp", ModTime: time.Unix(200, 0),
 			NumTurns: 3, AgentCount: 2, FileSize: 2000,
 			TokensByModel: map[string]TokenCount{
-				"opus":   {InputTokens: 50, OutputTokens: 50},
-				"sonnet": {InputTokens: 300, OutputTokens: 400},
+				"opus":   {InputTokens: 50, CacheWriteTokens: 5, CacheReadTokens: 500, OutputTokens: 50, CostUSD: 0.25},
+				"sonnet": {InputTokens: 300, OutputTokens: 400, CostUSD: 0.125},
 			},
 		},
 	}
@@ -111,10 +111,16 @@ func TestGroupSessionsBySlug_Aggregation(t *testing.T) {
 	if opus.InputTokens != 150 || opus.OutputTokens != 250 {
 		t.Errorf("expected opus tokens 150/250, got %d/%d", opus.InputTokens, opus.OutputTokens)
 	}
+	if opus.CacheWriteTokens != 15 || opus.CacheReadTokens != 1500 {
+		t.Errorf("expected opus cache tokens w15/r1500, got w%d/r%d", opus.CacheWriteTokens, opus.CacheReadTokens)
+	}
 	sonnet := rep.TokensByModel["sonnet"]
 	if sonnet.InputTokens != 300 || sonnet.OutputTokens != 400 {
 		t.Errorf("expected sonnet tokens 300/400, got %d/%d", sonnet.InputTokens, sonnet.OutputTokens)
 	}
+	if opus.CostUSD != 0.75 || rep.Cost() != 0.875 {
+		t.Errorf("expected opus cost 0.75 and total 0.875, got %v/%v", opus.CostUSD, rep.Cost())
+	}
 }
 
 func TestGroupSessionsBySlug_GroupSessionsOrder(t *testing.T) {
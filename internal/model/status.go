@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // Status represents the current state of an agent or task.
 type Status string
 
@@ -18,3 +20,70 @@ const (
 	// StatusCompleted is used for completed agents/tasks.
 	StatusCompleted Status = "completed"
 )
+
+// Live status windows: a transcript written within LiveWindow is still being
+// streamed, and one untouched for longer than StaleAfter has ended whatever
+// its last entry says.
+const (
+	LiveWindow = 30 * time.Second
+	StaleAfter = 10 * time.Minute
+)
+
+// Activity is the state at the end of a transcript, from which live status is
+// inferred together with the transcript's mtime.
+type Activity struct {
+	Waiting      bool     // a prompt or tool results were sent and no response has started
+	ThinkingOnly bool     // the latest assistant entry carried only thinking
+	OpenTools    []string // names of tool calls still awaiting a result
+}
+
+// Status infers the live status of an agent whose transcript was last written
+// at modTime. A running tool wins over a pending response; an idle main agent
+// is active (waiting for the user) while an idle subagent is done.
+func (a Activity) Status(modTime, now time.Time, subagent bool) Status {
+	age := now.Sub(modTime)
+	switch {
+	case age > StaleAfter:
+		if subagent {
+			return StatusDone
+		}
+		return StatusEnded
+	case len(a.OpenTools) > 0:
+		return toolStatus(a.OpenTools)
+	case a.Waiting || a.ThinkingOnly:
+		if age <= LiveWindow {
+			return StatusThinking
+		}
+		return StatusPending
+	case subagent:
+		return StatusDone
+	default:
+		return StatusActive
+	}
+}
+
+// toolStatus classifies running tools: anything that changes state is
+// executing, otherwise waiting on a subagent is running, otherwise reading.
+func toolStatus(names []string) Status {
+	status := StatusReading
+	for _, name := range names {
+		switch {
+		case IsAgentCall(name):
+			status = StatusRunning
+		case !readOnlyTools[name]:
+			return StatusExecuting
+		}
+	}
+	return status
+}
+
+// readOnlyTools are tools that only read files or the web.
+var readOnlyTools = map[string]bool{
+	"Read":         true,
+	"Grep":         true,
+	"Glob":         true,
+	"LS":           true,
+	"NotebookRead": true,
+	"WebFetch":     true,
+	"WebSearch":    true,
+}
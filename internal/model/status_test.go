@@ -0,0 +1,40 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+func TestActivityStatus(t *testing.T) {
+	now := time.Now()
+	fresh := now.Add(-5 * time.Second)
+	quiet := now.Add(-2 * time.Minute)
+	stale := now.Add(-time.Hour)
+	tests := []struct {
+		name     string
+		a        model.Activity
+		modTime  time.Time
+		subagent bool
+		want     model.Status
+	}{
+		{"idle main is active", model.Activity{}, quiet, false, model.StatusActive},
+		{"idle subagent is done", model.Activity{}, fresh, true, model.StatusDone},
+		{"stale main has ended", model.Activity{Waiting: true}, stale, false, model.StatusEnded},
+		{"stale subagent is done", model.Activity{OpenTools: []string{"Bash"}}, stale, true, model.StatusDone},
+		{"fresh prompt is thinking", model.Activity{Waiting: true}, fresh, false, model.StatusThinking},
+		{"thinking-only response is thinking", model.Activity{ThinkingOnly: true}, fresh, true, model.StatusThinking},
+		{"quiet prompt is pending", model.Activity{Waiting: true}, quiet, false, model.StatusPending},
+		{"read tools are reading", model.Activity{OpenTools: []string{"Grep", "Read"}}, quiet, false, model.StatusReading},
+		{"agent call is running", model.Activity{OpenTools: []string{"Agent", "Read"}}, quiet, false, model.StatusRunning},
+		{"other tools are executing", model.Activity{OpenTools: []string{"Agent", "Bash"}}, fresh, false, model.StatusExecuting},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Status(tt.modTime, now, tt.subagent); got != tt.want {
+				t.Errorf("Status() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
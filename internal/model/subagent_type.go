@@ -5,17 +5,22 @@ import "encoding/json"
 // ToolCallInfo holds the minimal fields needed to extract subagent types,
 // allowing a single implementation to work with both model and transcript turn types.
 type ToolCallInfo struct {
-	Name  string
-	Input json.RawMessage
+	Name       string
+	Input      json.RawMessage
+	SubagentID string // agent ID reported by the call's result, if any
+}
+
+// IsAgentCall reports whether a tool call with the given name spawns a subagent.
+func IsAgentCall(name string) bool {
+	return name == "Agent" || name == "Task"
 }
 
 // ExtractAgentTypesFromCalls reads Agent/Task tool calls and returns the subagent_type
-// value for each, in call order. This matches the positional order used by
-// BuildChatItems to interleave subagent turns.
+// value for each, in call order.
 func ExtractAgentTypesFromCalls(calls []ToolCallInfo) []AgentType {
 	var types []AgentType
 	for _, c := range calls {
-		if c.Name != "Agent" && c.Name != "Task" {
+		if !IsAgentCall(c.Name) {
 			continue
 		}
 		types = append(types, AgentTypeFromInput(c.Input))
@@ -23,17 +28,66 @@ func ExtractAgentTypesFromCalls(calls []ToolCallInfo) []AgentType {
 	return types
 }
 
-// ExtractSubagentTypes collects Agent/Task tool calls from Turn slices
-// and delegates to ExtractAgentTypesFromCalls.
-func ExtractSubagentTypes(turns []Turn) []AgentType {
+// SubagentCalls collects the tool calls of assistant turns in order, ready
+// for ExtractAgentTypesFromCalls and MatchSubagents.
+func SubagentCalls(turns []Turn) []ToolCallInfo {
 	var calls []ToolCallInfo
 	for _, t := range turns {
 		if t.Role != "assistant" {
 			continue
 		}
 		for _, tc := range t.ToolCalls {
-			calls = append(calls, ToolCallInfo{Name: tc.Name, Input: tc.Input})
+			calls = append(calls, ToolCallInfo{Name: tc.Name, Input: tc.Input, SubagentID: tc.SubagentID})
+		}
+	}
+	return calls
+}
+
+// MatchSubagents pairs the Agent/Task calls among calls with subagent
+// transcripts identified by agentIDs. byCall holds, for each Agent/Task call
+// in order, the index into agentIDs of the transcript it spawned, or -1.
+//
+// A call that reports an agent ID is matched by ID only, so parallel or
+// failed agents are never attached to the wrong call. Calls without an ID
+// (older transcripts) take the remaining transcripts in order. unmatched lists
+// the transcripts no call claimed, in order.
+func MatchSubagents(calls []ToolCallInfo, agentIDs []string) (byCall, unmatched []int) {
+	byID := make(map[string]int, len(agentIDs))
+	for i, id := range agentIDs {
+		if _, dup := byID[id]; id != "" && !dup {
+			byID[id] = i
+		}
+	}
+	used := make([]bool, len(agentIDs))
+	var pending []int // Agent/Task calls without an agent ID
+	for _, c := range calls {
+		if !IsAgentCall(c.Name) {
+			continue
+		}
+		idx := -1
+		if c.SubagentID == "" {
+			pending = append(pending, len(byCall))
+		} else if i, ok := byID[c.SubagentID]; ok && !used[i] {
+			idx = i
+			used[i] = true
+		}
+		byCall = append(byCall, idx)
+	}
+	next := 0
+	for _, k := range pending {
+		for next < len(used) && used[next] {
+			next++
+		}
+		if next == len(used) {
+			break
+		}
+		byCall[k] = next
+		used[next] = true
+	}
+	for i, u := range used {
+		if !u {
+			unmatched = append(unmatched, i)
 		}
 	}
-	return ExtractAgentTypesFromCalls(calls)
+	return byCall, unmatched
 }
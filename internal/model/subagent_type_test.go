@@ -0,0 +1,58 @@
+package model_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+func TestMatchSubagents(t *testing.T) {
+	tests := []struct {
+		name          string
+		calls         []model.ToolCallInfo
+		agentIDs      []string
+		wantByCall    []int
+		wantUnmatched []int
+	}{
+		{
+			name:          "by ID regardless of order",
+			calls:         []model.ToolCallInfo{{Name: "Agent", SubagentID: "a"}, {Name: "Read"}, {Name: "Agent", SubagentID: "b"}},
+			agentIDs:      []string{"b", "a"},
+			wantByCall:    []int{1, 0},
+			wantUnmatched: nil,
+		},
+		{
+			name:          "unknown ID is not matched positionally",
+			calls:         []model.ToolCallInfo{{Name: "Agent", SubagentID: "gone"}, {Name: "Agent", SubagentID: "b"}},
+			agentIDs:      []string{"stray", "b"},
+			wantByCall:    []int{-1, 1},
+			wantUnmatched: []int{0},
+		},
+		{
+			name:          "calls without ID take the remaining transcripts in order",
+			calls:         []model.ToolCallInfo{{Name: "Task"}, {Name: "Agent", SubagentID: "a"}, {Name: "Task"}},
+			agentIDs:      []string{"a", "x", "y", "z"},
+			wantByCall:    []int{1, 0, 2},
+			wantUnmatched: []int{3},
+		},
+		{
+			name:          "more calls than transcripts",
+			calls:         []model.ToolCallInfo{{Name: "Task"}, {Name: "Task"}},
+			agentIDs:      []string{"x"},
+			wantByCall:    []int{0, -1},
+			wantUnmatched: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byCall, unmatched := model.MatchSubagents(tt.calls, tt.agentIDs)
+			if !slices.Equal(byCall, tt.wantByCall) {
+				t.Errorf("byCall = %v, want %v", byCall, tt.wantByCall)
+			}
+			if !slices.Equal(unmatched, tt.wantUnmatched) {
+				t.Errorf("unmatched = %v, want %v", unmatched, tt.wantUnmatched)
+			}
+		})
+	}
+}
@@ -19,6 +19,8 @@ type ToolCall struct {
 	IsError   bool
 	Timestamp time.Time
 	Duration  time.Duration
+	// SubagentID is the agent ID an Agent/Task call's result reported.
+	SubagentID string
 }
 
 // InputSummary returns a one-line summary of the tool input (not truncated;
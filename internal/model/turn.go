@@ -4,13 +4,21 @@ import "time"
 
 // Turn represents a single conversation turn (user or assistant).
 type Turn struct {
-	Role            string // "user" or "assistant"
-	Text            string
-	Thinking        string
-	ToolCalls       []*ToolCall
-	ModelName       string
-	InputTokens     int
-	CacheReadTokens int
-	OutputTokens    int
-	Timestamp       time.Time
+	Role             string // "user" or "assistant"
+	Text             string
+	Thinking         string
+	ToolCalls        []*ToolCall
+	ModelName        string
+	InputTokens      int // excludes cache writes and reads
+	CacheWriteTokens int
+	CacheReadTokens  int
+	OutputTokens     int
+	CostUSD          float64 // estimated cost of this turn's tokens
+	Timestamp        time.Time
+
+	// Conversation tree links (see ConversationTree). Empty for transcripts
+	// without uuid/parentUuid fields.
+	UUID       string
+	ParentUUID string
+	Sidechain  bool
 }
@@ -0,0 +1,145 @@
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Override file names looked up in the config directory, in order.
+const (
+	jsonFile = "pricing.json"
+	tomlFile = "pricing.toml"
+)
+
+// entry is one user override as written in the file. Pointers distinguish
+// omitted prices from explicit zeros.
+type entry struct {
+	Input      *float64 `json:"input"`
+	CacheWrite *float64 `json:"cache_write"`
+	CacheRead  *float64 `json:"cache_read"`
+	Output     *float64 `json:"output"`
+}
+
+// Load returns the built-in table merged with the overrides in
+// configDir/pricing.json or, if that does not exist, configDir/pricing.toml.
+// A missing file is not an error.
+//
+// Both formats map a model-name pattern to its prices in USD per million
+// tokens:
+//
+//	{"opus": {"input": 5, "output": 25, "cache_write": 6.25, "cache_read": 0.5}}
+//
+//	[opus]
+//	input = 5
+//	output = 25
+//
+// input and output are required; cache_write defaults to 1.25× input and
+// cache_read to 0.1× input, matching Anthropic's standard cache multipliers.
+func Load(configDir string) (*Table, error) {
+	for _, name := range []string{jsonFile, tomlFile} {
+		path := filepath.Join(configDir, name)
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var entries map[string]entry
+		if name == jsonFile {
+			err = json.Unmarshal(data, &entries)
+		} else {
+			entries, err = parseTOML(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		overrides, err := resolve(entries)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return Default().WithOverrides(overrides), nil
+	}
+	return Default(), nil
+}
+
+// resolve validates entries and fills in defaulted cache prices.
+func resolve(entries map[string]entry) (map[string]Price, error) {
+	prices := make(map[string]Price, len(entries))
+	for pattern, e := range entries {
+		if e.Input == nil || e.Output == nil {
+			return nil, fmt.Errorf("%q: input and output prices are required", pattern)
+		}
+		p := Price{Input: *e.Input, Output: *e.Output, CacheWrite: *e.Input * 1.25, CacheRead: *e.Input * 0.1}
+		if e.CacheWrite != nil {
+			p.CacheWrite = *e.CacheWrite
+		}
+		if e.CacheRead != nil {
+			p.CacheRead = *e.CacheRead
+		}
+		prices[pattern] = p
+	}
+	return prices, nil
+}
+
+// parseTOML parses the subset of TOML used by the override file: [table]
+// headers (bare or quoted) followed by key = number lines, with # comments.
+func parseTOML(data []byte) (map[string]entry, error) {
+	entries := make(map[string]entry)
+	section := ""
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if j := strings.IndexByte(line, '#'); j >= 0 {
+			line = strings.TrimSpace(line[:j])
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated table header", i+1)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if unq, err := strconv.Unquote(section); err == nil {
+				section = unq
+			}
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty table name", i+1)
+			}
+			if _, ok := entries[section]; !ok {
+				entries[section] = entry{}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", i+1)
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: key outside of a [model] table", i+1)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		e := entries[section]
+		switch strings.TrimSpace(key) {
+		case "input":
+			e.Input = &v
+		case "cache_write":
+			e.CacheWrite = &v
+		case "cache_read":
+			e.CacheRead = &v
+		case "output":
+			e.Output = &v
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", i+1, strings.TrimSpace(key))
+		}
+		entries[section] = e
+	}
+	return entries, nil
+}
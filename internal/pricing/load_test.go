@@ -0,0 +1,102 @@
+package pricing_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/pricing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadMissingFileUsesDefaults(t *testing.T) {
+	table, err := pricing.Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p, _ := table.Lookup("claude-sonnet-4-6"); p.Input != 3 {
+		t.Errorf("expected built-in sonnet price, got %+v", p)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pricing.json", `{"sonnet": {"input": 2, "output": 10, "cache_read": 0.5}}`)
+	table, err := pricing.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	p, _ := table.Lookup("claude-sonnet-4-6")
+	want := pricing.Price{Input: 2, CacheWrite: 2.5, CacheRead: 0.5, Output: 10}
+	if p != want {
+		t.Errorf("got %+v, want %+v", p, want)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pricing.toml", `
+# team discount
+[opus]
+input = 4
+output = 20 # per MTok
+
+["claude-custom"]
+input = 1
+output = 2
+cache_write = 0
+`)
+	table, err := pricing.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p, _ := table.Lookup("claude-opus-4-6"); p != (pricing.Price{Input: 4, CacheWrite: 5, CacheRead: 0.4, Output: 20}) {
+		t.Errorf("opus: got %+v", p)
+	}
+	if p, _ := table.Lookup("claude-custom-1"); p != (pricing.Price{Input: 1, CacheWrite: 0, CacheRead: 0.1, Output: 2}) {
+		t.Errorf("claude-custom: got %+v", p)
+	}
+}
+
+func TestLoadPrefersJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "pricing.json", `{"sonnet": {"input": 2, "output": 10}}`)
+	writeFile(t, dir, "pricing.toml", "[sonnet]\ninput = 9\noutput = 9\n")
+	table, err := pricing.Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p, _ := table.Lookup("claude-sonnet-4-6"); p.Input != 2 {
+		t.Errorf("expected pricing.json to win, got input %v", p.Input)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name, file, content, wantErr string
+	}{
+		{"missing output", "pricing.json", `{"opus": {"input": 1}}`, "input and output prices are required"},
+		{"bad json", "pricing.json", `{"opus": `, "pricing.json"},
+		{"key outside table", "pricing.toml", "input = 1\n", "line 1: key outside"},
+		{"unknown key", "pricing.toml", "[opus]\ninput = 1\nprice = 2\n", `line 3: unknown key "price"`},
+		{"bad number", "pricing.toml", "[opus]\ninput = cheap\n", "line 2"},
+		{"unterminated header", "pricing.toml", "[opus\n", "line 1: unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, tt.file, tt.content)
+			_, err := pricing.Load(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
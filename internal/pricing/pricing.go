@@ -0,0 +1,93 @@
+// Package pricing estimates the USD cost of Claude API token usage from a
+// per-model-family price table.
+package pricing
+
+import "strings"
+
+// Price holds USD prices per million tokens for one model family.
+type Price struct {
+	Input      float64 `json:"input"`
+	CacheWrite float64 `json:"cache_write"`
+	CacheRead  float64 `json:"cache_read"`
+	Output     float64 `json:"output"`
+}
+
+// Tokens is a token usage breakdown to be priced. Input excludes cache
+// writes and cache reads, which are billed at their own rates.
+type Tokens struct {
+	Input      int
+	CacheWrite int
+	CacheRead  int
+	Output     int
+}
+
+// Table maps model-name patterns to prices. A model is priced by the longest
+// pattern that is a case-insensitive substring of its name; user overrides win
+// ties with built-in patterns.
+type Table struct {
+	builtin   map[string]Price
+	overrides map[string]Price
+}
+
+// builtinPrices lists Anthropic list prices (USD per million tokens).
+// Bare family names price current models; longer patterns pin older
+// generations that are billed differently.
+var builtinPrices = map[string]Price{
+	"opus":           {Input: 5, CacheWrite: 6.25, CacheRead: 0.50, Output: 25},
+	"opus-4-1":       {Input: 15, CacheWrite: 18.75, CacheRead: 1.50, Output: 75},
+	"opus-4-2025":    {Input: 15, CacheWrite: 18.75, CacheRead: 1.50, Output: 75},
+	"3-opus":         {Input: 15, CacheWrite: 18.75, CacheRead: 1.50, Output: 75},
+	"sonnet":         {Input: 3, CacheWrite: 3.75, CacheRead: 0.30, Output: 15},
+	"haiku":          {Input: 1, CacheWrite: 1.25, CacheRead: 0.10, Output: 5},
+	"3-5-haiku":      {Input: 0.80, CacheWrite: 1, CacheRead: 0.08, Output: 4},
+	"claude-3-haiku": {Input: 0.25, CacheWrite: 0.30, CacheRead: 0.03, Output: 1.25},
+}
+
+// Default returns a table containing only the built-in prices.
+func Default() *Table {
+	return &Table{builtin: builtinPrices}
+}
+
+// WithOverrides returns a copy of t in which overrides take precedence over
+// built-in prices for equally specific patterns.
+func (t *Table) WithOverrides(overrides map[string]Price) *Table {
+	merged := make(map[string]Price, len(t.overrides)+len(overrides))
+	for k, v := range t.overrides {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[strings.ToLower(k)] = v
+	}
+	return &Table{builtin: t.builtin, overrides: merged}
+}
+
+// Lookup returns the price for model and whether any pattern matched.
+func (t *Table) Lookup(model string) (Price, bool) {
+	name := strings.ToLower(model)
+	var (
+		best    Price
+		bestLen = -1
+	)
+	// Overrides are checked first and only replaced by strictly longer
+	// built-in matches, so they win ties.
+	for _, m := range []map[string]Price{t.overrides, t.builtin} {
+		for pattern, p := range m {
+			if len(pattern) > bestLen && strings.Contains(name, pattern) {
+				best, bestLen = p, len(pattern)
+			}
+		}
+	}
+	return best, bestLen >= 0
+}
+
+// Cost returns the USD cost of tok for model, or 0 for unknown models.
+func (t *Table) Cost(model string, tok Tokens) float64 {
+	p, ok := t.Lookup(model)
+	if !ok {
+		return 0
+	}
+	return (float64(tok.Input)*p.Input +
+		float64(tok.CacheWrite)*p.CacheWrite +
+		float64(tok.CacheRead)*p.CacheRead +
+		float64(tok.Output)*p.Output) / 1_000_000
+}
@@ -0,0 +1,80 @@
+package pricing_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/pricing"
+)
+
+func TestLookupPicksMostSpecificPattern(t *testing.T) {
+	tests := []struct {
+		model     string
+		wantInput float64
+	}{
+		{"claude-opus-4-6", 5},
+		{"claude-opus-4-1-20250805", 15},
+		{"claude-opus-4-20250514", 15},
+		{"claude-3-opus-20240229", 15},
+		{"claude-sonnet-4-6", 3},
+		{"claude-haiku-4-5-20251001", 1},
+		{"claude-3-5-haiku-20241022", 0.80},
+		{"claude-3-haiku-20240307", 0.25},
+		{"CLAUDE-SONNET-4-6", 3},
+	}
+	table := pricing.Default()
+	for _, tt := range tests {
+		p, ok := table.Lookup(tt.model)
+		if !ok {
+			t.Errorf("Lookup(%q): no match", tt.model)
+			continue
+		}
+		if p.Input != tt.wantInput {
+			t.Errorf("Lookup(%q).Input = %v, want %v", tt.model, p.Input, tt.wantInput)
+		}
+	}
+}
+
+func TestLookupUnknownModel(t *testing.T) {
+	if _, ok := pricing.Default().Lookup("<synthetic>"); ok {
+		t.Error("expected no match for unknown model")
+	}
+	if got := pricing.Default().Cost("gpt-4", pricing.Tokens{Input: 1000}); got != 0 {
+		t.Errorf("Cost for unknown model = %v, want 0", got)
+	}
+}
+
+func TestOverridesWinTies(t *testing.T) {
+	table := pricing.Default().WithOverrides(map[string]pricing.Price{
+		"Sonnet":    {Input: 1, Output: 2},
+		"my-sonnet": {Input: 7, Output: 8},
+	})
+	if p, _ := table.Lookup("claude-sonnet-4-6"); p.Input != 1 {
+		t.Errorf("override should replace built-in sonnet, got input %v", p.Input)
+	}
+	if p, _ := table.Lookup("my-sonnet-finetune"); p.Input != 7 {
+		t.Errorf("longer override pattern should win, got input %v", p.Input)
+	}
+	// A longer built-in pattern still beats a shorter override.
+	table = pricing.Default().WithOverrides(map[string]pricing.Price{"haiku": {Input: 9, Output: 9}})
+	if p, _ := table.Lookup("claude-3-5-haiku-20241022"); p.Input != 0.80 {
+		t.Errorf("expected built-in 3-5-haiku price, got input %v", p.Input)
+	}
+	// The receiver is not modified.
+	if p, _ := pricing.Default().Lookup("claude-sonnet-4-6"); p.Input != 3 {
+		t.Errorf("Default() changed by WithOverrides, got input %v", p.Input)
+	}
+}
+
+func TestCostPricesEachTokenKindSeparately(t *testing.T) {
+	got := pricing.Default().Cost("claude-sonnet-4-6", pricing.Tokens{
+		Input:      1_000_000,
+		CacheWrite: 1_000_000,
+		CacheRead:  1_000_000,
+		Output:     1_000_000,
+	})
+	want := 3 + 3.75 + 0.30 + 15.0
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Cost = %v, want %v", got, want)
+	}
+}
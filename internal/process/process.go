@@ -0,0 +1,197 @@
+// Package process finds running Claude Code processes and maps them to the
+// session transcripts they are writing.
+package process
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Process is a running Claude Code process.
+type Process struct {
+	PID        int
+	Args       []string // command line
+	Cwd        string   // working directory, i.e. the project
+	Files      []string // open .jsonl files
+	StartTime  time.Time
+	CPUPercent float64 // CPU use since the previous scan (since start on the first)
+
+	cpuTime time.Duration // total user+system CPU time
+}
+
+// Scanner lists Claude Code processes. It remembers each process's CPU time
+// so CPUPercent covers the interval between consecutive scans.
+type Scanner struct {
+	mu   sync.Mutex
+	prev map[int]sample
+}
+
+// sample is a process's CPU time at one scan.
+type sample struct {
+	start   time.Time
+	cpuTime time.Duration
+	at      time.Time
+}
+
+// NewScanner returns a Scanner with no CPU history.
+func NewScanner() *Scanner {
+	return &Scanner{prev: make(map[int]sample)}
+}
+
+// Scan returns the running Claude Code processes. It returns
+// errors.ErrUnsupported on platforms without a process table backend.
+func (s *Scanner) Scan() ([]Process, error) {
+	procs, err := scan()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := make(map[int]sample, len(procs))
+	for i := range procs {
+		p := &procs[i]
+		from := sample{start: p.StartTime, at: p.StartTime}
+		// A recycled PID has a different start time; measure it from its start.
+		if prev, ok := s.prev[p.PID]; ok && prev.start.Equal(p.StartTime) {
+			from = prev
+		}
+		if elapsed := now.Sub(from.at); elapsed > 0 {
+			p.CPUPercent = 100 * float64(p.cpuTime-from.cpuTime) / float64(elapsed)
+		}
+		next[p.PID] = sample{start: p.StartTime, cpuTime: p.cpuTime, at: now}
+	}
+	s.prev = next
+	return procs, nil
+}
+
+// isClaude reports whether a command line runs the Claude Code CLI, either
+// as the native binary or as a script under a JavaScript runtime.
+func isClaude(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if isClaudeArg(args[0]) {
+		return true
+	}
+	// The npm install runs as "node .../claude" or "bun .../cli.js".
+	runtime := filepath.Base(args[0])
+	return len(args) > 1 && (strings.HasPrefix(runtime, "node") || strings.HasPrefix(runtime, "bun")) &&
+		isClaudeArg(args[1])
+}
+
+// isClaudeArg reports whether one argument names the Claude Code CLI.
+func isClaudeArg(a string) bool {
+	return filepath.Base(a) == "claude" || strings.Contains(a, "@anthropic-ai/claude-code")
+}
+
+// SessionArg returns the session ID a command line resumes or pins
+// (--resume/-r <id>, --session-id <id>), or "".
+func SessionArg(args []string) string {
+	for i, a := range args {
+		for _, flag := range []string{"--resume", "-r", "--session-id"} {
+			if v, ok := strings.CutPrefix(a, flag+"="); ok {
+				return v
+			}
+			if a == flag && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				return args[i+1]
+			}
+		}
+	}
+	return ""
+}
+
+// ProjectHash returns the name Claude Code gives the project directory of a
+// working directory: every character other than a letter or digit becomes '-'.
+func ProjectHash(cwd string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, cwd)
+}
+
+// Transcript is a session transcript a process can be matched to.
+type Transcript struct {
+	ID          string
+	FilePath    string
+	ProjectHash string
+	ModTime     time.Time
+}
+
+// Match maps processes to transcripts and returns the process writing each
+// matched transcript, keyed by FilePath. A process is matched by, in order:
+// an open transcript file, the session named on its command line, or the most
+// recently written unclaimed transcript of its working directory's project
+// that was written after the process started. Processes are matched newest
+// first so the latest process gets the latest transcript.
+func Match(procs []Process, transcripts []Transcript) map[string]*Process {
+	byPath := make(map[string]int, len(transcripts))
+	byID := make(map[string]int, len(transcripts))
+	for i, t := range transcripts {
+		byPath[t.FilePath] = i
+		byID[t.ID] = i
+	}
+	order := make([]int, len(procs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return procs[order[a]].StartTime.After(procs[order[b]].StartTime)
+	})
+
+	matched := make(map[string]*Process)
+	claim := func(p *Process, i int) bool {
+		path := transcripts[i].FilePath
+		if _, taken := matched[path]; taken {
+			return false
+		}
+		matched[path] = p
+		return true
+	}
+	var rest []int
+	for _, k := range order {
+		p := &procs[k]
+		ok := false
+		for _, f := range p.Files {
+			if i, found := byPath[f]; found && claim(p, i) {
+				ok = true
+				break
+			}
+		}
+		if id := SessionArg(p.Args); !ok && id != "" {
+			if i, found := byID[id]; found {
+				ok = claim(p, i)
+			}
+		}
+		if !ok {
+			rest = append(rest, k)
+		}
+	}
+
+	newest := make([]int, len(transcripts))
+	for i := range newest {
+		newest[i] = i
+	}
+	sort.SliceStable(newest, func(a, b int) bool {
+		return transcripts[newest[a]].ModTime.After(transcripts[newest[b]].ModTime)
+	})
+	for _, k := range rest {
+		p := &procs[k]
+		hash := ProjectHash(p.Cwd)
+		for _, i := range newest {
+			t := transcripts[i]
+			if t.ModTime.Before(p.StartTime) {
+				break // newest first: no later transcript qualifies either
+			}
+			if t.ProjectHash == hash && claim(p, i) {
+				break
+			}
+		}
+	}
+	return matched
+}
@@ -0,0 +1,132 @@
+package process
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is USER_HZ, the unit of the CPU and start times in
+// /proc/<pid>/stat. It is 100 on every mainstream Linux architecture.
+const clockTicks = 100
+
+// scan reads the process table from /proc.
+func scan() ([]Process, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	boot, err := bootTime()
+	if err != nil {
+		return nil, err
+	}
+	var procs []Process
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		// Processes can exit at any point; skip any that vanish mid-read.
+		if p, ok := readProcess(pid, boot); ok {
+			procs = append(procs, p)
+		}
+	}
+	return procs, nil
+}
+
+// readProcess reads one process, reporting false unless it is Claude Code.
+func readProcess(pid int, boot time.Time) (Process, bool) {
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+	raw, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return Process{}, false
+	}
+	args := strings.Split(strings.TrimRight(string(raw), "\x00"), "\x00")
+	if !isClaude(args) {
+		return Process{}, false
+	}
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return Process{}, false
+	}
+	cpuTicks, startTicks, err := parseStat(stat)
+	if err != nil {
+		return Process{}, false
+	}
+	p := Process{
+		PID:       pid,
+		Args:      args,
+		StartTime: boot.Add(ticks(startTicks)),
+		cpuTime:   ticks(cpuTicks),
+	}
+	p.Cwd, _ = os.Readlink(filepath.Join(dir, "cwd"))
+	p.Files = openTranscripts(filepath.Join(dir, "fd"))
+	return p, true
+}
+
+// openTranscripts lists the .jsonl files a process holds open. Reading
+// another user's fds fails; that simply yields no files.
+func openTranscripts(fdDir string) []string {
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return nil
+	}
+	var files []string
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+		if err == nil && strings.HasSuffix(target, ".jsonl") {
+			files = append(files, target)
+		}
+	}
+	return files
+}
+
+// parseStat extracts utime+stime and starttime (in clock ticks) from the
+// contents of /proc/<pid>/stat. The command name in parentheses may contain
+// spaces, so fields are counted from the last ')'.
+func parseStat(stat []byte) (cpu, start uint64, err error) {
+	i := bytes.LastIndexByte(stat, ')')
+	if i < 0 {
+		return 0, 0, errors.New("malformed stat")
+	}
+	// fields[0] is field 3 (state); utime, stime and starttime are fields 14, 15 and 22.
+	fields := strings.Fields(string(stat[i+1:]))
+	if len(fields) < 20 {
+		return 0, 0, fmt.Errorf("short stat: %d fields", len(fields))
+	}
+	var v [3]uint64
+	for j, f := range []int{11, 12, 19} {
+		if v[j], err = strconv.ParseUint(fields[f], 10, 64); err != nil {
+			return 0, 0, err
+		}
+	}
+	return v[0] + v[1], v[2], nil
+}
+
+// bootTime reads the system boot time from /proc/stat.
+func bootTime() (time.Time, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return time.Time{}, err
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		if v, ok := strings.CutPrefix(line, "btime "); ok {
+			secs, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return time.Time{}, err
+			}
+			return time.Unix(secs, 0), nil
+		}
+	}
+	return time.Time{}, errors.New("btime not found in /proc/stat")
+}
+
+// ticks converts clock ticks to a duration.
+func ticks(n uint64) time.Duration {
+	return time.Duration(n) * time.Second / clockTicks
+}
@@ -0,0 +1,30 @@
+package process
+
+import "testing"
+
+func TestParseStat(t *testing.T) {
+	// The command name may contain spaces and parentheses.
+	stat := []byte("4242 (claude (x) y) S 1 4242 4242 0 -1 4194560 100 0 0 0 250 50 0 0 20 0 12 0 987654 0 0")
+	cpu, start, err := parseStat(stat)
+	if err != nil {
+		t.Fatalf("parseStat failed: %v", err)
+	}
+	if cpu != 300 || start != 987654 {
+		t.Errorf("parseStat = (%d, %d), want (300, 987654)", cpu, start)
+	}
+	if _, _, err := parseStat([]byte("4242 claude S")); err == nil {
+		t.Error("expected error for malformed stat")
+	}
+}
+
+func TestScanReturnsOnlyClaude(t *testing.T) {
+	procs, err := NewScanner().Scan()
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	for _, p := range procs {
+		if !isClaude(p.Args) {
+			t.Errorf("pid %d is not a Claude Code process: %q", p.PID, p.Args)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package process
+
+import "errors"
+
+// scan reports that no process table backend exists on this platform.
+func scan() ([]Process, error) {
+	return nil, errors.ErrUnsupported
+}
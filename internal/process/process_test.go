@@ -0,0 +1,78 @@
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsClaude(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"claude"}, true},
+		{[]string{"/home/u/.local/bin/claude", "--resume", "abc"}, true},
+		{[]string{"node", "/usr/lib/node_modules/@anthropic-ai/claude-code/cli.js"}, true},
+		{[]string{"node", "/usr/local/bin/claude"}, true},
+		{[]string{"claudeview"}, false},
+		{[]string{"vim", "claude"}, false},
+		{[]string{""}, false},
+	}
+	for _, tt := range tests {
+		if got := isClaude(tt.args); got != tt.want {
+			t.Errorf("isClaude(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestSessionArg(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"claude", "--resume", "abc"}, "abc"},
+		{[]string{"claude", "-r", "abc"}, "abc"},
+		{[]string{"claude", "--session-id=abc"}, "abc"},
+		{[]string{"claude", "--resume"}, ""},
+		{[]string{"claude", "-r", "--verbose"}, ""},
+		{[]string{"claude"}, ""},
+	}
+	for _, tt := range tests {
+		if got := SessionArg(tt.args); got != tt.want {
+			t.Errorf("SessionArg(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestProjectHash(t *testing.T) {
+	if got := ProjectHash("/Users/mac/my_app.v2"); got != "-Users-mac-my-app-v2" {
+		t.Errorf("ProjectHash = %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Now()
+	transcripts := []Transcript{
+		{ID: "old", FilePath: "/p/a/old.jsonl", ProjectHash: "-a", ModTime: now.Add(-time.Hour)},
+		{ID: "new", FilePath: "/p/a/new.jsonl", ProjectHash: "-a", ModTime: now.Add(-time.Minute)},
+		{ID: "mid", FilePath: "/p/a/mid.jsonl", ProjectHash: "-a", ModTime: now.Add(-10 * time.Minute)},
+		{ID: "res", FilePath: "/p/b/res.jsonl", ProjectHash: "-b", ModTime: now.Add(-2 * time.Hour)},
+	}
+	procs := []Process{
+		{PID: 1, Cwd: "/a", StartTime: now.Add(-30 * time.Minute)},                                 // newest unclaimed in -a
+		{PID: 2, Cwd: "/a", StartTime: now.Add(-3 * time.Hour), Files: []string{"/p/a/new.jsonl"}}, // open file wins
+		{PID: 3, Cwd: "/b", StartTime: now.Add(-3 * time.Hour), Args: []string{"claude", "-r", "res"}},
+		{PID: 4, Cwd: "/a", StartTime: now.Add(-5 * time.Minute)}, // started after every remaining write
+		{PID: 5, Cwd: "/c", StartTime: now.Add(-time.Hour)},
+	}
+	got := Match(procs, transcripts)
+	want := map[string]int{"/p/a/new.jsonl": 2, "/p/a/mid.jsonl": 1, "/p/b/res.jsonl": 3}
+	if len(got) != len(want) {
+		t.Errorf("matched %d transcripts, want %d", len(got), len(want))
+	}
+	for path, pid := range want {
+		if p := got[path]; p == nil || p.PID != pid {
+			t.Errorf("%s matched to %+v, want pid %d", path, p, pid)
+		}
+	}
+}
@@ -4,36 +4,187 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/Curt-Park/claudeview/internal/config"
 	"github.com/Curt-Park/claudeview/internal/model"
 	"github.com/Curt-Park/claudeview/internal/parallel"
+	"github.com/Curt-Park/claudeview/internal/pricing"
+	"github.com/Curt-Park/claudeview/internal/process"
 	"github.com/Curt-Park/claudeview/internal/stringutil"
 	"github.com/Curt-Park/claudeview/internal/transcript"
 	"github.com/Curt-Park/claudeview/internal/ui"
 )
 
+// indexSaveInterval throttles how often the persistent session index is
+// written while the app is running; it is always written on Close.
+const indexSaveInterval = 30 * time.Second
+
 // Live implements ui.DataProvider by reading from the Claude data directory.
 type Live struct {
 	claudeDir      string
 	currentProject string
 	currentSession string
-	aggCache       map[string]*transcript.SessionAggregates
-	turnsCache     map[string]*transcript.TranscriptCache
+	files          map[string]*transcript.FileCache
+	index          *transcript.Index
+	prices         *pricing.Table
+	procs          *process.Scanner
+	lastSave       time.Time
 	mu             sync.Mutex
+
+	// Change tracking (see TrackChanges): the last directory scan is reused
+	// until Invalidate reports a change. scanGen guards against storing a
+	// scan that raced with an invalidation.
+	tracking  bool
+	scan      []transcript.ProjectInfo
+	scanValid bool
+	scanGen   int
 }
 
-// NewLive creates a new Live provider.
-func NewLive(claudeDir string) ui.DataProvider {
+// NewLive creates a new Live provider that estimates costs with prices.
+// Session aggregates are restored from the persistent index under
+// config.CacheDir() so a cold start only parses what was appended since the
+// last run.
+func NewLive(claudeDir string, prices *pricing.Table) ui.DataProvider {
 	return &Live{
-		claudeDir:  claudeDir,
-		aggCache:   make(map[string]*transcript.SessionAggregates),
-		turnsCache: make(map[string]*transcript.TranscriptCache),
+		claudeDir: claudeDir,
+		files:     make(map[string]*transcript.FileCache),
+		index:     transcript.LoadIndex(filepath.Join(config.CacheDir(), "session-index.json")),
+		prices:    prices,
+		procs:     process.NewScanner(),
+		lastSave:  time.Now(),
 	}
 }
 
-func (l *Live) GetProjects() []*model.Project {
+// usageCost prices a per-turn or aggregate usage record.
+func (l *Live) usageCost(modelName string, u transcript.Usage) float64 {
+	return l.prices.Cost(modelName, pricing.Tokens{
+		Input:      u.InputTokens,
+		CacheWrite: u.CacheCreationInputTokens,
+		CacheRead:  u.CacheReadInputTokens,
+		Output:     u.OutputTokens,
+	})
+}
+
+// tokenCount converts an aggregate usage record and prices it.
+func (l *Live) tokenCount(modelName string, u transcript.Usage) model.TokenCount {
+	return model.TokenCount{
+		InputTokens:      u.InputTokens,
+		CacheWriteTokens: u.CacheCreationInputTokens,
+		CacheReadTokens:  u.CacheReadInputTokens,
+		OutputTokens:     u.OutputTokens,
+		CostUSD:          l.usageCost(modelName, u),
+	}
+}
+
+// transcriptTokens returns the priced per-model token counts of a transcript
+// and their total cost.
+func (l *Live) transcriptTokens(path string) (map[string]model.TokenCount, float64) {
+	agg, err := l.fileCache(path).Aggregates()
+	if err != nil {
+		return nil, 0
+	}
+	byModel := make(map[string]model.TokenCount, len(agg.TokensByModel))
+	var total float64
+	for m, u := range agg.TokensByModel {
+		tc := l.tokenCount(m, u)
+		byModel[m] = tc
+		total += tc.CostUSD
+	}
+	return byModel, total
+}
+
+// Close writes the persistent session index.
+func (l *Live) Close() error {
+	return l.index.Save()
+}
+
+// fileCache returns the shared incremental decode cache for a transcript file.
+func (l *Live) fileCache(path string) *transcript.FileCache {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	fc, ok := l.files[path]
+	if !ok {
+		fc = l.index.FileCache(path)
+		if l.tracking {
+			fc.Watch()
+		}
+		l.files[path] = fc
+	}
+	return fc
+}
+
+// TrackChanges switches the provider to change-driven refresh: directory
+// scans and transcript reads are reused until Invalidate reports that the
+// underlying files changed. Call it once a filesystem watcher is running.
+func (l *Live) TrackChanges() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tracking = true
+	for _, fc := range l.files {
+		fc.Watch()
+	}
+}
+
+// Invalidate marks paths as changed so the next load rescans the project
+// directories and re-reads those transcripts. A nil paths marks everything
+// as changed.
+func (l *Live) Invalidate(paths []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.scanValid = false
+	l.scanGen++
+	if paths == nil {
+		for _, fc := range l.files {
+			fc.MarkChanged()
+		}
+		return
+	}
+	for _, p := range paths {
+		if fc, ok := l.files[p]; ok {
+			fc.MarkChanged()
+		}
+	}
+}
+
+// scanProjects returns the project directory scan, reusing the previous one
+// while change tracking is on and nothing changed.
+func (l *Live) scanProjects() ([]transcript.ProjectInfo, error) {
+	l.mu.Lock()
+	if l.tracking && l.scanValid {
+		infos := l.scan
+		l.mu.Unlock()
+		return infos, nil
+	}
+	gen := l.scanGen
+	l.mu.Unlock()
+
 	infos, err := transcript.ScanProjects(l.claudeDir)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	if gen == l.scanGen {
+		l.scan, l.scanValid = infos, true
+	}
+	l.mu.Unlock()
+	return infos, nil
+}
+
+// maybeSaveIndex writes the persistent index at most once per indexSaveInterval.
+func (l *Live) maybeSaveIndex() {
+	l.mu.Lock()
+	if time.Since(l.lastSave) < indexSaveInterval {
+		l.mu.Unlock()
+		return
+	}
+	l.lastSave = time.Now()
+	l.mu.Unlock()
+	_ = l.index.Save()
+}
+
+func (l *Live) GetProjects() []*model.Project {
+	infos, err := l.scanProjects()
 	if err != nil {
 		return []*model.Project{}
 	}
@@ -55,6 +206,7 @@ func (l *Live) GetProjects() []*model.Project {
 		}
 		projects = append(projects, p)
 	}
+	l.maybeSaveIndex()
 	return projects
 }
 
@@ -63,7 +215,7 @@ func (l *Live) GetSessions(projectHash string) []*model.Session {
 		l.currentProject = projectHash
 	}
 
-	infos, err := transcript.ScanProjects(l.claudeDir)
+	infos, err := l.scanProjects()
 	if err != nil {
 		return []*model.Session{}
 	}
@@ -95,9 +247,31 @@ func (l *Live) GetSessions(projectHash string) []*model.Session {
 		}
 		sessions = append(sessions, s)
 	}
+	l.attachProcesses(sessions)
+	l.maybeSaveIndex()
 	return model.GroupSessionsBySlug(sessions)
 }
 
+// attachProcesses sets Process on every session a running Claude Code
+// process is writing. Platforms without a process scanner leave it nil.
+func (l *Live) attachProcesses(sessions []*model.Session) {
+	procs, err := l.procs.Scan()
+	if err != nil {
+		return
+	}
+	transcripts := make([]process.Transcript, len(sessions))
+	for i, s := range sessions {
+		transcripts[i] = process.Transcript{ID: s.ID, FilePath: s.FilePath, ProjectHash: s.ProjectHash, ModTime: s.ModTime}
+	}
+	matched := process.Match(procs, transcripts)
+	for _, s := range sessions {
+		s.Process = nil
+		if p, ok := matched[s.FilePath]; ok {
+			s.Process = &model.Process{PID: p.PID, StartTime: p.StartTime, CPUPercent: p.CPUPercent}
+		}
+	}
+}
+
 func (l *Live) GetAgents(sessionID string) []*model.Agent {
 	if sessionID != "" {
 		l.currentSession = sessionID
@@ -106,13 +280,13 @@ func (l *Live) GetAgents(sessionID string) []*model.Agent {
 	if sessionID == "" {
 		var all []*model.Agent
 		for _, s := range sessions {
-			all = append(all, parseAgentsFromSession(s)...)
+			all = append(all, l.parseAgentsFromSession(s)...)
 		}
 		return all
 	}
 	for _, s := range sessions {
 		if s.ID == sessionID {
-			return parseAgentsFromSession(s)
+			return l.parseAgentsFromSession(s)
 		}
 	}
 	return []*model.Agent{}
@@ -191,41 +365,52 @@ func (l *Live) GetMemories(projectHash string) []*model.Memory {
 }
 
 func (l *Live) GetTurns(filePath string) []model.Turn {
-	l.mu.Lock()
-	cached := l.turnsCache[filePath]
-	l.mu.Unlock()
-
-	cache, err := transcript.ParseFileIncremental(filePath, cached)
+	parsed, err := l.fileCache(filePath).Turns()
 	if err != nil {
 		return nil
 	}
+	return l.modelTurns(parsed)
+}
 
-	l.mu.Lock()
-	l.turnsCache[filePath] = cache
-	l.mu.Unlock()
+// ReadTurns parses a transcript's turns without caching them, for one-off
+// passes over many files such as building the search index.
+func (l *Live) ReadTurns(filePath string) []model.Turn {
+	parsed, err := transcript.ParseFile(filePath)
+	if err != nil {
+		return nil
+	}
+	return l.modelTurns(parsed.Turns)
+}
 
-	parsed := cache.Turns()
+// modelTurns converts parsed turns to the model's, pricing them.
+func (l *Live) modelTurns(parsed []transcript.Turn) []model.Turn {
 	turns := make([]model.Turn, 0, len(parsed))
 	for _, t := range parsed {
 		turn := model.Turn{
-			Role:            t.Role,
-			Text:            t.Text,
-			Thinking:        t.Thinking,
-			ModelName:       t.Model,
-			InputTokens:     t.Usage.NewInputTokens(),
-			CacheReadTokens: t.Usage.CacheReadInputTokens,
-			OutputTokens:    t.Usage.OutputTokens,
-			Timestamp:       t.Timestamp,
+			Role:             t.Role,
+			Text:             t.Text,
+			Thinking:         t.Thinking,
+			ModelName:        t.Model,
+			InputTokens:      t.Usage.InputTokens,
+			CacheWriteTokens: t.Usage.CacheCreationInputTokens,
+			CacheReadTokens:  t.Usage.CacheReadInputTokens,
+			OutputTokens:     t.Usage.OutputTokens,
+			CostUSD:          l.usageCost(t.Model, t.Usage),
+			Timestamp:        t.Timestamp,
+			UUID:             t.UUID,
+			ParentUUID:       t.ParentUUID,
+			Sidechain:        t.Sidechain,
 		}
 		for _, tc := range t.ToolCalls {
 			turn.ToolCalls = append(turn.ToolCalls, &model.ToolCall{
-				ID:        tc.ID,
-				Name:      tc.Name,
-				Input:     tc.Input,
-				Result:    tc.Result,
-				IsError:   tc.IsError,
-				Timestamp: tc.Timestamp,
-				Duration:  tc.Duration,
+				ID:         tc.ID,
+				Name:       tc.Name,
+				Input:      tc.Input,
+				Result:     tc.Result,
+				IsError:    tc.IsError,
+				Timestamp:  tc.Timestamp,
+				Duration:   tc.Duration,
+				SubagentID: tc.SubagentID,
 			})
 		}
 		turns = append(turns, turn)
@@ -242,23 +427,16 @@ func (l *Live) sessionFromInfo(si transcript.SessionInfo) *model.Session {
 		ModTime:     si.ModTime,
 	}
 
-	l.mu.Lock()
-	cached := l.aggCache[si.FilePath]
-	l.mu.Unlock()
-
-	agg, err := transcript.ParseAggregatesIncremental(si.FilePath, cached)
+	agg, err := l.fileCache(si.FilePath).Aggregates()
 	if err != nil {
 		return s
 	}
 
-	l.mu.Lock()
-	l.aggCache[si.FilePath] = agg
-	l.mu.Unlock()
-
 	s.NumTurns = agg.NumTurns
 	s.Topic = agg.Topic
 	s.Branch = agg.Branch
 	s.Slug = agg.Slug
+	s.Activity = activity(agg.Activity)
 	s.ToolCallCount = agg.TotalToolCalls
 	s.AgentCount = 1 + transcript.CountSubagents(si.SubagentDir)
 	if info, err := os.Stat(si.FilePath); err == nil {
@@ -267,11 +445,7 @@ func (l *Live) sessionFromInfo(si transcript.SessionInfo) *model.Session {
 
 	s.TokensByModel = make(map[string]model.TokenCount, len(agg.TokensByModel))
 	for m, u := range agg.TokensByModel {
-		s.TokensByModel[m] = model.TokenCount{
-			InputTokens:     u.InputTokens,
-			CacheReadTokens: u.CacheReadInputTokens,
-			OutputTokens:    u.OutputTokens,
-		}
+		s.TokensByModel[m] = l.tokenCount(m, u)
 	}
 
 	// Merge subagent token data into session totals
@@ -279,19 +453,10 @@ func (l *Live) sessionFromInfo(si transcript.SessionInfo) *model.Session {
 		subInfos, _ := transcript.ScanSubagents(si.SubagentDir)
 		if len(subInfos) > 0 {
 			subAggs := parallel.Map(subInfos, func(sub transcript.SessionInfo) *transcript.SessionAggregates {
-				l.mu.Lock()
-				subCached := l.aggCache[sub.FilePath]
-				l.mu.Unlock()
-
-				subAgg, err := transcript.ParseAggregatesIncremental(sub.FilePath, subCached)
+				subAgg, err := l.fileCache(sub.FilePath).Aggregates()
 				if err != nil {
 					return nil
 				}
-
-				l.mu.Lock()
-				l.aggCache[sub.FilePath] = subAgg
-				l.mu.Unlock()
-
 				return subAgg
 			})
 			for _, subAgg := range subAggs {
@@ -301,8 +466,10 @@ func (l *Live) sessionFromInfo(si transcript.SessionInfo) *model.Session {
 				for m, u := range subAgg.TokensByModel {
 					cur := s.TokensByModel[m]
 					cur.InputTokens += u.InputTokens
+					cur.CacheWriteTokens += u.CacheCreationInputTokens
 					cur.CacheReadTokens += u.CacheReadInputTokens
 					cur.OutputTokens += u.OutputTokens
+					cur.CostUSD += l.usageCost(m, u)
 					s.TokensByModel[m] = cur
 				}
 			}
@@ -312,19 +479,38 @@ func (l *Live) sessionFromInfo(si transcript.SessionInfo) *model.Session {
 	return s
 }
 
-// populateToolCalls fills agent.ToolCalls from a parsed transcript.
-func populateToolCalls(agent *model.Agent, sessionID string, parsed *transcript.ParsedTranscript) {
-	for _, turn := range parsed.Turns {
+// activity converts a transcript's tail state for status inference.
+func activity(a transcript.Activity) model.Activity {
+	return model.Activity{
+		Waiting:      a.Waiting,
+		ThinkingOnly: a.ThinkingOnly,
+		OpenTools:    a.ToolNames(),
+	}
+}
+
+// subagentStatus infers a subagent's live status from the tail of its transcript.
+func (l *Live) subagentStatus(si transcript.SessionInfo) model.Status {
+	agg, err := l.fileCache(si.FilePath).Aggregates()
+	if err != nil {
+		return model.StatusDone
+	}
+	return activity(agg.Activity).Status(si.ModTime, time.Now(), true)
+}
+
+// populateToolCalls fills agent.ToolCalls from parsed transcript turns.
+func populateToolCalls(agent *model.Agent, sessionID string, turns []transcript.Turn) {
+	for _, turn := range turns {
 		for _, tc := range turn.ToolCalls {
 			agent.ToolCalls = append(agent.ToolCalls, &model.ToolCall{
-				ID:        tc.ID,
-				SessionID: sessionID,
-				AgentID:   agent.ID,
-				Name:      tc.Name,
-				Input:     tc.Input,
-				Result:    tc.Result,
-				IsError:   tc.IsError,
-				Timestamp: turn.Timestamp,
+				ID:         tc.ID,
+				SessionID:  sessionID,
+				AgentID:    agent.ID,
+				Name:       tc.Name,
+				Input:      tc.Input,
+				Result:     tc.Result,
+				IsError:    tc.IsError,
+				Timestamp:  turn.Timestamp,
+				SubagentID: tc.SubagentID,
 			})
 		}
 	}
@@ -335,30 +521,30 @@ func populateToolCalls(agent *model.Agent, sessionID string, parsed *transcript.
 }
 
 // parseAgentsFromSession loads transcript and extracts agents.
-func parseAgentsFromSession(s *model.Session) []*model.Agent {
+func (l *Live) parseAgentsFromSession(s *model.Session) []*model.Agent {
 	mainAgent := &model.Agent{
 		ID:         "",
 		SessionID:  s.ID,
 		Type:       model.AgentTypeMain,
-		Status:     model.StatusEnded,
+		Status:     s.Status(),
 		FilePath:   s.FilePath,
 		IsSubagent: false,
+		ModTime:    s.ModTime,
 	}
+	mainAgent.TokensByModel, mainAgent.CostUSD = l.transcriptTokens(s.FilePath)
 
-	// Parse main transcript for tool calls and subagent type extraction
-	var subTypes []model.AgentType
-	if parsed, err := transcript.ParseFile(s.FilePath); err == nil {
-		populateToolCalls(mainAgent, s.ID, parsed)
-		var calls []model.ToolCallInfo
-		for _, t := range parsed.Turns {
+	// Parse main transcript for tool calls; Agent/Task calls give subagent types
+	var calls []model.ToolCallInfo
+	if turns, err := l.fileCache(s.FilePath).Turns(); err == nil {
+		populateToolCalls(mainAgent, s.ID, turns)
+		for _, t := range turns {
 			if t.Role != "assistant" {
 				continue
 			}
 			for _, tc := range t.ToolCalls {
-				calls = append(calls, model.ToolCallInfo{Name: tc.Name, Input: tc.Input})
+				calls = append(calls, model.ToolCallInfo{Name: tc.Name, Input: tc.Input, SubagentID: tc.SubagentID})
 			}
 		}
-		subTypes = model.ExtractAgentTypesFromCalls(calls)
 	}
 
 	agents := []*model.Agent{mainAgent}
@@ -370,27 +556,37 @@ func parseAgentsFromSession(s *model.Session) []*model.Agent {
 			type subWork struct {
 				si        transcript.SessionInfo
 				agentType model.AgentType
+				unmatched bool
 			}
 			items := make([]subWork, len(subInfos))
+			ids := make([]string, len(subInfos))
 			for i, si := range subInfos {
-				agentType := model.AgentTypeGeneral
-				if i < len(subTypes) {
-					agentType = subTypes[i]
+				items[i] = subWork{si: si, agentType: model.AgentTypeGeneral, unmatched: true}
+				ids[i] = transcript.SubagentID(si)
+			}
+			subTypes := model.ExtractAgentTypesFromCalls(calls)
+			byCall, _ := model.MatchSubagents(calls, ids)
+			for k, i := range byCall {
+				if i >= 0 {
+					items[i].agentType = subTypes[k]
+					items[i].unmatched = false
 				}
-				items[i] = subWork{si: si, agentType: agentType}
 			}
 			subAgents := parallel.Map(items, func(item subWork) *model.Agent {
 				sub := &model.Agent{
 					ID:         item.si.ID,
 					SessionID:  s.ID,
 					Type:       item.agentType,
-					Status:     model.StatusDone,
+					Status:     l.subagentStatus(item.si),
 					FilePath:   item.si.FilePath,
 					IsSubagent: true,
 					StartTime:  item.si.ModTime,
+					ModTime:    item.si.ModTime,
+					Unmatched:  item.unmatched,
 				}
-				if subParsed, err := transcript.ParseFile(item.si.FilePath); err == nil {
-					populateToolCalls(sub, s.ID, subParsed)
+				sub.TokensByModel, sub.CostUSD = l.transcriptTokens(item.si.FilePath)
+				if turns, err := l.fileCache(item.si.FilePath).Turns(); err == nil {
+					populateToolCalls(sub, s.ID, turns)
 				}
 				return sub
 			})
@@ -0,0 +1,446 @@
+// Package search is a full-text index over every session transcript: user
+// and assistant text, thinking, tool inputs and tool results.
+package search
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/parallel"
+)
+
+// Kind is the part of a turn a document holds.
+type Kind string
+
+const (
+	KindUser       Kind = "user"
+	KindAssistant  Kind = "assistant"
+	KindThinking   Kind = "thinking"
+	KindToolInput  Kind = "tool input"
+	KindToolResult Kind = "tool result"
+)
+
+// maxDocText caps the text indexed per document; large tool results (file
+// reads, command output) are searched only in their first part.
+const maxDocText = 32 << 10
+
+// maxStoredText caps the text a document keeps for snippets once indexed;
+// DocText re-reads all of it from the transcript's turns, and FillSnippets
+// does for matches further on.
+const maxStoredText = 2 << 10
+
+// maxTermLen skips longer tokens, which are hashes and encoded blobs rather
+// than words anyone searches for.
+const maxTermLen = 40
+
+// Source is one transcript file to index.
+type Source struct {
+	ProjectHash string
+	SessionID   string
+	FilePath    string
+	SubagentID  string    // agent ID of a subagent transcript; "" for the session's own
+	ModTime     time.Time // a file is re-read only when this changes
+}
+
+// Doc is one searchable piece of a turn.
+type Doc struct {
+	Source
+	TurnUUID  string // turn the text belongs to; tool calls belong to the assistant turn that made them
+	Timestamp time.Time
+	Kind      Kind
+	Tool      string // tool name for KindToolInput and KindToolResult
+	ToolID    string // tool call ID for KindToolInput and KindToolResult
+	Text      string // the first maxStoredText bytes; see DocText
+
+	cut bool // Text is shorter than the indexed text
+}
+
+// Hit is a document matching a search.
+type Hit struct {
+	Doc
+	Snippet string   // one line of text around the first match
+	Terms   []string // the query's words, for highlighting
+	Partial bool     // the match lies past Doc.Text and Snippet shows its start; see FillSnippets
+}
+
+// Index is an inverted index over transcripts. It is safe for concurrent use;
+// searches see each file as soon as it is indexed.
+type Index struct {
+	mu    sync.RWMutex
+	files map[string]*fileIndex // by Source.FilePath
+	gen   int
+	load  func(path string) []model.Turn // of the last Update, for FillSnippets
+
+	updating    sync.Mutex
+	done, total atomic.Int64
+}
+
+// fileIndex is the index of one transcript.
+type fileIndex struct {
+	source   Source
+	docs     []Doc
+	terms    []string  // sorted vocabulary
+	postings [][]int32 // ascending doc indices of each term
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{files: make(map[string]*fileIndex)}
+}
+
+// Update indexes sources, re-reading only files whose ModTime changed, and
+// drops files no longer listed. load returns a transcript's turns; the index
+// keeps none of them, so load should not cache them either. It returns false
+// without doing anything when another Update is running.
+func (ix *Index) Update(sources []Source, load func(path string) []model.Turn) bool {
+	if !ix.updating.TryLock() {
+		return false
+	}
+	defer ix.updating.Unlock()
+
+	ix.mu.Lock()
+	ix.load = load
+	ix.mu.Unlock()
+	ix.mu.RLock()
+	var changed []Source
+	for _, src := range sources {
+		if old, ok := ix.files[src.FilePath]; !ok || !old.source.ModTime.Equal(src.ModTime) {
+			changed = append(changed, src)
+		}
+	}
+	ix.mu.RUnlock()
+
+	ix.total.Store(int64(len(changed)))
+	ix.done.Store(0)
+	parallel.Map(changed, func(src Source) struct{} {
+		fi := newFileIndex(src, load(src.FilePath))
+		ix.mu.Lock()
+		ix.files[src.FilePath] = fi
+		ix.gen++
+		ix.mu.Unlock()
+		ix.done.Add(1)
+		return struct{}{}
+	})
+
+	listed := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		listed[src.FilePath] = true
+	}
+	ix.mu.Lock()
+	for path := range ix.files {
+		if !listed[path] {
+			delete(ix.files, path)
+			ix.gen++
+		}
+	}
+	ix.mu.Unlock()
+	return true
+}
+
+// Progress returns how many of the files the running (or last) Update had to
+// read are indexed.
+func (ix *Index) Progress() (done, total int) {
+	return int(ix.done.Load()), int(ix.total.Load())
+}
+
+// Generation changes whenever the indexed content does.
+func (ix *Index) Generation() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.gen
+}
+
+// Files returns the number of indexed transcripts.
+func (ix *Index) Files() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.files)
+}
+
+// Search returns up to limit documents containing every word of query, newest
+// first. The last word also matches as a prefix, so results follow typing.
+func (ix *Index) Search(query string, limit int) []Hit {
+	words := tokenize(query)
+	if len(words) == 0 {
+		return nil
+	}
+	ix.mu.RLock()
+	var docs []Doc
+	for _, fi := range ix.files {
+		for _, i := range fi.match(words) {
+			docs = append(docs, fi.docs[i])
+		}
+	}
+	ix.mu.RUnlock()
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		if !docs[i].Timestamp.Equal(docs[j].Timestamp) {
+			return docs[i].Timestamp.After(docs[j].Timestamp)
+		}
+		return docs[i].FilePath < docs[j].FilePath
+	})
+	if len(docs) > limit {
+		docs = docs[:limit]
+	}
+	hits := make([]Hit, len(docs))
+	for i, d := range docs {
+		s, found := snippet(d.Text, words)
+		hits[i] = Hit{Doc: d, Snippet: s, Terms: words, Partial: !found && d.cut}
+	}
+	return hits
+}
+
+// FillSnippets returns hits with the snippet of each Partial hit taken from
+// its document's whole text, loading each transcript involved once with the
+// load function of the last Update. It parses transcripts, so callers run it
+// off the UI goroutine.
+func (ix *Index) FillSnippets(hits []Hit) []Hit {
+	ix.mu.RLock()
+	load := ix.load
+	ix.mu.RUnlock()
+	if load == nil {
+		return hits
+	}
+	out := slices.Clone(hits)
+	turns := make(map[string][]model.Turn)
+	for i, h := range out {
+		if !h.Partial {
+			continue
+		}
+		ts, ok := turns[h.FilePath]
+		if !ok {
+			ts = load(h.FilePath)
+			turns[h.FilePath] = ts
+		}
+		if s, found := snippet(DocText(h.Doc, ts), h.Terms); found {
+			out[i].Snippet, out[i].Partial = s, false
+		}
+	}
+	return out
+}
+
+// newFileIndex splits a transcript's turns into documents and indexes them.
+func newFileIndex(src Source, turns []model.Turn) *fileIndex {
+	fi := &fileIndex{source: src}
+	byTerm := make(map[string][]int32)
+	add := func(t model.Turn, kind Kind, tc *model.ToolCall, text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		d := Doc{Source: src, TurnUUID: t.UUID, Timestamp: t.Timestamp, Kind: kind, Text: truncate(text, maxStoredText)}
+		d.cut = len(d.Text) < len(text)
+		if tc != nil {
+			d.Tool, d.ToolID = tc.Name, tc.ID
+		}
+		for _, term := range tokenize(truncate(text, maxDocText)) {
+			byTerm[term] = append(byTerm[term], int32(len(fi.docs)))
+		}
+		fi.docs = append(fi.docs, d)
+	}
+	for _, t := range turns {
+		for _, part := range docParts(t) {
+			add(t, part.kind, part.call, part.text)
+		}
+	}
+
+	fi.terms = make([]string, 0, len(byTerm))
+	for term := range byTerm {
+		fi.terms = append(fi.terms, term)
+	}
+	sort.Strings(fi.terms)
+	fi.postings = make([][]int32, len(fi.terms))
+	for i, term := range fi.terms {
+		fi.postings[i] = byTerm[term]
+	}
+	return fi
+}
+
+// docPart is one searchable part of a turn.
+type docPart struct {
+	kind Kind
+	call *model.ToolCall // for KindToolInput and KindToolResult
+	text string
+}
+
+// docParts splits a turn into the parts that become documents.
+func docParts(t model.Turn) []docPart {
+	kind := KindAssistant
+	if t.Role == "user" {
+		kind = KindUser
+	}
+	parts := []docPart{{kind, nil, t.Text}, {KindThinking, nil, t.Thinking}}
+	for _, tc := range t.ToolCalls {
+		parts = append(parts, docPart{KindToolInput, tc, string(tc.Input)}, docPart{KindToolResult, tc, tc.ResultText()})
+	}
+	return parts
+}
+
+// DocText returns the whole text of d from its transcript's turns, or the
+// stored start of it when the turn is no longer there.
+func DocText(d Doc, turns []model.Turn) string {
+	for _, t := range turns {
+		if t.UUID != d.TurnUUID || !t.Timestamp.Equal(d.Timestamp) {
+			continue
+		}
+		for _, part := range docParts(t) {
+			if part.kind == d.Kind && (part.call == nil || part.call.ID == d.ToolID) {
+				return part.text
+			}
+		}
+	}
+	return d.Text
+}
+
+// truncate cuts s to at most n bytes without splitting a rune.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// match returns the ascending indices of the documents holding every word;
+// the last word may be a prefix of a term.
+func (fi *fileIndex) match(words []string) []int32 {
+	var ids []int32
+	for i, w := range words {
+		var found []int32
+		if i == len(words)-1 {
+			found = fi.prefixPostings(w)
+		} else if k := sort.SearchStrings(fi.terms, w); k < len(fi.terms) && fi.terms[k] == w {
+			found = fi.postings[k]
+		}
+		if i == 0 {
+			ids = found
+		} else {
+			ids = intersect(ids, found)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+	}
+	return ids
+}
+
+// prefixPostings returns the union of the postings of every term starting
+// with prefix.
+func (fi *fileIndex) prefixPostings(prefix string) []int32 {
+	k := sort.SearchStrings(fi.terms, prefix)
+	end := k
+	for end < len(fi.terms) && strings.HasPrefix(fi.terms[end], prefix) {
+		end++
+	}
+	if end-k == 1 {
+		return fi.postings[k]
+	}
+	seen := make(map[int32]bool)
+	var ids []int32
+	for _, p := range fi.postings[k:end] {
+		for _, id := range p {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// intersect returns the ids in both ascending lists.
+func intersect(a, b []int32) []int32 {
+	var out []int32
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// tokenize returns the distinct lower-cased words of s: runs of letters and
+// digits of 2 to maxTermLen characters.
+func tokenize(s string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, f := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if n := len([]rune(f)); n < 2 || n > maxTermLen || seen[f] {
+			continue
+		}
+		seen[f] = true
+		words = append(words, f)
+	}
+	return words
+}
+
+// snippetWidth is the length of a snippet in runes; snippetLead of them come
+// before the match.
+const (
+	snippetWidth = 160
+	snippetLead  = 40
+)
+
+// snippet returns one line of text around the first occurrence of any word,
+// or its start and false when none occurs.
+func snippet(text string, words []string) (string, bool) {
+	flat := strings.Join(strings.Fields(text), " ")
+	at := -1
+	for _, w := range words {
+		if i := indexFold(flat, w); i >= 0 {
+			if n := utf8.RuneCountInString(flat[:i]); at < 0 || n < at {
+				at = n
+			}
+		}
+	}
+	runes := []rune(flat)
+	start := min(max(at-snippetLead, 0), len(runes))
+	end := min(start+snippetWidth, len(runes))
+	s := string(runes[start:end])
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(runes) {
+		s += "…"
+	}
+	return s, at >= 0
+}
+
+// indexFold returns the byte offset in s of the first occurrence of the
+// lower-cased word w, comparing rune by rune in lower case so the offset
+// holds in s itself even where lower-casing changes a string's length.
+func indexFold(s, w string) int {
+	for i := range s {
+		rest, ok := s[i:], true
+		for _, wr := range w {
+			r, size := utf8.DecodeRuneInString(rest)
+			if size == 0 || unicode.ToLower(r) != wr {
+				ok = false
+				break
+			}
+			rest = rest[size:]
+		}
+		if ok {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,206 @@
+package search_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/search"
+)
+
+var t0 = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testTurns() map[string][]model.Turn {
+	return map[string][]model.Turn{
+		"/a.jsonl": {
+			{Role: "user", UUID: "u1", Text: "Please fix the flaky watcher test", Timestamp: t0},
+			{Role: "assistant", UUID: "a1", Thinking: "The watcher races on close", Timestamp: t0.Add(time.Minute),
+				ToolCalls: []*model.ToolCall{{
+					Name:   "Bash",
+					Input:  json.RawMessage(`{"command":"go test ./internal/watch"}`),
+					Result: json.RawMessage(`"ok  github.com/x/watch 0.4s"`),
+				}}},
+		},
+		"/b.jsonl": {
+			{Role: "user", UUID: "u2", Text: "Add a watcher for plugin changes", Timestamp: t0.Add(time.Hour)},
+			{Role: "assistant", UUID: "a2", Text: "Done: the plugins watcher reloads on change.", Timestamp: t0.Add(2 * time.Hour)},
+		},
+	}
+}
+
+func newTestIndex(t *testing.T) *search.Index {
+	t.Helper()
+	turns := testTurns()
+	ix := search.NewIndex()
+	sources := []search.Source{
+		{ProjectHash: "p", SessionID: "a", FilePath: "/a.jsonl", ModTime: t0},
+		{ProjectHash: "p", SessionID: "b", FilePath: "/b.jsonl", ModTime: t0},
+	}
+	ok := ix.Update(sources, func(path string) []model.Turn { return turns[path] })
+	if !ok {
+		t.Fatal("Update returned false")
+	}
+	return ix
+}
+
+func hitUUIDs(hits []search.Hit) string {
+	var ids []string
+	for _, h := range hits {
+		ids = append(ids, h.TurnUUID+"/"+string(h.Kind))
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSearch(t *testing.T) {
+	ix := newTestIndex(t)
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"watcher", "a2/assistant,u2/user,a1/thinking,u1/user"}, // newest first
+		{"WATCHER changes", "u2/user"},                          // every word, any case
+		{"watcher plug", "a2/assistant,u2/user"},                // "plug" prefixes plugin and plugins
+		{"races", "a1/thinking"},
+		{"internal watch", "a1/tool input"},
+		{"0.4s", "a1/tool result"},
+		{"nothing-here", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := hitUUIDs(ix.Search(tt.query, 100)); got != tt.want {
+			t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSearchHitContext(t *testing.T) {
+	ix := newTestIndex(t)
+	hits := ix.Search("go test", 10)
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+	h := hits[0]
+	if h.SessionID != "a" || h.ProjectHash != "p" || h.Tool != "Bash" || !h.Timestamp.Equal(t0.Add(time.Minute)) {
+		t.Errorf("hit context = %+v", h.Doc)
+	}
+	if !strings.Contains(h.Snippet, "go test ./internal/watch") {
+		t.Errorf("Snippet = %q", h.Snippet)
+	}
+	if strings.Join(h.Terms, " ") != "go test" {
+		t.Errorf("Terms = %v", h.Terms)
+	}
+	if got := ix.Search("watcher", 2); len(got) != 2 {
+		t.Errorf("limit 2 returned %d hits", len(got))
+	}
+}
+
+func TestSearchSnippetWindow(t *testing.T) {
+	ix := search.NewIndex()
+	long := strings.Repeat("lorem ipsum ", 40) + "needle\n\nfound " + strings.Repeat("dolor sit ", 40)
+	ix.Update([]search.Source{{FilePath: "/x"}}, func(string) []model.Turn {
+		return []model.Turn{{Role: "user", Text: long}}
+	})
+	hits := ix.Search("needle", 1)
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits", len(hits))
+	}
+	s := hits[0].Snippet
+	if !strings.HasPrefix(s, "…") || !strings.HasSuffix(s, "…") || !strings.Contains(s, "needle found") {
+		t.Errorf("Snippet = %q, want an ellipsized single line around the match", s)
+	}
+}
+
+func TestUpdateReadsOnlyChangedFiles(t *testing.T) {
+	ix := newTestIndex(t)
+	gen := ix.Generation()
+	turns := testTurns()
+	loads := 0 // at most one file changes per Update below, so load is not called concurrently
+	load := func(path string) []model.Turn {
+		loads++
+		return turns[path]
+	}
+
+	ix.Update([]search.Source{
+		{FilePath: "/a.jsonl", ModTime: t0},
+		{FilePath: "/b.jsonl", ModTime: t0},
+	}, load)
+	if loads != 0 || ix.Generation() != gen {
+		t.Errorf("unchanged files: %d loads, generation %d→%d", loads, gen, ix.Generation())
+	}
+
+	// b changed, a was deleted.
+	turns["/b.jsonl"] = []model.Turn{{Role: "user", UUID: "u3", Text: "rename the watcher"}}
+	ix.Update([]search.Source{{FilePath: "/b.jsonl", ModTime: t0.Add(time.Second)}}, load)
+	if loads != 1 {
+		t.Errorf("changed file: %d loads, want 1", loads)
+	}
+	if ix.Files() != 1 {
+		t.Errorf("Files = %d, want 1 after a's removal", ix.Files())
+	}
+	if got := hitUUIDs(ix.Search("watcher", 10)); got != "u3/user" {
+		t.Errorf("after update Search = %q", got)
+	}
+	if done, total := ix.Progress(); done != 1 || total != 1 {
+		t.Errorf("Progress = %d/%d, want 1/1", done, total)
+	}
+}
+
+func TestDocTextAndRunes(t *testing.T) {
+	ix := search.NewIndex()
+	// Each "é" is two bytes, so the stored prefix ends mid-text on an odd
+	// offset; each "İ" lower-cases to two runes.
+	long := "a" + strings.Repeat("é", 3000) + " needle"
+	dotted := strings.Repeat("İ", 100) + " haystack " + strings.Repeat("x ", 100)
+	turns := []model.Turn{{Role: "user", UUID: "u1", Text: long}, {Role: "user", UUID: "u2", Text: dotted}}
+	ix.Update([]search.Source{{FilePath: "/x"}}, func(string) []model.Turn { return turns })
+
+	hits := ix.Search("needle", 1)
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits", len(hits))
+	}
+	d := hits[0].Doc
+	if !utf8.ValidString(d.Text) || len(d.Text) >= len(long) || !utf8.ValidString(hits[0].Snippet) {
+		t.Errorf("stored text: %d bytes, valid %v", len(d.Text), utf8.ValidString(d.Text))
+	}
+	if got := search.DocText(d, turns); got != long {
+		t.Errorf("DocText = %d bytes, want the whole %d", len(got), len(long))
+	}
+
+	hits = ix.Search("haystack", 1)
+	if len(hits) != 1 || !strings.Contains(hits[0].Snippet, "haystack") {
+		t.Errorf("snippet around a match after İ = %v", hits)
+	}
+}
+
+func TestSnippetPastStoredText(t *testing.T) {
+	ix := search.NewIndex()
+	long := "lorem " + strings.Repeat("ipsum ", 1000) + "needle in the output " + strings.Repeat("dolor ", 100)
+	turns := []model.Turn{{Role: "assistant", UUID: "a1", ToolCalls: []*model.ToolCall{{
+		ID:     "t1",
+		Name:   "Bash",
+		Result: json.RawMessage(`"` + long + `"`),
+	}}}}
+	ix.Update([]search.Source{{FilePath: "/x"}}, func(string) []model.Turn { return turns })
+
+	hits := ix.Search("needle", 1)
+	if len(hits) != 1 {
+		t.Fatalf("got %d hits", len(hits))
+	}
+	if !hits[0].Partial || strings.Contains(hits[0].Snippet, "needle") {
+		t.Errorf("a match past the stored text: Partial %v, Snippet %q", hits[0].Partial, hits[0].Snippet)
+	}
+	filled := ix.FillSnippets(hits)
+	if s := filled[0].Snippet; filled[0].Partial || !strings.Contains(s, "needle in the output") || !strings.HasPrefix(s, "…") {
+		t.Errorf("filled Snippet = %q, want the text around the match", s)
+	}
+	if !hits[0].Partial {
+		t.Error("FillSnippets modified its argument")
+	}
+
+	if h := ix.Search("lorem", 1); len(h) != 1 || h[0].Partial || !strings.HasPrefix(h[0].Snippet, "lorem ipsum") {
+		t.Errorf("match in the stored text = %+v", h)
+	}
+}
@@ -0,0 +1,431 @@
+// Package server exposes a DataProvider as a read-only JSON REST API, with
+// server-sent events for live transcript updates, for `claudeview serve`.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/export"
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/tail"
+	"github.com/Curt-Park/claudeview/internal/transcript"
+	"github.com/Curt-Park/claudeview/internal/ui"
+	"github.com/Curt-Park/claudeview/internal/usage"
+)
+
+// Options configure a Server.
+type Options struct {
+	// Usage fetches plan usage; nil when there are no credentials.
+	Usage func(ctx context.Context) (*usage.Data, bool, error)
+	// Interval is how often event streams check for appended lines.
+	Interval time.Duration
+	// AllowOrigin, when set, is sent as Access-Control-Allow-Origin so
+	// browser dashboards on another origin can call the API.
+	AllowOrigin string
+	// AllowedHosts, when set, are the only host names a request's Host
+	// header may carry, so a web page cannot reach a server on localhost
+	// through DNS rebinding.
+	AllowedHosts []string
+}
+
+// Server serves the API for one DataProvider.
+type Server struct {
+	dp   ui.DataProvider
+	opts Options
+	// mu serializes provider calls: the live provider resolves GetAgents
+	// against the project its last GetSessions call named.
+	mu sync.Mutex
+}
+
+// New returns a server for dp.
+func New(dp ui.DataProvider, opts Options) *Server {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+	return &Server{dp: dp, opts: opts}
+}
+
+// heartbeat is how often an idle event stream sends a comment line, so
+// proxies and clients keep the connection open.
+const heartbeat = 15 * time.Second
+
+// Routes lists the API's endpoints, all GET.
+var Routes = []string{
+	"/api/projects",
+	"/api/projects/{project}/sessions",
+	"/api/projects/{project}/sessions/{session}",
+	"/api/projects/{project}/sessions/{session}/agents",
+	"/api/projects/{project}/sessions/{session}/turns?agent={agent-id}",
+	"/api/projects/{project}/sessions/{session}/events?backlog={n}",
+	"/api/projects/{project}/memories",
+	"/api/projects/{project}/memories/{name}",
+	"/api/sessions",
+	"/api/plugins?project={project}",
+	"/api/plugins/{plugin}/items?project={project}",
+	"/api/plugins/{plugin}/items/{category}/{name}?project={project}",
+	"/api/usage",
+}
+
+// Handler returns the API's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api", func(w http.ResponseWriter, r *http.Request) { s.json(w, map[string]any{"routes": Routes}) })
+	mux.HandleFunc("GET /api/projects", s.projects)
+	mux.HandleFunc("GET /api/projects/{project}/sessions", s.sessions)
+	mux.HandleFunc("GET /api/projects/{project}/sessions/{session}", s.session)
+	mux.HandleFunc("GET /api/projects/{project}/sessions/{session}/agents", s.agents)
+	mux.HandleFunc("GET /api/projects/{project}/sessions/{session}/turns", s.turns)
+	mux.HandleFunc("GET /api/projects/{project}/sessions/{session}/events", s.events)
+	mux.HandleFunc("GET /api/projects/{project}/memories", s.memories)
+	mux.HandleFunc("GET /api/projects/{project}/memories/{name}", s.memory)
+	mux.HandleFunc("GET /api/sessions", s.sessions)
+	mux.HandleFunc("GET /api/plugins", s.plugins)
+	mux.HandleFunc("GET /api/plugins/{plugin}/items", s.pluginItems)
+	mux.HandleFunc("GET /api/plugins/{plugin}/items/{category}/{name}", s.pluginItem)
+	mux.HandleFunc("GET /api/usage", s.usage)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			s.error(w, http.StatusMethodNotAllowed, "the API is read-only")
+			return
+		}
+		s.error(w, http.StatusNotFound, "no such endpoint; GET /api lists them")
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.hostAllowed(r.Host) {
+			s.error(w, http.StatusForbidden, "unexpected Host "+r.Host)
+			return
+		}
+		if s.opts.AllowOrigin != "" {
+			w.Header().Set("Access-Control-Allow-Origin", s.opts.AllowOrigin)
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// hostAllowed reports whether a request's Host header names one of
+// AllowedHosts, with any port.
+func (s *Server) hostAllowed(host string) bool {
+	if len(s.opts.AllowedHosts) == 0 {
+		return true
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	for _, allowed := range s.opts.AllowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// LoopbackHosts returns the host names to allow for a server listening on
+// addr: localhost and its loopback addresses, or nil, allowing any, when
+// addr is not a loopback address and other machines are meant to connect.
+func LoopbackHosts(addr net.Addr) []string {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok || !tcp.IP.IsLoopback() {
+		return nil
+	}
+	return []string{"localhost", "127.0.0.1", "::1", tcp.IP.String()}
+}
+
+// lock runs fn with the provider to itself.
+func (s *Server) lock(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn()
+}
+
+func (s *Server) json(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func (s *Server) error(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+func (s *Server) projects(w http.ResponseWriter, r *http.Request) {
+	out := []Project{}
+	s.lock(func() {
+		for _, p := range s.dp.GetProjects() {
+			out = append(out, newProject(p))
+		}
+	})
+	s.json(w, out)
+}
+
+// sessions lists a project's sessions, or every project's without one. The
+// latter asks for each project by hash: the live provider keeps the last
+// project it was asked for, so GetSessions("") would list only that one's.
+func (s *Server) sessions(w http.ResponseWriter, r *http.Request) {
+	out := []Session{}
+	s.lock(func() {
+		hashes := []string{r.PathValue("project")}
+		if hashes[0] == "" {
+			hashes = hashes[:0]
+			for _, p := range s.dp.GetProjects() {
+				hashes = append(hashes, p.Hash)
+			}
+		}
+		for _, hash := range hashes {
+			for _, sess := range s.dp.GetSessions(hash) {
+				out = append(out, newSession(sess, hash))
+			}
+		}
+	})
+	s.json(w, out)
+}
+
+// findSession returns the session of a project with the given ID, looking
+// inside slug groups too. Callers hold s.mu.
+func (s *Server) findSession(project, id string) *model.Session {
+	for _, sess := range s.dp.GetSessions(project) {
+		if sess.ID == id {
+			return sess
+		}
+		for _, m := range sess.GroupSessions {
+			if m.ID == id {
+				return m
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) session(w http.ResponseWriter, r *http.Request) {
+	var sess *model.Session
+	s.lock(func() { sess = s.findSession(r.PathValue("project"), r.PathValue("session")) })
+	if sess == nil {
+		s.error(w, http.StatusNotFound, "no such session")
+		return
+	}
+	s.json(w, newSession(sess, r.PathValue("project")))
+}
+
+func (s *Server) agents(w http.ResponseWriter, r *http.Request) {
+	out := []Agent{}
+	found := false
+	s.lock(func() {
+		if found = s.findSession(r.PathValue("project"), r.PathValue("session")) != nil; found {
+			for _, a := range s.dp.GetAgents(r.PathValue("session")) {
+				out = append(out, newAgent(a))
+			}
+		}
+	})
+	if !found {
+		s.error(w, http.StatusNotFound, "no such session")
+		return
+	}
+	s.json(w, out)
+}
+
+// turns returns a session's turns, or with ?agent= one subagent's, in the
+// export package's form.
+func (s *Server) turns(w http.ResponseWriter, r *http.Request) {
+	var sess *model.Session
+	s.lock(func() { sess = s.findSession(r.PathValue("project"), r.PathValue("session")) })
+	if sess == nil {
+		s.error(w, http.StatusNotFound, "no such session")
+		return
+	}
+	path := sess.FilePath
+	if agent := r.URL.Query().Get("agent"); agent != "" {
+		path = ""
+		infos, _ := transcript.ScanSubagents(sess.SubagentDir)
+		for _, si := range infos {
+			if transcript.SubagentID(si) == agent {
+				path = si.FilePath
+			}
+		}
+		if path == "" {
+			s.error(w, http.StatusNotFound, "no such subagent")
+			return
+		}
+	}
+	var turns []model.Turn
+	s.lock(func() { turns = s.dp.GetTurns(path) })
+	out := make([]export.Turn, len(turns))
+	for i, t := range turns {
+		out[i] = export.NewTurn(t, export.Options{Thinking: true, ToolOutput: true})
+	}
+	s.json(w, out)
+}
+
+// events streams a session's tail events as server-sent events: the last
+// ?backlog= events already written (default none), then new ones as they
+// are appended. Each event's name is its type and its data the event JSON.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	backlog, err := strconv.Atoi(r.URL.Query().Get("backlog"))
+	if r.URL.Query().Has("backlog") && (err != nil || backlog < 0) {
+		s.error(w, http.StatusBadRequest, "backlog must be a number of events")
+		return
+	}
+	var sess *model.Session
+	s.lock(func() { sess = s.findSession(r.PathValue("project"), r.PathValue("session")) })
+	if sess == nil {
+		s.error(w, http.StatusNotFound, "no such session")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.error(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	f := tail.NewFollower(sess, func(path string) []model.Turn {
+		var turns []model.Turn
+		s.lock(func() { turns = s.dp.GetTurns(path) })
+		return turns
+	})
+	events := f.Poll()
+	events = append([]tail.Event{tail.SessionEvent(sess)}, events[max(0, len(events)-backlog):]...)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+	id, idle := 0, time.Duration(0)
+	for {
+		for _, ev := range events {
+			data, _ := json.Marshal(ev)
+			id++
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, ev.Type, data); err != nil {
+				return
+			}
+		}
+		if len(events) > 0 {
+			idle = 0
+		} else if idle += s.opts.Interval; idle >= heartbeat {
+			idle = 0
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		events = f.Poll()
+	}
+}
+
+func (s *Server) memories(w http.ResponseWriter, r *http.Request) {
+	out := []Memory{}
+	s.lock(func() {
+		for _, m := range s.dp.GetMemories(r.PathValue("project")) {
+			out = append(out, newMemory(m))
+		}
+	})
+	s.json(w, out)
+}
+
+func (s *Server) memory(w http.ResponseWriter, r *http.Request) {
+	var mem *model.Memory
+	s.lock(func() {
+		for _, m := range s.dp.GetMemories(r.PathValue("project")) {
+			if m.Name == r.PathValue("name") {
+				mem = m
+			}
+		}
+	})
+	if mem == nil {
+		s.error(w, http.StatusNotFound, "no such memory")
+		return
+	}
+	out := newMemory(mem)
+	out.Content = mem.Content
+	if out.Content == "" && mem.Path != "" {
+		data, err := os.ReadFile(mem.Path)
+		if err != nil {
+			s.error(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		out.Content = string(data)
+	}
+	s.json(w, out)
+}
+
+func (s *Server) plugins(w http.ResponseWriter, r *http.Request) {
+	out := []Plugin{}
+	s.lock(func() {
+		for _, p := range s.dp.GetPlugins(r.URL.Query().Get("project")) {
+			out = append(out, newPlugin(p))
+		}
+	})
+	s.json(w, out)
+}
+
+// findPluginItems returns a plugin's items; ok is false, after writing a 404,
+// when there is no such plugin.
+func (s *Server) findPluginItems(w http.ResponseWriter, r *http.Request) (items []*model.PluginItem, ok bool) {
+	s.lock(func() {
+		for _, p := range s.dp.GetPlugins(r.URL.Query().Get("project")) {
+			if p.Name == r.PathValue("plugin") {
+				items, ok = s.dp.GetPluginItems(p), true
+				return
+			}
+		}
+	})
+	if !ok {
+		s.error(w, http.StatusNotFound, "no such plugin")
+	}
+	return items, ok
+}
+
+func (s *Server) pluginItems(w http.ResponseWriter, r *http.Request) {
+	items, ok := s.findPluginItems(w, r)
+	if !ok {
+		return
+	}
+	out := []PluginItem{}
+	for _, it := range items {
+		out = append(out, PluginItem{Name: it.Name, Category: it.Category})
+	}
+	s.json(w, out)
+}
+
+func (s *Server) pluginItem(w http.ResponseWriter, r *http.Request) {
+	items, ok := s.findPluginItems(w, r)
+	if !ok {
+		return
+	}
+	for _, it := range items {
+		if it.Category == r.PathValue("category") && it.Name == r.PathValue("name") {
+			s.json(w, PluginItem{Name: it.Name, Category: it.Category, Content: model.ReadPluginItemContent(it)})
+			return
+		}
+	}
+	s.error(w, http.StatusNotFound, "no such plugin item")
+}
+
+func (s *Server) usage(w http.ResponseWriter, r *http.Request) {
+	if s.opts.Usage == nil {
+		s.error(w, http.StatusNotFound, "usage is not available without Claude credentials")
+		return
+	}
+	data, stale, err := s.opts.Usage(r.Context())
+	if err != nil {
+		s.error(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	s.json(w, newUsage(data, stale))
+}
@@ -0,0 +1,186 @@
+package server_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/server"
+)
+
+// stubDP serves two projects whose turns tests can append to. Like the live
+// provider, GetSessions("") lists the last project asked for, once there is one.
+type stubDP struct {
+	mu      sync.Mutex
+	turns   map[string][]model.Turn
+	current string
+}
+
+func (d *stubDP) GetProjects() []*model.Project {
+	return []*model.Project{{Hash: "h1", Path: "/p/h1"}, {Hash: "h2", Path: "/p/h2"}}
+}
+func (d *stubDP) GetSessions(hash string) []*model.Session {
+	if hash != "" {
+		d.current = hash
+	}
+	plan := &model.Session{ID: "s-plan", ProjectHash: "h1", Slug: "brave-fox", FilePath: "plan.jsonl"}
+	exec := &model.Session{ID: "s-exec", ProjectHash: "h1", Slug: "brave-fox", FilePath: "exec.jsonl"}
+	var out []*model.Session
+	if d.current == "" || d.current == "h1" {
+		out = append(out, &model.Session{ID: exec.ID, ProjectHash: "h1", Slug: "brave-fox", FilePath: exec.FilePath, GroupSessions: []*model.Session{plan, exec}})
+	}
+	if d.current == "" || d.current == "h2" {
+		out = append(out, &model.Session{ID: "s-other", ProjectHash: "h2", FilePath: "other.jsonl"})
+	}
+	return out
+}
+func (d *stubDP) GetAgents(string) []*model.Agent {
+	return []*model.Agent{{Type: model.AgentTypeMain, Status: model.StatusDone}}
+}
+func (d *stubDP) GetPlugins(string) []*model.Plugin {
+	return []*model.Plugin{{Name: "lint", Enabled: true}}
+}
+func (d *stubDP) GetPluginItems(*model.Plugin) []*model.PluginItem {
+	return []*model.PluginItem{{Name: "fix", Category: "skill", Content: "# Fix"}}
+}
+func (d *stubDP) GetMemories(string) []*model.Memory {
+	return []*model.Memory{{Name: "notes.md", Title: "Notes", Content: "# Notes"}}
+}
+func (d *stubDP) GetTurns(path string) []model.Turn {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]model.Turn(nil), d.turns[path]...)
+}
+
+func (d *stubDP) appendTurn(path string, t model.Turn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.turns[path] = append(d.turns[path], t)
+}
+
+func newStub() *stubDP {
+	return &stubDP{turns: map[string][]model.Turn{
+		"plan.jsonl": {{Role: "user", Text: "plan it", Timestamp: time.Now()}},
+	}}
+}
+
+func TestRoutes(t *testing.T) {
+	ts := httptest.NewServer(server.New(newStub(), server.Options{}).Handler())
+	defer ts.Close()
+
+	tests := []struct {
+		method, path string
+		code         int
+		contains     string
+	}{
+		{"GET", "/api", 200, `"/api/projects"`},
+		{"GET", "/api/projects", 200, `"hash": "h1"`},
+		{"GET", "/api/sessions", 200, `"group": [`},
+		{"GET", "/api/projects/h1/sessions/s-plan", 200, `"id": "s-plan"`},
+		{"GET", "/api/sessions", 200, `"id": "s-other"`}, // still every project's after a project route
+		{"GET", "/api/projects/h1/sessions/nope", 404, `"error"`},
+		{"GET", "/api/projects/h1/sessions/s-exec/agents", 200, `"status": "done"`},
+		{"GET", "/api/projects/h1/sessions/s-plan/turns", 200, `"text": "plan it"`},
+		{"GET", "/api/projects/h1/sessions/s-plan/turns?agent=a9", 404, "no such subagent"},
+		{"GET", "/api/projects/h1/memories/notes.md", 200, `"content": "# Notes"`},
+		{"GET", "/api/plugins/lint/items/skill/fix", 200, `"content": "# Fix"`},
+		{"GET", "/api/plugins/other/items", 404, "no such plugin"},
+		{"GET", "/api/usage", 404, "credentials"},
+		{"GET", "/api/nothing", 404, "GET /api lists them"},
+		{"DELETE", "/api/projects", 405, "read-only"},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, ts.URL+tt.path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != tt.code || !strings.Contains(string(body), tt.contains) {
+			t.Errorf("%s %s = %d %s; want %d containing %q", tt.method, tt.path, resp.StatusCode, body, tt.code, tt.contains)
+		}
+		if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s Content-Type = %q", tt.method, tt.path, ct)
+		}
+	}
+}
+
+func TestEventsStreamAppendedTurns(t *testing.T) {
+	dp := newStub()
+	ts := httptest.NewServer(server.New(dp, server.Options{Interval: 10 * time.Millisecond}).Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/api/projects/h1/sessions/s-plan/events?backlog=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	// next returns the name and data of the next event on the stream.
+	sc := bufio.NewScanner(resp.Body)
+	next := func() (name string, data map[string]any) {
+		for sc.Scan() {
+			line := sc.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data)
+			case line == "" && name != "":
+				return name, data
+			}
+		}
+		t.Fatalf("stream ended: %v", sc.Err())
+		return "", nil
+	}
+
+	if name, data := next(); name != "session" || data["session"] != "s-plan" {
+		t.Errorf("first event = %s %v", name, data)
+	}
+	if name, data := next(); name != "user" || data["text"] != "plan it" {
+		t.Errorf("backlog event = %s %v", name, data)
+	}
+	dp.appendTurn("plan.jsonl", model.Turn{Role: "assistant", Text: "Here is the plan.", Timestamp: time.Now()})
+	if name, data := next(); name != "assistant" || data["text"] != "Here is the plan." {
+		t.Errorf("live event = %s %v", name, data)
+	}
+}
+
+func TestHostCheck(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts := server.LoopbackHosts(ln.Addr())
+	_ = ln.Close()
+	if server.LoopbackHosts(&net.TCPAddr{IP: net.IPv4zero, Port: 7878}) != nil {
+		t.Error("all interfaces should allow any host")
+	}
+
+	ts := httptest.NewServer(server.New(newStub(), server.Options{AllowedHosts: hosts}).Handler())
+	defer ts.Close()
+	for host, code := range map[string]int{"localhost:7878": 200, "127.0.0.1": 200, "[::1]:7878": 200, "evil.example:7878": 403} {
+		req, _ := http.NewRequest("GET", ts.URL+"/api/projects", nil)
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Errorf("Host %s: %d, want %d", host, resp.StatusCode, code)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/usage"
+)
+
+// The API's JSON shapes. They are kept apart from the model types so the
+// API stays stable while the model changes, and carry what the views show.
+
+// Tokens is token usage and its estimated cost for one model.
+type Tokens struct {
+	Input      int     `json:"input"`
+	CacheWrite int     `json:"cache_write"`
+	CacheRead  int     `json:"cache_read"`
+	Output     int     `json:"output"`
+	CostUSD    float64 `json:"cost_usd"`
+}
+
+// Project is a row of the projects view.
+type Project struct {
+	Hash     string    `json:"hash"`
+	Path     string    `json:"path"`
+	Sessions int       `json:"sessions"`
+	CostUSD  float64   `json:"cost_usd"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Session is a row of the sessions view. A slug group's row lists its
+// members, oldest first, in Group.
+type Session struct {
+	ID        string            `json:"id"`
+	Project   string            `json:"project"`
+	Slug      string            `json:"slug,omitempty"`
+	Topic     string            `json:"topic,omitempty"`
+	Branch    string            `json:"branch,omitempty"`
+	Status    string            `json:"status"`
+	Turns     int               `json:"turns"`
+	Agents    int               `json:"agents"`
+	ToolCalls int               `json:"tool_calls"`
+	FileSize  int64             `json:"file_size"`
+	Tokens    map[string]Tokens `json:"tokens"` // by model
+	CostUSD   float64           `json:"cost_usd"`
+	PID       int               `json:"pid,omitempty"` // running Claude Code process
+	StartTime time.Time         `json:"start_time"`
+	ModTime   time.Time         `json:"mod_time"`
+	Group     []string          `json:"group,omitempty"`
+}
+
+// Agent is a row of the agents view.
+type Agent struct {
+	ID           string            `json:"id"` // empty for the main agent
+	Type         string            `json:"type"`
+	Label        string            `json:"label"`
+	Subagent     bool              `json:"subagent"`
+	Unmatched    bool              `json:"unmatched,omitempty"`
+	Status       string            `json:"status"`
+	ToolCalls    int               `json:"tool_calls"`
+	Tokens       map[string]Tokens `json:"tokens"`
+	CostUSD      float64           `json:"cost_usd"`
+	LastActivity string            `json:"last_activity,omitempty"`
+	ModTime      time.Time         `json:"mod_time"`
+}
+
+// Plugin is a row of the plugins view.
+type Plugin struct {
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+	Marketplace string `json:"marketplace,omitempty"`
+	Scope       string `json:"scope"`
+	Enabled     bool   `json:"enabled"`
+	InstalledAt string `json:"installed_at,omitempty"`
+	Skills      int    `json:"skills"`
+	Commands    int    `json:"commands"`
+	Hooks       int    `json:"hooks"`
+	Agents      int    `json:"agents"`
+	MCPs        int    `json:"mcps"`
+}
+
+// PluginItem is a skill, command, hook, agent or MCP server of a plugin;
+// Content is filled when one item is requested.
+type PluginItem struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Content  string `json:"content,omitempty"`
+}
+
+// Memory is a memory file of a project; Content is filled when one memory
+// is requested.
+type Memory struct {
+	Name    string    `json:"name"`
+	Title   string    `json:"title,omitempty"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Content string    `json:"content,omitempty"`
+}
+
+// Usage is the plan usage the header bar shows.
+type Usage struct {
+	FiveHour     *Window `json:"five_hour,omitempty"`
+	SevenDay     *Window `json:"seven_day,omitempty"`
+	SevenDayOpus *Window `json:"seven_day_opus,omitempty"`
+	Stale        bool    `json:"stale"` // the last fetch failed; this is older data
+}
+
+// Window is one usage window.
+type Window struct {
+	Utilization float64    `json:"utilization"` // percent
+	ResetsAt    *time.Time `json:"resets_at,omitempty"`
+}
+
+func newTokens(byModel map[string]model.TokenCount) map[string]Tokens {
+	out := make(map[string]Tokens, len(byModel))
+	for m, tc := range byModel {
+		out[m] = Tokens{Input: tc.InputTokens, CacheWrite: tc.CacheWriteTokens, CacheRead: tc.CacheReadTokens, Output: tc.OutputTokens, CostUSD: tc.CostUSD}
+	}
+	return out
+}
+
+func newProject(p *model.Project) Project {
+	return Project{Hash: p.Hash, Path: p.Path, Sessions: p.SessionCount(), CostUSD: p.Cost(), LastSeen: p.LastSeen}
+}
+
+func newSession(s *model.Session, project string) Session {
+	out := Session{
+		ID:        s.ID,
+		Project:   project,
+		Slug:      s.Slug,
+		Topic:     s.Topic,
+		Branch:    s.Branch,
+		Status:    string(s.Status()),
+		Turns:     s.NumTurns,
+		Agents:    s.AgentCount,
+		ToolCalls: s.ToolCallCount,
+		FileSize:  s.FileSize,
+		Tokens:    newTokens(s.TokensByModel),
+		CostUSD:   s.Cost(),
+		StartTime: s.StartTime,
+		ModTime:   s.ModTime,
+	}
+	if s.Process != nil {
+		out.PID = s.Process.PID
+	}
+	for _, m := range s.GroupSessions {
+		out.Group = append(out.Group, m.ID)
+	}
+	return out
+}
+
+func newAgent(a *model.Agent) Agent {
+	return Agent{
+		ID:           a.ID,
+		Type:         string(a.Type),
+		Label:        a.DisplayName(),
+		Subagent:     a.IsSubagent,
+		Unmatched:    a.Unmatched,
+		Status:       string(a.Status),
+		ToolCalls:    len(a.ToolCalls),
+		Tokens:       newTokens(a.TokensByModel),
+		CostUSD:      a.CostUSD,
+		LastActivity: a.LastActivity,
+		ModTime:      a.ModTime,
+	}
+}
+
+func newPlugin(p *model.Plugin) Plugin {
+	return Plugin{
+		Name:        p.Name,
+		Version:     p.Version,
+		Marketplace: p.Marketplace,
+		Scope:       p.Scope,
+		Enabled:     p.Enabled,
+		InstalledAt: p.InstalledAt,
+		Skills:      p.SkillCount,
+		Commands:    p.CommandCount,
+		Hooks:       p.HookCount,
+		Agents:      p.AgentCount,
+		MCPs:        p.MCPCount,
+	}
+}
+
+func newMemory(m *model.Memory) Memory {
+	return Memory{Name: m.Name, Title: m.Title, Size: m.Size, ModTime: m.ModTime}
+}
+
+func newUsage(d *usage.Data, stale bool) Usage {
+	window := func(w *usage.Window) *Window {
+		if w == nil {
+			return nil
+		}
+		return &Window{Utilization: w.Utilization, ResetsAt: w.ResetsAt}
+	}
+	return Usage{FiveHour: window(d.FiveHour), SevenDay: window(d.SevenDay), SevenDayOpus: window(d.SevenDayOpus), Stale: stale}
+}
@@ -0,0 +1,215 @@
+// Package stats aggregates token usage, turns and estimated cost across
+// transcripts, grouped by project, session, model, day, week, branch or agent
+// type, and writes the report as a table, CSV or JSON.
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// Key is a dimension a report is grouped by.
+type Key string
+
+const (
+	KeyProject Key = "project"
+	KeySession Key = "session"
+	KeyModel   Key = "model"
+	KeyDay     Key = "day"
+	KeyWeek    Key = "week"
+	KeyBranch  Key = "branch"
+	KeyAgent   Key = "agent" // "main" or the subagent type
+)
+
+// Keys lists every key in the order they are documented.
+var Keys = []Key{KeyProject, KeySession, KeyModel, KeyDay, KeyWeek, KeyBranch, KeyAgent}
+
+// ParseKeys parses a comma-separated list of keys such as "day,model".
+func ParseKeys(s string) ([]Key, error) {
+	var keys []Key
+	seen := map[Key]bool{}
+	for _, f := range strings.Split(s, ",") {
+		k := Key(strings.ToLower(strings.TrimSpace(f)))
+		if k == "" {
+			continue
+		}
+		if !isKey(k) {
+			names := make([]string, len(Keys))
+			for i, k := range Keys {
+				names[i] = string(k)
+			}
+			return nil, fmt.Errorf("unknown group key %q (want %s)", k, strings.Join(names, ", "))
+		}
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no group key given")
+	}
+	return keys, nil
+}
+
+func isKey(k Key) bool {
+	for _, known := range Keys {
+		if k == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Transcript is one transcript's turns with the values it is grouped by.
+type Transcript struct {
+	Project   string // project hash, as the projects view names it
+	SessionID string
+	Branch    string
+	Agent     string // "main" or the subagent type
+	Turns     []model.Turn
+}
+
+// Range limits a report to turns at or after From and before To; a zero
+// bound is open.
+type Range struct {
+	From, To time.Time
+}
+
+func (r Range) contains(t time.Time) bool {
+	if r.From.IsZero() && r.To.IsZero() {
+		return true
+	}
+	return !t.IsZero() && !t.Before(r.From) && (r.To.IsZero() || t.Before(r.To))
+}
+
+// Usage is what a report row adds up.
+type Usage struct {
+	Sessions   int     `json:"sessions"`
+	Turns      int     `json:"turns"` // assistant turns, one per API call
+	ToolCalls  int     `json:"tool_calls"`
+	Input      int     `json:"input_tokens"`
+	CacheWrite int     `json:"cache_write_tokens"`
+	CacheRead  int     `json:"cache_read_tokens"`
+	Output     int     `json:"output_tokens"`
+	CostUSD    float64 `json:"cost_usd"`
+}
+
+// Tokens returns all tokens of u.
+func (u Usage) Tokens() int {
+	return u.Input + u.CacheWrite + u.CacheRead + u.Output
+}
+
+// Row is the usage of one group; Group holds its value for each key.
+type Row struct {
+	Group []string
+	Usage
+}
+
+// Report is usage grouped by Keys over Range, with the overall Total.
+type Report struct {
+	Keys  []Key
+	Range Range
+	Rows  []Row
+	Total Usage
+}
+
+// Aggregate adds up the assistant turns of transcripts inside r by keys.
+// Rows grouped by day or week are in time order, others by cost, highest
+// first.
+func Aggregate(transcripts []Transcript, keys []Key, r Range) Report {
+	type acc struct {
+		Usage
+		sessions map[string]bool
+	}
+	groups := map[string]*acc{}
+	values := map[string][]string{}
+	all := acc{sessions: map[string]bool{}}
+	add := func(a *acc, sessionID string, t model.Turn) {
+		a.Turns++
+		a.ToolCalls += len(t.ToolCalls)
+		a.Input += t.InputTokens
+		a.CacheWrite += t.CacheWriteTokens
+		a.CacheRead += t.CacheReadTokens
+		a.Output += t.OutputTokens
+		a.CostUSD += t.CostUSD
+		a.sessions[sessionID] = true
+	}
+	for _, tr := range transcripts {
+		for _, t := range tr.Turns {
+			if t.Role != "assistant" || !r.contains(t.Timestamp) {
+				continue
+			}
+			group := make([]string, len(keys))
+			for i, k := range keys {
+				group[i] = tr.value(k, t)
+			}
+			id := strings.Join(group, "\x00")
+			a := groups[id]
+			if a == nil {
+				a = &acc{sessions: map[string]bool{}}
+				groups[id] = a
+				values[id] = group
+			}
+			add(a, tr.SessionID, t)
+			add(&all, tr.SessionID, t)
+		}
+	}
+
+	rep := Report{Keys: keys, Range: r}
+	for id, a := range groups {
+		a.Sessions = len(a.sessions)
+		rep.Rows = append(rep.Rows, Row{Group: values[id], Usage: a.Usage})
+	}
+	all.Sessions = len(all.sessions)
+	rep.Total = all.Usage
+
+	byTime := false
+	for _, k := range keys {
+		byTime = byTime || k == KeyDay || k == KeyWeek
+	}
+	sort.Slice(rep.Rows, func(i, j int) bool {
+		a, b := rep.Rows[i], rep.Rows[j]
+		if !byTime && a.CostUSD != b.CostUSD {
+			return a.CostUSD > b.CostUSD
+		}
+		if !byTime && a.Tokens() != b.Tokens() {
+			return a.Tokens() > b.Tokens()
+		}
+		return strings.Join(a.Group, "\x00") < strings.Join(b.Group, "\x00")
+	})
+	return rep
+}
+
+// value returns the value of key k for turn t of the transcript.
+func (tr Transcript) value(k Key, t model.Turn) string {
+	var v string
+	switch k {
+	case KeyProject:
+		v = tr.Project
+	case KeySession:
+		v = tr.SessionID
+	case KeyModel:
+		v = t.ModelName
+	case KeyDay:
+		if !t.Timestamp.IsZero() {
+			v = t.Timestamp.Local().Format("2006-01-02")
+		}
+	case KeyWeek:
+		if !t.Timestamp.IsZero() {
+			y, w := t.Timestamp.Local().ISOWeek()
+			v = fmt.Sprintf("%d-W%02d", y, w)
+		}
+	case KeyBranch:
+		v = tr.Branch
+	case KeyAgent:
+		v = tr.Agent
+	}
+	if v == "" {
+		return "-"
+	}
+	return v
+}
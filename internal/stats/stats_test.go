@@ -0,0 +1,120 @@
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/stats"
+)
+
+func sampleTranscripts() []stats.Transcript {
+	d1 := time.Date(2026, 3, 2, 12, 0, 0, 0, time.Local)
+	d2 := d1.AddDate(0, 0, 1)
+	turn := func(ts time.Time, m string, in, out int, cost float64, tools int) model.Turn {
+		return model.Turn{Role: "assistant", Timestamp: ts, ModelName: m, InputTokens: in, OutputTokens: out, CostUSD: cost, ToolCalls: make([]*model.ToolCall, tools)}
+	}
+	return []stats.Transcript{
+		{Project: "/a", SessionID: "s1", Branch: "main", Agent: "main", Turns: []model.Turn{
+			{Role: "user", Timestamp: d1, InputTokens: 999},
+			turn(d1, "claude-opus-4-6", 100, 10, 1.0, 2),
+			turn(d2, "claude-sonnet-4-6", 50, 5, 0.25, 0),
+		}},
+		{Project: "/a", SessionID: "s1", Branch: "main", Agent: "Explore", Turns: []model.Turn{
+			turn(d1, "claude-sonnet-4-6", 20, 2, 0.5, 1),
+		}},
+		{Project: "/b", SessionID: "s2", Agent: "main", Turns: []model.Turn{
+			turn(d2, "claude-opus-4-6", 300, 30, 3.0, 0),
+		}},
+	}
+}
+
+func TestAggregateGroupsAndSorts(t *testing.T) {
+	keys, err := stats.ParseKeys("project, agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rep := stats.Aggregate(sampleTranscripts(), keys, stats.Range{})
+	var got []string
+	for _, r := range rep.Rows {
+		got = append(got, strings.Join(r.Group, "/"))
+	}
+	// By cost, highest first; user turns are not counted.
+	if strings.Join(got, ",") != "/b/main,/a/main,/a/Explore" {
+		t.Fatalf("rows = %v", got)
+	}
+	if a := rep.Rows[1]; a.Turns != 2 || a.Input != 150 || a.ToolCalls != 2 || a.Sessions != 1 {
+		t.Errorf("/a main = %+v", a.Usage)
+	}
+	if rep.Total.Sessions != 2 || rep.Total.Turns != 4 || rep.Total.CostUSD != 4.75 {
+		t.Errorf("total = %+v", rep.Total)
+	}
+	if _, err := stats.ParseKeys("day,colour"); err == nil {
+		t.Error("ParseKeys accepted an unknown key")
+	}
+}
+
+func TestAggregateByDayInRange(t *testing.T) {
+	from := time.Date(2026, 3, 3, 0, 0, 0, 0, time.Local)
+	rep := stats.Aggregate(sampleTranscripts(), []stats.Key{stats.KeyDay, stats.KeyBranch}, stats.Range{From: from})
+	if len(rep.Rows) != 2 {
+		t.Fatalf("rows = %+v", rep.Rows)
+	}
+	// Day groups are in time order; a missing branch shows as "-".
+	if g := strings.Join(rep.Rows[0].Group, " "); g != "2026-03-03 -" {
+		t.Errorf("first row = %q", g)
+	}
+	if rep.Total.Turns != 2 || rep.Total.Input != 350 {
+		t.Errorf("total = %+v", rep.Total)
+	}
+
+	week := stats.Aggregate(sampleTranscripts(), []stats.Key{stats.KeyWeek}, stats.Range{})
+	if len(week.Rows) != 1 || week.Rows[0].Group[0] != "2026-W10" {
+		t.Errorf("week rows = %+v", week.Rows)
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	rep := stats.Aggregate(sampleTranscripts(), []stats.Key{stats.KeyModel}, stats.Range{})
+
+	var table bytes.Buffer
+	if err := stats.Write(&table, stats.FormatTable, rep); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "MODEL") || !strings.HasPrefix(lines[3], "TOTAL") {
+		t.Errorf("table:\n%s", table.String())
+	}
+
+	var csv bytes.Buffer
+	if err := stats.Write(&csv, stats.FormatCSV, rep); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(csv.String(), "claude-opus-4-6,2,2,2,400,0,0,40,4.000000\n") {
+		t.Errorf("csv:\n%s", csv.String())
+	}
+
+	var js bytes.Buffer
+	if err := stats.Write(&js, stats.FormatJSON, rep); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Rows []struct {
+			Group map[string]string `json:"group"`
+			Turns int               `json:"turns"`
+		} `json:"rows"`
+	}
+	if err := json.Unmarshal(js.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Rows) != 2 || out.Rows[0].Group["model"] != "claude-opus-4-6" || out.Rows[0].Turns != 2 {
+		t.Errorf("json:\n%s", js.String())
+	}
+
+	if _, err := stats.ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat accepted xml")
+	}
+}
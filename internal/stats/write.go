@@ -0,0 +1,144 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// Format names a report output format.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatCSV   Format = "csv"
+	FormatJSON  Format = "json"
+)
+
+// ParseFormat returns the format s names: table, csv or json.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatTable, FormatCSV, FormatJSON:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown stats format %q (want table, csv or json)", s)
+}
+
+// Write writes rep to w in format f.
+func Write(w io.Writer, f Format, rep Report) error {
+	switch f {
+	case FormatCSV:
+		return WriteCSV(w, rep)
+	case FormatJSON:
+		return WriteJSON(w, rep)
+	}
+	return WriteTable(w, rep)
+}
+
+// WriteTable writes rep as aligned columns with human-readable token counts
+// and costs, and a TOTAL row.
+func WriteTable(w io.Writer, rep Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var head []string
+	for _, k := range rep.Keys {
+		head = append(head, strings.ToUpper(string(k)))
+	}
+	head = append(head, "SESSIONS", "TURNS", "TOOLS", "INPUT", "CACHE W", "CACHE R", "OUTPUT", "COST")
+	line := func(cells []string) {
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	line(head)
+	for _, r := range rep.Rows {
+		line(append(append([]string{}, r.Group...), tableCells(r.Usage)...))
+	}
+	total := make([]string, len(rep.Keys))
+	total[0] = "TOTAL"
+	line(append(total, tableCells(rep.Total)...))
+	return tw.Flush()
+}
+
+func tableCells(u Usage) []string {
+	return []string{
+		strconv.Itoa(u.Sessions),
+		strconv.Itoa(u.Turns),
+		strconv.Itoa(u.ToolCalls),
+		model.FormatTokenCount(u.Input),
+		model.FormatTokenCount(u.CacheWrite),
+		model.FormatTokenCount(u.CacheRead),
+		model.FormatTokenCount(u.Output),
+		model.FormatCost(u.CostUSD),
+	}
+}
+
+// WriteCSV writes rep as CSV with a header row and raw numbers; the total is
+// left out so the rows can be summed or pivoted as they are.
+func WriteCSV(w io.Writer, rep Report) error {
+	cw := csv.NewWriter(w)
+	var head []string
+	for _, k := range rep.Keys {
+		head = append(head, string(k))
+	}
+	head = append(head, "sessions", "turns", "tool_calls", "input_tokens", "cache_write_tokens", "cache_read_tokens", "output_tokens", "cost_usd")
+	if err := cw.Write(head); err != nil {
+		return err
+	}
+	for _, r := range rep.Rows {
+		rec := append(append([]string{}, r.Group...),
+			strconv.Itoa(r.Sessions),
+			strconv.Itoa(r.Turns),
+			strconv.Itoa(r.ToolCalls),
+			strconv.Itoa(r.Input),
+			strconv.Itoa(r.CacheWrite),
+			strconv.Itoa(r.CacheRead),
+			strconv.Itoa(r.Output),
+			strconv.FormatFloat(r.CostUSD, 'f', 6, 64),
+		)
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+type jsonReport struct {
+	By    []Key      `json:"by"`
+	Since *time.Time `json:"since,omitempty"`
+	Until *time.Time `json:"until,omitempty"`
+	Rows  []jsonRow  `json:"rows"`
+	Total Usage      `json:"total"`
+}
+
+type jsonRow struct {
+	Group map[Key]string `json:"group"`
+	Usage
+}
+
+// WriteJSON writes rep as indented JSON: the keys, the range, one object per
+// row with its group values by key, and the total.
+func WriteJSON(w io.Writer, rep Report) error {
+	out := jsonReport{By: rep.Keys, Rows: []jsonRow{}, Total: rep.Total}
+	if !rep.Range.From.IsZero() {
+		out.Since = &rep.Range.From
+	}
+	if !rep.Range.To.IsZero() {
+		out.Until = &rep.Range.To
+	}
+	for _, r := range rep.Rows {
+		g := make(map[Key]string, len(rep.Keys))
+		for i, k := range rep.Keys {
+			g[k] = r.Group[i]
+		}
+		out.Rows = append(out.Rows, jsonRow{Group: g, Usage: r.Usage})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
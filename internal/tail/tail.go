@@ -0,0 +1,183 @@
+// Package tail turns a session's growing transcripts into a stream of events —
+// prompts, replies, tool calls and their results, subagent activity — for
+// `claudeview tail`, and writes them as colored lines or NDJSON.
+package tail
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/transcript"
+)
+
+// Event types.
+const (
+	TypeSession    = "session" // the session being followed; first event and on switches
+	TypeUser       = "user"
+	TypeAssistant  = "assistant"
+	TypeSystem     = "system" // compaction markers
+	TypeToolCall   = "tool_call"
+	TypeToolResult = "tool_result"
+)
+
+// Event is one thing that happened in a followed session.
+type Event struct {
+	Time       time.Time       `json:"time"`
+	Session    string          `json:"session"`
+	Agent      string          `json:"agent"`              // "main" or the subagent type
+	AgentID    string          `json:"agent_id,omitempty"` // subagents only
+	Type       string          `json:"type"`
+	Text       string          `json:"text,omitempty"` // message text, result text, or session topic
+	Model      string          `json:"model,omitempty"`
+	Tool       string          `json:"tool,omitempty"`
+	ToolID     string          `json:"tool_id,omitempty"`
+	Input      json.RawMessage `json:"input,omitempty"`
+	IsError    bool            `json:"is_error,omitempty"`
+	DurationMS int64           `json:"duration_ms,omitempty"`
+}
+
+// SessionEvent returns the event that introduces session s.
+func SessionEvent(s *model.Session) Event {
+	return Event{Time: s.ModTime, Session: s.ID, Agent: string(model.AgentTypeMain), Type: TypeSession, Text: s.Topic}
+}
+
+// Follower reports what was appended to a session's main and subagent
+// transcripts since its last Poll. Turns are read through a provider's
+// GetTurns, which on the live provider decodes only the appended lines.
+type Follower struct {
+	session  *model.Session
+	getTurns func(path string) []model.Turn
+	main     *Diff
+	subs     map[string]*subagent // by transcript path
+}
+
+type subagent struct {
+	id, agentType string
+	diff          *Diff
+}
+
+// NewFollower returns a follower for session s.
+func NewFollower(s *model.Session, getTurns func(path string) []model.Turn) *Follower {
+	return &Follower{session: s, getTurns: getTurns, main: &Diff{}, subs: map[string]*subagent{}}
+}
+
+// Poll returns the events appended since the last Poll, oldest first; the
+// first Poll returns everything already written.
+func (f *Follower) Poll() []Event {
+	s := f.session
+	turns := f.getTurns(s.FilePath)
+	events := f.main.Next(turns, s.ID, string(model.AgentTypeMain), "")
+
+	if s.SubagentDir != "" {
+		infos, _ := transcript.ScanSubagents(s.SubagentDir)
+		ids := make([]string, len(infos))
+		for i, si := range infos {
+			ids[i] = transcript.SubagentID(si)
+		}
+		calls := model.SubagentCalls(turns)
+		types := model.ExtractAgentTypesFromCalls(calls)
+		byCall, _ := model.MatchSubagents(calls, ids)
+		typeOf := map[int]model.AgentType{}
+		for k, i := range byCall {
+			if i >= 0 {
+				typeOf[i] = types[k]
+			}
+		}
+		for i, si := range infos {
+			sub := f.subs[si.FilePath]
+			if sub == nil {
+				sub = &subagent{id: ids[i], agentType: string(model.AgentTypeGeneral), diff: &Diff{}}
+				f.subs[si.FilePath] = sub
+			}
+			if t, ok := typeOf[i]; ok && t != "" {
+				sub.agentType = string(t)
+			}
+			events = append(events, sub.diff.Next(f.getTurns(si.FilePath), s.ID, sub.agentType, sub.id)...)
+		}
+	}
+	// Each transcript's events are in order; interleave them by time.
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
+
+// Diff remembers what it has reported of one transcript's turns.
+type Diff struct {
+	turns []turnState
+}
+
+type turnState struct {
+	text    string
+	calls   map[string]bool
+	results map[string]bool
+}
+
+// Next returns the events for what turns holds beyond the previous call:
+// new turns, text added to an assistant turn still being written, and new
+// tool calls and results.
+func (d *Diff) Next(turns []model.Turn, sessionID, agent, agentID string) []Event {
+	if len(turns) < len(d.turns) {
+		d.turns = d.turns[:len(turns)] // the transcript was rewritten
+	}
+	var events []Event
+	emit := func(ev Event) {
+		ev.Session, ev.Agent, ev.AgentID = sessionID, agent, agentID
+		events = append(events, ev)
+	}
+	for i, t := range turns {
+		if i == len(d.turns) {
+			d.turns = append(d.turns, turnState{calls: map[string]bool{}, results: map[string]bool{}})
+		}
+		st := &d.turns[i]
+		if t.Text != st.text {
+			text := t.Text
+			if strings.HasPrefix(text, st.text) {
+				text = strings.TrimLeft(text[len(st.text):], "\n")
+			}
+			st.text = t.Text
+			if text != "" {
+				emit(Event{Time: t.Timestamp, Type: roleType(t.Role), Text: text, Model: t.ModelName})
+			}
+		}
+		for k, tc := range t.ToolCalls {
+			key := tc.ID
+			if key == "" {
+				key = "#" + strconv.Itoa(k)
+			}
+			start := tc.Timestamp
+			if start.IsZero() {
+				start = t.Timestamp
+			}
+			if !st.calls[key] {
+				st.calls[key] = true
+				emit(Event{Time: start, Type: TypeToolCall, Tool: tc.Name, ToolID: tc.ID, Input: tc.Input, Model: t.ModelName})
+			}
+			if tc.Result != nil && !st.results[key] {
+				st.results[key] = true
+				emit(Event{
+					Time:       start.Add(tc.Duration),
+					Type:       TypeToolResult,
+					Tool:       tc.Name,
+					ToolID:     tc.ID,
+					Text:       tc.ResultText(),
+					IsError:    tc.IsError,
+					DurationMS: tc.Duration.Milliseconds(),
+				})
+			}
+		}
+	}
+	return events
+}
+
+func roleType(role string) string {
+	switch role {
+	case "user":
+		return TypeUser
+	case "system":
+		return TypeSystem
+	}
+	return TypeAssistant
+}
@@ -0,0 +1,119 @@
+package tail_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/tail"
+)
+
+func types(events []tail.Event) string {
+	var ts []string
+	for _, ev := range events {
+		ts = append(ts, ev.Type)
+	}
+	return strings.Join(ts, ",")
+}
+
+func TestDiffReportsOnlyWhatIsNew(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	call := &model.ToolCall{ID: "t1", Name: "Bash", Input: json.RawMessage(`{"command":"go test"}`), Timestamp: t0.Add(2 * time.Second)}
+	turns := []model.Turn{
+		{Role: "user", Text: "run the tests", Timestamp: t0},
+		{Role: "assistant", Text: "Running them.", Timestamp: t0.Add(time.Second), ToolCalls: []*model.ToolCall{call}},
+	}
+	var d tail.Diff
+	if got := types(d.Next(turns, "s1", "main", "")); got != "user,assistant,tool_call" {
+		t.Fatalf("first = %s", got)
+	}
+	if got := d.Next(turns, "s1", "main", ""); len(got) != 0 {
+		t.Fatalf("unchanged turns gave %v", got)
+	}
+
+	// The pending turn grows by a text block and its call gets a result.
+	done := *call
+	done.Result, done.IsError, done.Duration = json.RawMessage(`"FAIL"`), true, 3*time.Second
+	turns[1].Text += "\nThey fail."
+	turns[1].ToolCalls = []*model.ToolCall{&done}
+	turns = append(turns, model.Turn{Role: "system", Text: "Conversation compacted", Timestamp: t0.Add(9 * time.Second)})
+	got := d.Next(turns, "s1", "main", "")
+	if types(got) != "assistant,tool_result,system" {
+		t.Fatalf("second = %s", types(got))
+	}
+	if got[0].Text != "They fail." {
+		t.Errorf("added text = %q", got[0].Text)
+	}
+	if r := got[1]; !r.IsError || r.Text != "FAIL" || r.DurationMS != 3000 || !r.Time.Equal(t0.Add(5*time.Second)) {
+		t.Errorf("result = %+v", r)
+	}
+}
+
+func TestFollowerInterleavesSubagents(t *testing.T) {
+	dir := t.TempDir()
+	subPath := filepath.Join(dir, "agent-a1.jsonl")
+	if err := os.WriteFile(subPath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	spawn := &model.ToolCall{ID: "t1", Name: "Task", Input: json.RawMessage(`{"subagent_type":"Explore"}`), Timestamp: t0, SubagentID: "a1"}
+	turns := map[string][]model.Turn{
+		"main.jsonl": {{Role: "assistant", Timestamp: t0, ToolCalls: []*model.ToolCall{spawn}}},
+		subPath:      {{Role: "assistant", Text: "found it", Timestamp: t0.Add(time.Second)}},
+	}
+	s := &model.Session{ID: "s1", FilePath: "main.jsonl", SubagentDir: dir}
+	f := tail.NewFollower(s, func(path string) []model.Turn { return turns[path] })
+
+	events := f.Poll()
+	if types(events) != "tool_call,assistant" {
+		t.Fatalf("events = %s", types(events))
+	}
+	if sub := events[1]; sub.Agent != "Explore" || sub.AgentID != "a1" || sub.Session != "s1" {
+		t.Errorf("subagent event = %+v", sub)
+	}
+	if more := f.Poll(); len(more) != 0 {
+		t.Errorf("second poll = %v", more)
+	}
+}
+
+func TestWriters(t *testing.T) {
+	t0 := time.Date(2026, 1, 2, 10, 0, 0, 0, time.Local)
+	events := []tail.Event{
+		{Time: t0, Session: "s1", Agent: "main", Type: tail.TypeAssistant, Text: "line one\n\nline two"},
+		{Time: t0, Session: "s1", Agent: "main", Type: tail.TypeToolCall, Tool: "Bash", Input: json.RawMessage(`{"command":"make"}`)},
+		{Time: t0, Session: "s1", Agent: "Explore", AgentID: "a1", Type: tail.TypeToolResult, Tool: "Read", IsError: true, Text: "no such file", DurationMS: 1500},
+	}
+	never := false
+	var text bytes.Buffer
+	w := tail.NewTextWriter(&text, &never)
+	for _, ev := range events {
+		if err := w.Write(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "10:00:00 claude       line one\n" +
+		"10:00:00 claude       line two\n" +
+		"10:00:00 claude       → Bash  make\n" +
+		"10:00:00 ↳ Explorer   ✗ Read  1.5s  no such file\n"
+	if text.String() != want {
+		t.Errorf("text:\n%s\nwant:\n%s", text.String(), want)
+	}
+
+	var js bytes.Buffer
+	jw := tail.NewJSONWriter(&js)
+	for _, ev := range events {
+		if err := jw.Write(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines := strings.Split(strings.TrimSpace(js.String()), "\n")
+	var last tail.Event
+	if len(lines) != 3 || json.Unmarshal([]byte(lines[2]), &last) != nil || last.AgentID != "a1" || !last.IsError {
+		t.Errorf("ndjson:\n%s", js.String())
+	}
+}
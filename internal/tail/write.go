@@ -0,0 +1,140 @@
+package tail
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// Writer writes events as they arrive.
+type Writer interface {
+	Write(ev Event) error
+}
+
+// NewJSONWriter returns a Writer that writes one JSON object per line.
+func NewJSONWriter(w io.Writer) Writer {
+	return jsonWriter{json.NewEncoder(w)}
+}
+
+type jsonWriter struct{ enc *json.Encoder }
+
+func (j jsonWriter) Write(ev Event) error { return j.enc.Encode(ev) }
+
+// TextWriter writes events as lines prefixed with the time and who acted, so
+// every line of a multi-line message can be matched by grep on its own.
+type TextWriter struct {
+	w                                      io.Writer
+	time, user, claude, sub, ok, err, rule lipgloss.Style
+}
+
+// NewTextWriter returns a TextWriter; colors follow what w supports (none
+// when it is not a terminal or NO_COLOR is set) unless color forces them on
+// or off.
+func NewTextWriter(w io.Writer, color *bool) *TextWriter {
+	r := lipgloss.NewRenderer(w)
+	if color != nil && !*color {
+		r.SetColorProfile(termenv.Ascii)
+	} else if color != nil {
+		r.SetColorProfile(termenv.ANSI)
+	}
+	return &TextWriter{
+		w:      w,
+		time:   r.NewStyle().Foreground(lipgloss.Color("8")),
+		user:   r.NewStyle().Foreground(lipgloss.Color("6")).Bold(true),
+		claude: r.NewStyle().Foreground(lipgloss.Color("5")).Bold(true),
+		sub:    r.NewStyle().Foreground(lipgloss.Color("3")),
+		ok:     r.NewStyle().Foreground(lipgloss.Color("2")),
+		err:    r.NewStyle().Foreground(lipgloss.Color("1")).Bold(true),
+		rule:   r.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+}
+
+// whoWidth is the width of the who column; subagent labels are cut to fit.
+const whoWidth = 12
+
+// Write implements Writer.
+func (t *TextWriter) Write(ev Event) error {
+	if ev.Type == TypeSession {
+		line := "session " + ev.Session
+		if ev.Text != "" {
+			line += " · " + firstLine(ev.Text, 80)
+		}
+		_, err := fmt.Fprintln(t.w, t.rule.Render("── "+line+" ──"))
+		return err
+	}
+
+	prefix := t.time.Render(clock(ev.Time)) + " " + t.who(ev) + " "
+	var lines []string
+	switch ev.Type {
+	case TypeToolCall:
+		tc := model.ToolCall{Name: ev.Tool, Input: ev.Input}
+		lines = []string{"→ " + ev.Tool + "  " + firstLine(tc.InputSummary(), 160)}
+	case TypeToolResult:
+		tc := model.ToolCall{Duration: time.Duration(ev.DurationMS) * time.Millisecond}
+		line := t.ok.Render("✓") + " " + ev.Tool + "  " + tc.DurationString()
+		if ev.IsError {
+			line = t.err.Render("✗") + " " + ev.Tool + "  " + tc.DurationString() + "  " + t.err.Render(firstLine(ev.Text, 120))
+		}
+		lines = []string{line}
+	default:
+		for _, l := range strings.Split(ev.Text, "\n") {
+			if strings.TrimSpace(l) != "" {
+				lines = append(lines, l)
+			}
+		}
+	}
+	var sb strings.Builder
+	for _, l := range lines {
+		sb.WriteString(prefix + l + "\n")
+	}
+	_, err := io.WriteString(t.w, sb.String())
+	return err
+}
+
+// who renders the who column: you, claude or system for the main agent,
+// ↳ and the agent type for subagents.
+func (t *TextWriter) who(ev Event) string {
+	var s string
+	style := t.claude
+	switch {
+	case ev.AgentID != "" || ev.Agent != string(model.AgentTypeMain):
+		s, style = "↳ "+model.AgentType(ev.Agent).DisplayLabel(), t.sub
+	case ev.Type == TypeUser:
+		s, style = "you", t.user
+	case ev.Type == TypeSystem:
+		s, style = "system", t.time
+	default:
+		s = "claude"
+	}
+	if r := []rune(s); len(r) > whoWidth {
+		s = string(r[:whoWidth-1]) + "…"
+	}
+	return style.Render(s + strings.Repeat(" ", whoWidth-len([]rune(s))))
+}
+
+func clock(t time.Time) string {
+	if t.IsZero() {
+		return "--:--:--"
+	}
+	return t.Local().Format("15:04:05")
+}
+
+// firstLine returns the first non-blank line of s, cut to n runes.
+func firstLine(s string, n int) string {
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			if r := []rune(l); len(r) > n {
+				return string(r[:n-1]) + "…"
+			}
+			return l
+		}
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package transcript
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// interruptMarker starts the text Claude Code writes when the user cancels a
+// response or a running tool.
+const interruptMarker = "[Request interrupted by user"
+
+// Activity is the conversation state at the end of a transcript: whether a
+// response is still awaited and which tool calls have not returned yet.
+// Live status is inferred from it together with the file's mtime.
+type Activity struct {
+	Waiting      bool              `json:"waiting,omitempty"`      // a prompt or tool results were sent and no response has started
+	ThinkingOnly bool              `json:"thinkingOnly,omitempty"` // the latest assistant entry carried only thinking
+	OpenTools    map[string]string `json:"openTools,omitempty"`    // tool_use id → tool name, awaiting a result
+}
+
+// handle advances the activity state by one event.
+func (a *Activity) handle(ev Event) {
+	switch ev.Kind {
+	case EventUser:
+		interrupted := strings.HasPrefix(ev.Text, interruptMarker)
+		for _, r := range ev.ToolResults {
+			delete(a.OpenTools, r.ToolUseID)
+			if bytes.Contains(r.Content, []byte(interruptMarker)) {
+				interrupted = true
+			}
+		}
+		a.ThinkingOnly = false
+		if interrupted {
+			a.Waiting = false
+			a.OpenTools = nil
+		} else if ev.Text != "" || len(ev.ToolResults) > 0 {
+			a.Waiting = true
+		}
+
+	case EventAssistant:
+		turn := ev.Turn
+		a.Waiting = false
+		a.ThinkingOnly = turn.Thinking != "" && turn.Text == "" && len(turn.ToolCalls) == 0
+		for _, tc := range turn.ToolCalls {
+			if a.OpenTools == nil {
+				a.OpenTools = make(map[string]string)
+			}
+			a.OpenTools[tc.ID] = tc.Name
+		}
+
+	case EventTurnDuration:
+		// Written once Claude Code has finished the turn.
+		*a = Activity{}
+	}
+}
+
+// clone returns a deep copy of a.
+func (a Activity) clone() Activity {
+	if a.OpenTools == nil {
+		return a
+	}
+	open := make(map[string]string, len(a.OpenTools))
+	for id, name := range a.OpenTools {
+		open[id] = name
+	}
+	a.OpenTools = open
+	return a
+}
+
+// ToolNames returns the names of the open tool calls, sorted.
+func (a Activity) ToolNames() []string {
+	names := make([]string, 0, len(a.OpenTools))
+	for _, name := range a.OpenTools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
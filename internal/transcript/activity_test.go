@@ -0,0 +1,39 @@
+package transcript_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/transcript"
+)
+
+func TestActivityFollowsTranscriptTail(t *testing.T) {
+	steps := []struct {
+		line         string
+		waiting      bool
+		thinkingOnly bool
+		open         []string
+	}{
+		{`{"type":"user","message":{"role":"user","content":"run the tests"}}`, true, false, nil},
+		{`{"type":"assistant","message":{"role":"assistant","content":[{"type":"thinking","thinking":"hmm"}],"model":"m"}}`, false, true, nil},
+		{`{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Bash","input":{}},{"type":"tool_use","id":"t2","name":"Read","input":{}}],"model":"m"}}`, false, false, []string{"Bash", "Read"}},
+		{`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t2","content":"ok"}]}}`, true, false, []string{"Bash"}},
+		{`{"type":"progress","data":{}}`, true, false, []string{"Bash"}},
+		{`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"[Request interrupted by user for tool use]","is_error":true}]}}`, false, false, nil},
+		{`{"type":"user","message":{"role":"user","content":"try again"}}`, true, false, nil},
+		{`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"done"}],"model":"m"}}`, false, false, nil},
+		{`{"type":"system","subtype":"turn_duration","durationMs":1500}`, false, false, nil},
+	}
+	agg := &transcript.SessionAggregates{}
+	for i, s := range steps {
+		if err := transcript.Decode(strings.NewReader(s.line+"\n"), agg); err != nil {
+			t.Fatalf("step %d: Decode failed: %v", i, err)
+		}
+		a := agg.Activity
+		if a.Waiting != s.waiting || a.ThinkingOnly != s.thinkingOnly || !slices.Equal(a.ToolNames(), s.open) {
+			t.Errorf("step %d: activity = {waiting=%v thinkingOnly=%v open=%v}, want {waiting=%v thinkingOnly=%v open=%v}",
+				i, a.Waiting, a.ThinkingOnly, a.ToolNames(), s.waiting, s.thinkingOnly, s.open)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// EventKind identifies the type of a decoded transcript event.
+type EventKind int
+
+const (
+	// EventOther is any entry that carries no conversation content
+	// (progress, snapshots, unknown types, undecodable messages).
+	EventOther EventKind = iota
+	// EventUser is a user entry: typed text and/or tool results.
+	EventUser
+	// EventAssistant is a single assistant entry (one streaming snapshot).
+	EventAssistant
+	// EventCompact is a compact_boundary system entry.
+	EventCompact
+	// EventTurnDuration is a turn_duration system entry.
+	EventTurnDuration
+)
+
+// ToolResult is a tool_result block delivered by a user entry.
+type ToolResult struct {
+	ToolUseID string
+	Content   json.RawMessage
+	IsError   bool
+	AgentID   string // sub-agent that produced the result (Agent/Task calls), if recorded
+}
+
+// Event is a typed transcript event decoded from a single JSONL line.
+// Every decodable line yields exactly one Event; fields not relevant to Kind
+// are left zero.
+type Event struct {
+	Kind      EventKind
+	Timestamp time.Time
+	RequestID string
+	GitBranch string
+	Slug      string
+
+	// Message tree links. ParentUUID falls back to the logical parent when
+	// the physical link was cut (compaction).
+	UUID       string
+	ParentUUID string
+	Sidechain  bool
+
+	// EventUser: plain text typed by the user. EventCompact: display text.
+	Text string
+	// EventUser: tool results delivered by this entry.
+	ToolResults []ToolResult
+	// EventAssistant: turn built from this single entry.
+	Turn Turn
+
+	// EventTurnDuration fields.
+	DurationMS int64
+	NumTurns   int
+	CostUSD    float64
+}
+
+// Sink consumes decoded transcript events in file order.
+type Sink interface {
+	HandleEvent(ev Event)
+}
+
+// SinkFunc adapts a plain function to the Sink interface.
+type SinkFunc func(ev Event)
+
+// HandleEvent calls f(ev).
+func (f SinkFunc) HandleEvent(ev Event) { f(ev) }
+
+// decodeLine decodes one JSONL line into an Event.
+// Returns false if the line is not a valid transcript entry.
+func decodeLine(line []byte) (Event, bool) {
+	var e entry
+	if err := json.Unmarshal(line, &e); err != nil {
+		return Event{}, false
+	}
+	ts, _ := time.Parse(time.RFC3339Nano, e.Timestamp)
+	ev := Event{
+		Kind:       EventOther,
+		Timestamp:  ts,
+		RequestID:  e.RequestID,
+		GitBranch:  e.GitBranch,
+		Slug:       e.Slug,
+		UUID:       e.UUID,
+		ParentUUID: e.ParentUUID,
+		Sidechain:  e.IsSidechain,
+	}
+	if ev.ParentUUID == "" {
+		ev.ParentUUID = e.LogicalParent
+	}
+
+	switch e.Type {
+	case "user":
+		var msg userMessage
+		if err := json.Unmarshal(e.Message, &msg); err != nil {
+			break
+		}
+		ev.Kind = EventUser
+		ev.Text = msg.textContent()
+		results := msg.toolResults()
+		for _, c := range results {
+			// toolUseResult describes the entry's result, so it only
+			// identifies the agent when there is exactly one.
+			var meta json.RawMessage
+			if len(results) == 1 {
+				meta = e.ToolUseResult
+			}
+			ev.ToolResults = append(ev.ToolResults, ToolResult{
+				ToolUseID: c.ToolUseID,
+				Content:   c.Content,
+				IsError:   c.IsError,
+				AgentID:   resultAgentID(meta, c.Content),
+			})
+		}
+
+	case "assistant":
+		var msg assistantMessage
+		if err := json.Unmarshal(e.Message, &msg); err != nil {
+			break
+		}
+		ev.Kind = EventAssistant
+		ev.Turn = buildAssistantTurn(msg, ts)
+		ev.Turn.RequestID = e.RequestID
+
+	case "system":
+		if e.Subtype == "compact_boundary" {
+			ev.Kind = EventCompact
+			ev.Text = formatCompactText(e)
+			break
+		}
+		// Two formats: old (fields in Message, snake_case) and new (top-level, camelCase).
+		if dur, turns, cost, ok := parseSystemDuration(e.Message, line); ok {
+			ev.Kind = EventTurnDuration
+			ev.DurationMS = dur
+			ev.NumTurns = turns
+			ev.CostUSD = cost
+		}
+	}
+	return ev, true
+}
+
+// dispatch sends ev to every sink in order.
+func dispatch(ev Event, sinks []Sink) {
+	for _, s := range sinks {
+		s.HandleEvent(ev)
+	}
+}
+
+// Decode reads JSONL lines from r and feeds every decoded event to sinks.
+func Decode(r io.Reader, sinks ...Sink) error {
+	return decodeLines(newJSONLScanner(r), sinks)
+}
+
+// decodeLines feeds every line produced by scanner to sinks.
+func decodeLines(scanner *bufio.Scanner, sinks []Sink) error {
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		if ev, ok := decodeLine(line); ok {
+			dispatch(ev, sinks)
+		}
+	}
+	return scanner.Err()
+}
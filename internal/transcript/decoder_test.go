@@ -0,0 +1,240 @@
+package transcript_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/transcript"
+)
+
+func TestDecodeEmitsOneEventPerLine(t *testing.T) {
+	const input = `{"type":"user","timestamp":"2025-01-01T10:00:00Z","gitBranch":"main","message":{"role":"user","content":[{"type":"text","text":"Hello"}]}}
+{"type":"assistant","timestamp":"2025-01-01T10:00:01Z","requestId":"req1","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}],"model":"claude-opus-4-6","usage":{"input_tokens":10,"output_tokens":5}}}
+{"type":"user","timestamp":"2025-01-01T10:00:02Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"t1","content":"ok","is_error":true}]}}
+{"type":"system","subtype":"compact_boundary","compactMetadata":{"trigger":"auto","preTokens":42000},"timestamp":"2025-01-01T10:00:03Z"}
+{"type":"system","subtype":"turn_duration","durationMs":1500,"timestamp":"2025-01-01T10:00:04Z"}
+{"type":"progress","timestamp":"2025-01-01T10:00:05Z"}
+not json
+`
+	var events []transcript.Event
+	sink := transcript.SinkFunc(func(ev transcript.Event) { events = append(events, ev) })
+	if err := transcript.Decode(strings.NewReader(input), sink); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+
+	wantKinds := []transcript.EventKind{
+		transcript.EventUser,
+		transcript.EventAssistant,
+		transcript.EventUser,
+		transcript.EventCompact,
+		transcript.EventTurnDuration,
+		transcript.EventOther,
+	}
+	if len(events) != len(wantKinds) {
+		t.Fatalf("expected %d events, got %d", len(wantKinds), len(events))
+	}
+	for i, want := range wantKinds {
+		if events[i].Kind != want {
+			t.Errorf("event %d: kind = %d, want %d", i, events[i].Kind, want)
+		}
+	}
+	if events[0].Text != "Hello" || events[0].GitBranch != "main" {
+		t.Errorf("user event: got text=%q branch=%q", events[0].Text, events[0].GitBranch)
+	}
+	if events[1].Turn.RequestID != "req1" || len(events[1].Turn.ToolCalls) != 1 {
+		t.Errorf("assistant event: unexpected turn %+v", events[1].Turn)
+	}
+	if rs := events[2].ToolResults; len(rs) != 1 || rs[0].ToolUseID != "t1" || !rs[0].IsError {
+		t.Errorf("tool result event: unexpected results %+v", rs)
+	}
+	if events[3].Text != "Conversation compacted (42k tokens)" {
+		t.Errorf("compact event: text = %q", events[3].Text)
+	}
+	if events[4].DurationMS != 1500 {
+		t.Errorf("turn_duration event: DurationMS = %d, want 1500", events[4].DurationMS)
+	}
+}
+
+func TestDecodeFeedsEverySink(t *testing.T) {
+	const input = `{"type":"user","message":{"role":"user","content":"Hi"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Hello"}],"model":"claude-opus-4-6"}}
+`
+	var a, b int
+	err := transcript.Decode(strings.NewReader(input),
+		transcript.SinkFunc(func(transcript.Event) { a++ }),
+		transcript.SinkFunc(func(transcript.Event) { b++ }),
+	)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if a != 2 || b != 2 {
+		t.Errorf("expected both sinks to receive 2 events, got %d and %d", a, b)
+	}
+}
+
+// TestCompactBoundaryDedupConsistent verifies that a streaming duplicate
+// flushed by a compact_boundary is deduplicated identically by Parse and
+// ParseFileIncremental.
+func TestCompactBoundaryDedupConsistent(t *testing.T) {
+	const input = `{"type":"user","message":{"role":"user","content":[{"type":"text","text":"Do something"}]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"partial"}],"model":"claude-opus-4-6","usage":{"input_tokens":50,"output_tokens":5}},"requestId":"req1"}
+{"type":"user","message":{"role":"user","content":[]}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"final"}],"model":"claude-opus-4-6","usage":{"input_tokens":100,"output_tokens":10}},"requestId":"req1"}
+{"type":"system","subtype":"compact_boundary","compactMetadata":{"trigger":"auto","preTokens":1000}}
+`
+	f, err := os.CreateTemp("", "compact-dedup-*.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.WriteString(input); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	parsed, err := transcript.ParseFile(f.Name())
+	if err != nil {
+		t.Fatalf("ParseFile failed: %v", err)
+	}
+	cache, err := transcript.ParseFileIncremental(f.Name(), nil)
+	if err != nil {
+		t.Fatalf("ParseFileIncremental failed: %v", err)
+	}
+	incremental := cache.Turns()
+
+	// user, deduplicated assistant, system
+	if len(parsed.Turns) != 3 || len(incremental) != 3 {
+		t.Fatalf("expected 3 turns from both paths, got Parse=%d incremental=%d", len(parsed.Turns), len(incremental))
+	}
+	for i := range parsed.Turns {
+		if parsed.Turns[i].Text != incremental[i].Text {
+			t.Errorf("turn %d: Parse text %q != incremental text %q", i, parsed.Turns[i].Text, incremental[i].Text)
+		}
+	}
+	if parsed.Turns[1].Text != "final" {
+		t.Errorf("expected final streaming event to win, got %q", parsed.Turns[1].Text)
+	}
+}
+
+func TestAggregatesRecordTurnDurationCost(t *testing.T) {
+	f, err := os.CreateTemp("", "agg-cost-*.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	content := `{"type":"user","message":{"role":"user","content":"Hi"}}` + "\n" +
+		`{"type":"system","message":{"subtype":"turn_duration","duration_ms":6000,"num_turns":3,"total_cost_usd":0.5}}` + "\n"
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	agg, err := transcript.ParseAggregatesIncremental(f.Name(), nil)
+	if err != nil {
+		t.Fatalf("ParseAggregatesIncremental failed: %v", err)
+	}
+	if agg.TotalCost != 0.5 {
+		t.Errorf("TotalCost = %f, want 0.5", agg.TotalCost)
+	}
+	if agg.DurationMS != 6000 || agg.NumTurns != 3 {
+		t.Errorf("DurationMS=%d NumTurns=%d, want 6000/3", agg.DurationMS, agg.NumTurns)
+	}
+}
+
+func TestFileCacheSharesOneRead(t *testing.T) {
+	f, err := os.CreateTemp("", "filecache-*.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	line1 := `{"type":"user","timestamp":"2025-01-01T10:00:00Z","slug":"s1","message":{"role":"user","content":"Hello"}}` + "\n"
+	line2 := `{"type":"assistant","timestamp":"2025-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"text","text":"Hi"}],"model":"claude-opus-4-6","usage":{"input_tokens":100,"output_tokens":20}}}` + "\n"
+	if _, err := f.WriteString(line1); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	fc := transcript.NewFileCache(f.Name())
+	agg, err := fc.Aggregates()
+	if err != nil {
+		t.Fatalf("Aggregates failed: %v", err)
+	}
+	if agg.Topic != "Hello" || agg.Slug != "s1" {
+		t.Errorf("unexpected aggregates: topic=%q slug=%q", agg.Topic, agg.Slug)
+	}
+
+	// Attaching the turn builder after aggregates were read must still see every line.
+	turns, err := fc.Turns()
+	if err != nil {
+		t.Fatalf("Turns failed: %v", err)
+	}
+	if len(turns) != 1 || turns[0].Text != "Hello" {
+		t.Fatalf("expected 1 user turn, got %+v", turns)
+	}
+
+	af, err := os.OpenFile(f.Name(), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := af.WriteString(line2); err != nil {
+		_ = af.Close()
+		t.Fatal(err)
+	}
+	_ = af.Close()
+
+	turns, err = fc.Turns()
+	if err != nil {
+		t.Fatalf("Turns failed: %v", err)
+	}
+	if len(turns) != 2 {
+		t.Fatalf("expected 2 turns after append, got %d", len(turns))
+	}
+	// The same read fed the aggregate counter: no double counting.
+	agg, err = fc.Aggregates()
+	if err != nil {
+		t.Fatalf("Aggregates failed: %v", err)
+	}
+	if got := agg.TokensByModel["claude-opus-4-6"].InputTokens; got != 100 {
+		t.Errorf("InputTokens = %d, want 100", got)
+	}
+	if agg.NumTurns != 1 {
+		t.Errorf("NumTurns = %d, want 1", agg.NumTurns)
+	}
+}
+
+func TestFileCacheWatchedRereadsOnlyAfterChange(t *testing.T) {
+	f, err := os.CreateTemp("", "filecache-watch-*.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.WriteString(`{"type":"user","message":{"role":"user","content":"Hello"}}` + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	fc := transcript.NewFileCache(f.Name())
+	fc.Watch()
+	if turns, err := fc.Turns(); err != nil || len(turns) != 1 {
+		t.Fatalf("expected 1 turn on first read, got %d (err %v)", len(turns), err)
+	}
+
+	af, err := os.OpenFile(f.Name(), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := af.WriteString(`{"type":"user","message":{"role":"user","content":"Again"}}` + "\n"); err != nil {
+		_ = af.Close()
+		t.Fatal(err)
+	}
+	_ = af.Close()
+
+	if turns, _ := fc.Turns(); len(turns) != 1 {
+		t.Errorf("watched cache must not re-read before MarkChanged, got %d turns", len(turns))
+	}
+	fc.MarkChanged()
+	if turns, _ := fc.Turns(); len(turns) != 2 {
+		t.Errorf("expected 2 turns after MarkChanged, got %d", len(turns))
+	}
+}
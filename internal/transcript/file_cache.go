@@ -0,0 +1,115 @@
+package transcript
+
+import (
+	"os"
+	"sync"
+)
+
+// FileCache holds the shared incremental decode state for one transcript file.
+// Each refresh reads newly appended lines once and feeds them to the aggregate
+// counter and, once turns have been requested, the turn builder, so every
+// consumer sees the same events without re-reading the file.
+// FileCache is safe for concurrent use.
+type FileCache struct {
+	mu     sync.Mutex
+	path   string
+	offset int64
+	info   os.FileInfo
+	agg    *SessionAggregates
+	turns  *TranscriptCache // nil until Turns is first called
+	index  *Index           // optional; receives the aggregates after each refresh
+
+	// watched switches refresh to change-driven mode: the file is re-read
+	// only while fresh is false (never read, or MarkChanged since).
+	watched bool
+	fresh   bool
+}
+
+// NewFileCache creates an empty FileCache for path. Nothing is read until the
+// first call to Aggregates or Turns.
+func NewFileCache(path string) *FileCache {
+	return &FileCache{
+		path: path,
+		agg:  newSessionAggregates(),
+	}
+}
+
+// refresh decodes complete lines appended since the last refresh into every
+// attached sink. If the file was truncated or replaced, all sinks are reset
+// first. Callers must hold f.mu.
+func (f *FileCache) refresh() error {
+	if f.watched && f.fresh {
+		return nil
+	}
+	reset := func() {
+		f.agg = newSessionAggregates()
+		if f.turns != nil {
+			f.turns = newTranscriptCache()
+		}
+	}
+	// The sinks may be replaced by reset, so resolve them lazily.
+	sink := SinkFunc(func(ev Event) {
+		f.agg.HandleEvent(ev)
+		if f.turns != nil {
+			f.turns.HandleEvent(ev)
+		}
+	})
+	err := tailFile(f.path, &f.offset, &f.info, reset, sink)
+	f.agg.Offset, f.agg.info = f.offset, f.info
+	if f.turns != nil {
+		f.turns.offset, f.turns.info = f.offset, f.info
+	}
+	if err == nil {
+		f.fresh = true
+		if f.index != nil {
+			f.index.update(f.path, f.agg)
+		}
+	}
+	return err
+}
+
+// Watch switches f to change-driven refresh: once read, the file is not
+// read again until MarkChanged reports that it was modified. Use it when a
+// filesystem watcher delivers change notifications for the file.
+func (f *FileCache) Watch() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.watched = true
+}
+
+// MarkChanged records that the file was modified, so the next Aggregates or
+// Turns call re-reads it. It has no effect unless Watch was called.
+func (f *FileCache) MarkChanged() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fresh = false
+}
+
+// Aggregates refreshes the cache and returns a snapshot of the session-level metrics.
+func (f *FileCache) Aggregates() (*SessionAggregates, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.refresh(); err != nil {
+		return nil, err
+	}
+	return f.agg.clone(), nil
+}
+
+// Turns refreshes the cache and returns all turns, including a snapshot of the
+// pending assistant turn. The turn builder is attached on first use; because
+// it must see every line, the first call re-decodes the file from the start
+// for all sinks.
+func (f *FileCache) Turns() ([]Turn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.turns == nil {
+		f.turns = newTranscriptCache()
+		f.agg = newSessionAggregates()
+		f.offset = 0
+		f.fresh = false
+	}
+	if err := f.refresh(); err != nil {
+		return nil, err
+	}
+	return f.turns.Turns(), nil
+}
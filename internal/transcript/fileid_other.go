@@ -0,0 +1,11 @@
+//go:build !unix
+
+package transcript
+
+import "os"
+
+// fileID reports no file identity on this platform; index entries are then
+// checked by size and mtime only.
+func fileID(os.FileInfo) (dev, ino uint64) {
+	return 0, 0
+}
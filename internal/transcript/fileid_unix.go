@@ -0,0 +1,17 @@
+//go:build unix
+
+package transcript
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileID returns the device and inode numbers of a file, which tell a
+// transcript recreated at the same path from the one that was there.
+func fileID(fi os.FileInfo) (dev, ino uint64) {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Dev), uint64(st.Ino)
+	}
+	return 0, 0
+}
@@ -0,0 +1,224 @@
+package transcript
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// indexVersion is bumped whenever the stored entry format or the meaning of
+// an aggregate changes; entries written by another version are discarded.
+const indexVersion = 5
+
+// indexEntry is the persisted form of one file's SessionAggregates, including
+// the streaming dedup state needed to resume decoding mid-file.
+type indexEntry struct {
+	Offset            int64            `json:"offset"`
+	Size              int64            `json:"size"`
+	ModTime           time.Time        `json:"mtime"`
+	Dev               uint64           `json:"dev,omitempty"`
+	Ino               uint64           `json:"ino,omitempty"`
+	Topic             string           `json:"topic,omitempty"`
+	Branch            string           `json:"branch,omitempty"`
+	Slug              string           `json:"slug,omitempty"`
+	TokensByModel     map[string]Usage `json:"tokens,omitempty"`
+	TotalToolCalls    int              `json:"toolCalls,omitempty"`
+	TotalCost         float64          `json:"cost,omitempty"`
+	DurationMS        int64            `json:"durationMs,omitempty"`
+	NumTurns          int              `json:"numTurns,omitempty"`
+	Activity          Activity         `json:"activity"`
+	LastRequestID     string           `json:"lastRequestId,omitempty"`
+	LastRequestModel  string           `json:"lastRequestModel,omitempty"`
+	LastRequestUsage  Usage            `json:"lastRequestUsage"`
+	LastToolCallDelta int              `json:"lastToolCallDelta,omitempty"`
+}
+
+type indexFile struct {
+	Version int                   `json:"version"`
+	Entries map[string]indexEntry `json:"entries"`
+}
+
+// Index persists SessionAggregates per transcript file so a cold start can
+// resume incremental parsing from the stored offset instead of re-reading
+// every transcript from the beginning. Index is safe for concurrent use.
+type Index struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]indexEntry
+	dirty   bool
+}
+
+// LoadIndex reads the index stored at path. A missing, unreadable, or
+// outdated index yields an empty one; it is rebuilt as files are parsed.
+func LoadIndex(path string) *Index {
+	x := &Index{path: path, entries: make(map[string]indexEntry)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return x
+	}
+	var f indexFile
+	if err := json.Unmarshal(data, &f); err != nil || f.Version != indexVersion {
+		return x
+	}
+	if f.Entries != nil {
+		x.entries = f.Entries
+	}
+	return x
+}
+
+// FileCache returns a FileCache for path whose aggregates are restored from
+// the index when the stored entry is still valid, and whose future refreshes
+// are recorded back into the index.
+//
+// An entry is valid when the path still names the same file (device and
+// inode), the file has not shrunk and, if its size is unchanged, its mtime is
+// unchanged too. Transcripts are append-only, so a grown file is resumed from
+// the stored offset; anything else, including a file deleted and recreated
+// at the same path, is re-parsed.
+func (x *Index) FileCache(path string) *FileCache {
+	fc := NewFileCache(path)
+	fc.index = x
+
+	x.mu.Lock()
+	e, ok := x.entries[path]
+	x.mu.Unlock()
+	if !ok {
+		return fc
+	}
+	fi, err := os.Stat(path)
+	if err != nil || !e.sameFile(fi) || fi.Size() < e.Size || e.Offset > e.Size ||
+		(fi.Size() == e.Size && !fi.ModTime().Equal(e.ModTime)) {
+		x.mu.Lock()
+		delete(x.entries, path)
+		x.dirty = true
+		x.mu.Unlock()
+		return fc
+	}
+
+	agg := newSessionAggregates()
+	agg.Topic = e.Topic
+	agg.Branch = e.Branch
+	agg.Slug = e.Slug
+	for m, u := range e.TokensByModel {
+		agg.TokensByModel[m] = u
+	}
+	agg.TotalToolCalls = e.TotalToolCalls
+	agg.TotalCost = e.TotalCost
+	agg.DurationMS = e.DurationMS
+	agg.NumTurns = e.NumTurns
+	agg.Activity = e.Activity
+	agg.Offset = e.Offset
+	agg.info = fi
+	agg.lastRequestID = e.LastRequestID
+	agg.lastRequestModel = e.LastRequestModel
+	agg.lastRequestUsage = e.LastRequestUsage
+	agg.lastToolCallDelta = e.LastToolCallDelta
+
+	fc.agg = agg
+	fc.offset = e.Offset
+	fc.info = fi
+	return fc
+}
+
+// sameFile reports whether fi is the file the entry was recorded for. Entries
+// without an identity (platforms without inodes) match any file.
+func (e indexEntry) sameFile(fi os.FileInfo) bool {
+	dev, ino := fileID(fi)
+	return e.Ino == 0 || (dev == e.Dev && ino == e.Ino)
+}
+
+// update records the current state of agg for path.
+func (x *Index) update(path string, agg *SessionAggregates) {
+	if agg.info == nil {
+		return
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if e, ok := x.entries[path]; ok && e.Offset == agg.Offset && e.sameFile(agg.info) &&
+		e.Size == agg.info.Size() && e.ModTime.Equal(agg.info.ModTime()) {
+		return
+	}
+	dev, ino := fileID(agg.info)
+	tokens := make(map[string]Usage, len(agg.TokensByModel))
+	for m, u := range agg.TokensByModel {
+		tokens[m] = u
+	}
+	x.entries[path] = indexEntry{
+		Offset:            agg.Offset,
+		Size:              agg.info.Size(),
+		ModTime:           agg.info.ModTime(),
+		Dev:               dev,
+		Ino:               ino,
+		Topic:             agg.Topic,
+		Branch:            agg.Branch,
+		Slug:              agg.Slug,
+		TokensByModel:     tokens,
+		TotalToolCalls:    agg.TotalToolCalls,
+		TotalCost:         agg.TotalCost,
+		DurationMS:        agg.DurationMS,
+		NumTurns:          agg.NumTurns,
+		Activity:          agg.Activity.clone(),
+		LastRequestID:     agg.lastRequestID,
+		LastRequestModel:  agg.lastRequestModel,
+		LastRequestUsage:  agg.lastRequestUsage,
+		LastToolCallDelta: agg.lastToolCallDelta,
+	}
+	x.dirty = true
+}
+
+// Save writes the index to disk if it changed since the last save.
+// Entries for files that no longer exist are dropped.
+func (x *Index) Save() error {
+	x.mu.Lock()
+	if !x.dirty {
+		x.mu.Unlock()
+		return nil
+	}
+	f := indexFile{Version: indexVersion, Entries: make(map[string]indexEntry, len(x.entries))}
+	for p, e := range x.entries {
+		f.Entries[p] = e
+	}
+	x.dirty = false
+	x.mu.Unlock()
+
+	for p := range f.Entries {
+		if _, err := os.Stat(p); os.IsNotExist(err) {
+			delete(f.Entries, p)
+		}
+	}
+
+	if err := x.write(f); err != nil {
+		x.mu.Lock()
+		x.dirty = true
+		x.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// write replaces the index file with f via a temp file and rename, so a crash
+// mid-write never leaves a corrupt index.
+func (x *Index) write(f indexFile) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(x.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(x.path), filepath.Base(x.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), x.path)
+}
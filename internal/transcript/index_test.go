@@ -0,0 +1,152 @@
+package transcript_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/transcript"
+)
+
+func TestIndexResumesFromStoredOffset(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.jsonl")
+	indexPath := filepath.Join(dir, "cache", "index.json")
+	if err := os.WriteFile(path, []byte(tailUserLine+tailAssistantLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx := transcript.LoadIndex(indexPath)
+	agg, err := idx.FileCache(path).Aggregates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.TotalToolCalls != 1 {
+		t.Fatalf("expected 1 tool call, got %d", agg.TotalToolCalls)
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// Tamper with the stored count: if the next run resumes from the stored
+	// offset instead of re-parsing, the tampered value must survive.
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), `"toolCalls":1`, `"toolCalls":5`, 1)
+	if tampered == string(data) {
+		t.Fatalf("toolCalls not found in index: %s", data)
+	}
+	if err := os.WriteFile(indexPath, []byte(tampered), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	appendToFile(t, path, strings.Replace(tailAssistantLine, `"id":"t1"`, `"id":"t2"`, 1))
+
+	agg, err = transcript.LoadIndex(indexPath).FileCache(path).Aggregates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.TotalToolCalls != 6 {
+		t.Errorf("expected resume from stored offset (5 stored + 1 appended), got %d", agg.TotalToolCalls)
+	}
+	if agg.Topic != "Hello" {
+		t.Errorf("expected topic restored from index, got %q", agg.Topic)
+	}
+	if got := agg.TokensByModel["claude-opus-4-6"].InputTokens; got != 200 {
+		t.Errorf("InputTokens = %d, want 200", got)
+	}
+}
+
+func TestIndexInvalidatesRewrittenFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.jsonl")
+	indexPath := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(path, []byte(tailUserLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx := transcript.LoadIndex(indexPath)
+	if _, err := idx.FileCache(path).Aggregates(); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same size, different content and mtime: the entry must be discarded.
+	rewritten := strings.Replace(tailUserLine, "Hello", "Howdy", 1)
+	if err := os.WriteFile(path, []byte(rewritten), 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	agg, err := transcript.LoadIndex(indexPath).FileCache(path).Aggregates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.Topic != "Howdy" {
+		t.Errorf("expected re-parse after rewrite, got topic %q", agg.Topic)
+	}
+}
+
+func TestIndexInvalidatesRecreatedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.jsonl")
+	indexPath := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(path, []byte(tailUserLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+	idx := transcript.LoadIndex(indexPath)
+	if _, err := idx.FileCache(path).Aggregates(); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new, larger file replaces the old one at the same path. It would pass
+	// the size check, so only its identity tells it apart.
+	next := filepath.Join(dir, "next.jsonl")
+	content := strings.Replace(tailUserLine, "Hello", "Goodbye", 1) + tailAssistantLine
+	if err := os.WriteFile(next, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(next, path); err != nil {
+		t.Fatal(err)
+	}
+
+	agg, err := transcript.LoadIndex(indexPath).FileCache(path).Aggregates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.Topic != "Goodbye" || agg.TotalToolCalls != 1 {
+		t.Errorf("expected a full re-parse of the new file, got topic %q, %d tool calls", agg.Topic, agg.TotalToolCalls)
+	}
+}
+
+func TestLoadIndexIgnoresCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "session.jsonl")
+	indexPath := filepath.Join(dir, "index.json")
+	if err := os.WriteFile(path, []byte(tailUserLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(indexPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	agg, err := transcript.LoadIndex(indexPath).FileCache(path).Aggregates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.Topic != "Hello" {
+		t.Errorf("expected full parse with a corrupt index, got topic %q", agg.Topic)
+	}
+}
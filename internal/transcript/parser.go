@@ -21,6 +21,9 @@ type ToolCall struct {
 	IsError   bool
 	Timestamp time.Time
 	Duration  time.Duration
+	// SubagentID identifies the sub-agent an Agent/Task call spawned, taken
+	// from its result. Empty for other tools and for older transcripts.
+	SubagentID string
 }
 
 // Turn is a parsed conversation turn with tool calls extracted.
@@ -33,6 +36,14 @@ type Turn struct {
 	Usage     Usage
 	Timestamp time.Time
 	RequestID string // API request ID for streaming deduplication
+
+	// UUID is the uuid of the turn's first entry. ParentUUID is the UUID of
+	// the turn it continues; turns sharing a parent are alternative branches
+	// (rewinds, edited prompts, retries). Empty when the transcript has no
+	// tree links.
+	UUID       string
+	ParentUUID string
+	Sidechain  bool
 }
 
 // ParsedTranscript is the result of parsing a JSONL file.
@@ -56,14 +67,33 @@ func ParseFile(path string) (*ParsedTranscript, error) {
 	return Parse(f)
 }
 
-// matchToolResults fills Result, IsError, and Duration for each ToolCall
-// whose ID appears in the provided maps.
-func matchToolResults(calls []ToolCall, results map[string]json.RawMessage, errors map[string]bool, timestamps map[string]time.Time) {
+// Parse reads from an io.Reader and parses JSONL transcript entries.
+// Turns come from the turn builder and metrics from the aggregate counter,
+// so the result matches what the incremental paths produce for the same input.
+func Parse(r io.Reader) (*ParsedTranscript, error) {
+	turns := newTranscriptCache()
+	agg := newSessionAggregates()
+	err := Decode(r, turns, agg)
+	return &ParsedTranscript{
+		Turns:          turns.Turns(),
+		Topic:          agg.Topic,
+		TokensByModel:  agg.TokensByModel,
+		TotalToolCalls: agg.TotalToolCalls,
+		TotalCost:      agg.TotalCost,
+		DurationMS:     agg.DurationMS,
+		NumTurns:       agg.NumTurns,
+	}, err
+}
+
+// matchToolResults fills Result, IsError, Duration and SubagentID for each
+// ToolCall whose ID appears in the provided maps.
+func matchToolResults(calls []ToolCall, results map[string]json.RawMessage, errors map[string]bool, timestamps map[string]time.Time, agents map[string]string) {
 	for i := range calls {
 		tc := &calls[i]
 		if res, ok := results[tc.ID]; ok {
 			tc.Result = res
 			tc.IsError = errors[tc.ID]
+			tc.SubagentID = agents[tc.ID]
 			if resultTS, ok := timestamps[tc.ID]; ok && !tc.Timestamp.IsZero() {
 				tc.Duration = resultTS.Sub(tc.Timestamp)
 			}
@@ -71,44 +101,17 @@ func matchToolResults(calls []ToolCall, results map[string]json.RawMessage, erro
 	}
 }
 
-// flushPendingTurn matches tool results into the pending turn, accumulates metrics, and appends it.
-// toolTimestamps maps tool_use_id -> timestamp of the user turn that delivered the result;
-// used to compute per-tool-call Duration.
-// If the last flushed turn shares the same non-empty requestId (streaming deduplication),
-// the previous turn is replaced and its metrics are undone before adding the new ones.
-func flushPendingTurn(result *ParsedTranscript, turn *Turn, toolResults map[string]json.RawMessage, toolErrors map[string]bool, toolTimestamps map[string]time.Time) {
-	matchToolResults(turn.ToolCalls, toolResults, toolErrors, toolTimestamps)
-
-	// Streaming dedup: same requestId as last flushed turn → replace instead of append.
-	if turn.RequestID != "" && len(result.Turns) > 0 {
-		if last := &result.Turns[len(result.Turns)-1]; last.RequestID == turn.RequestID {
-			// Undo previous accumulation
-			u := result.TokensByModel[last.Model]
-			u.InputTokens -= last.Usage.NewInputTokens()
-			u.CacheReadInputTokens -= last.Usage.CacheReadInputTokens
-			u.OutputTokens -= last.Usage.OutputTokens
-			result.TokensByModel[last.Model] = u
-			result.TotalToolCalls -= len(last.ToolCalls)
-			// Replace with new turn and re-accumulate
-			*last = *turn
-			u = result.TokensByModel[turn.Model]
-			u.InputTokens += turn.Usage.NewInputTokens()
-			u.CacheReadInputTokens += turn.Usage.CacheReadInputTokens
-			u.OutputTokens += turn.Usage.OutputTokens
-			result.TokensByModel[turn.Model] = u
-			result.TotalToolCalls += len(turn.ToolCalls)
-			return
-		}
+// appendOrReplaceTurn appends turn to turns, or replaces the last turn when
+// both share the same non-empty requestId (streaming deduplication). A
+// replacement keeps the tree identity of the turn it replaces.
+func appendOrReplaceTurn(turns []Turn, turn Turn) []Turn {
+	if turn.RequestID != "" && len(turns) > 0 && turns[len(turns)-1].RequestID == turn.RequestID {
+		last := &turns[len(turns)-1]
+		turn.UUID, turn.ParentUUID = last.UUID, last.ParentUUID
+		*last = turn
+		return turns
 	}
-
-	// Normal path: accumulate and append.
-	u := result.TokensByModel[turn.Model]
-	u.InputTokens += turn.Usage.NewInputTokens()
-	u.CacheReadInputTokens += turn.Usage.CacheReadInputTokens
-	u.OutputTokens += turn.Usage.OutputTokens
-	result.TokensByModel[turn.Model] = u
-	result.TotalToolCalls += len(turn.ToolCalls)
-	result.Turns = append(result.Turns, *turn)
+	return append(turns, turn)
 }
 
 // newJSONLScanner creates a bufio.Scanner with a 10MB buffer for JSONL parsing.
@@ -120,7 +123,8 @@ func newJSONLScanner(r io.Reader) *bufio.Scanner {
 
 // parseSystemDuration extracts duration, turn count, and cost from a system entry.
 // It handles both the old format (fields in Message) and new format (fields at top level).
-func parseSystemDuration(message json.RawMessage, rawLine []byte) (durationMS int64, numTurns int, costUSD float64) {
+// ok is false when the entry is not a turn_duration entry.
+func parseSystemDuration(message json.RawMessage, rawLine []byte) (durationMS int64, numTurns int, costUSD float64, ok bool) {
 	if len(message) > 0 {
 		var msg struct {
 			Subtype      string  `json:"subtype"`
@@ -129,7 +133,7 @@ func parseSystemDuration(message json.RawMessage, rawLine []byte) (durationMS in
 			TotalCostUSD float64 `json:"total_cost_usd"`
 		}
 		if err := json.Unmarshal(message, &msg); err == nil && msg.Subtype == "turn_duration" {
-			return msg.DurationMS, msg.NumTurns, msg.TotalCostUSD
+			return msg.DurationMS, msg.NumTurns, msg.TotalCostUSD, true
 		}
 	} else {
 		var msg struct {
@@ -137,10 +141,10 @@ func parseSystemDuration(message json.RawMessage, rawLine []byte) (durationMS in
 			DurationMS int64  `json:"durationMs"`
 		}
 		if err := json.Unmarshal(rawLine, &msg); err == nil && msg.Subtype == "turn_duration" {
-			return msg.DurationMS, 0, 0
+			return msg.DurationMS, 0, 0, true
 		}
 	}
-	return 0, 0, 0
+	return 0, 0, 0, false
 }
 
 // formatCompactText builds display text for a compact_boundary system entry.
@@ -152,15 +156,6 @@ func formatCompactText(e entry) string {
 	return text
 }
 
-// collectToolResults populates the result/error/timestamp maps from a user message's tool results.
-func collectToolResults(msg userMessage, ts time.Time, results map[string]json.RawMessage, errors map[string]bool, timestamps map[string]time.Time) {
-	for _, c := range msg.toolResults() {
-		results[c.ToolUseID] = c.Content
-		errors[c.ToolUseID] = c.IsError
-		timestamps[c.ToolUseID] = ts
-	}
-}
-
 // buildAssistantTurn constructs an assistant Turn from a parsed message.
 func buildAssistantTurn(msg assistantMessage, ts time.Time) Turn {
 	turn := Turn{
@@ -192,6 +187,7 @@ func buildAssistantTurn(msg assistantMessage, ts time.Time) Turn {
 // pending turn is replaced wholesale with next rather than accumulated.
 func mergeAssistantTurn(pending *Turn, next Turn) {
 	if next.RequestID != "" && pending.RequestID == next.RequestID {
+		next.UUID, next.ParentUUID = pending.UUID, pending.ParentUUID
 		*pending = next
 		return
 	}
@@ -214,248 +210,166 @@ func mergeAssistantTurn(pending *Turn, next Turn) {
 	pending.Usage.OutputTokens += next.Usage.OutputTokens
 }
 
-// Parse reads from an io.Reader and parses JSONL transcript entries.
-func Parse(r io.Reader) (*ParsedTranscript, error) {
-	scanner := newJSONLScanner(r)
-
-	result := &ParsedTranscript{
-		TokensByModel: make(map[string]Usage),
-	}
-	// toolResults maps tool_use_id -> tool_result content
-	toolResults := map[string]json.RawMessage{}
-	toolErrors := map[string]bool{}
-	toolTimestamps := map[string]time.Time{}
-
-	var pendingAssistantTurn *Turn
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
-		}
-
-		var entry entry
-		if err := json.Unmarshal(line, &entry); err != nil {
-			continue
-		}
-
-		ts, _ := time.Parse(time.RFC3339Nano, entry.Timestamp)
-
-		switch entry.Type {
-		case "user":
-			var msg userMessage
-			if err := json.Unmarshal(entry.Message, &msg); err != nil {
-				continue
-			}
-			// Collect tool results to match with pending tool calls
-			collectToolResults(msg, ts, toolResults, toolErrors, toolTimestamps)
-			// Flush pending assistant turn with matched results
-			if pendingAssistantTurn != nil {
-				flushPendingTurn(result, pendingAssistantTurn, toolResults, toolErrors, toolTimestamps)
-				pendingAssistantTurn = nil
-			}
-			// Add user text turns
-			turn := Turn{Role: "user", Timestamp: ts}
-			turn.Text = msg.textContent()
-			if turn.Text != "" {
-				// Set topic from first real user message, matching claude -r display (skip skill prefix lines)
-				if result.Topic == "" && !strings.HasPrefix(turn.Text, "Base directory for this skill:") {
-					result.Topic = extractTopic(turn.Text)
-				}
-				result.Turns = append(result.Turns, turn)
-			}
-
-		case "assistant":
-			var msg assistantMessage
-			if err := json.Unmarshal(entry.Message, &msg); err != nil {
-				continue
-			}
-			turn := buildAssistantTurn(msg, ts)
-			turn.RequestID = entry.RequestID
-			if pendingAssistantTurn != nil {
-				// Merge consecutive assistant entries (e.g. text-only followed by tool-only).
-				// mergeAssistantTurn replaces instead of merging when same requestId.
-				mergeAssistantTurn(pendingAssistantTurn, turn)
-			} else {
-				pendingAssistantTurn = &turn
-			}
-
-		case "system":
-			if entry.Subtype == "compact_boundary" {
-				if pendingAssistantTurn != nil {
-					flushPendingTurn(result, pendingAssistantTurn, toolResults, toolErrors, toolTimestamps)
-					pendingAssistantTurn = nil
-				}
-				result.Turns = append(result.Turns, Turn{
-					Role: "system", Text: formatCompactText(entry), Timestamp: ts,
-				})
-			}
-			// Two formats: old (fields in Message, snake_case) and new (top-level, camelCase).
-			dur, turns, cost := parseSystemDuration(entry.Message, line)
-			result.DurationMS += dur
-			if turns > 0 {
-				result.NumTurns = turns
-			}
-			if cost > 0 {
-				result.TotalCost = cost
-			}
-		}
-	}
-
-	// Flush any remaining pending turn
-	if pendingAssistantTurn != nil {
-		flushPendingTurn(result, pendingAssistantTurn, toolResults, toolErrors, toolTimestamps)
-	}
-
-	return result, scanner.Err()
+// isTopicCandidate reports whether user text may be used as the session topic,
+// matching claude -r display (skill prefix lines are skipped).
+func isTopicCandidate(text string) bool {
+	return text != "" && !strings.HasPrefix(text, "Base directory for this skill:")
 }
 
-// TranscriptCache holds parser state for incremental turns parsing.
+// TranscriptCache is the turn builder sink: it folds events into conversation
+// turns and holds the parser state needed for incremental parsing. Every
+// cache comes from newTranscriptCache, which makes its maps.
 type TranscriptCache struct {
 	committed      []Turn                     // fully flushed turns (tool results matched)
 	pending        *Turn                      // current assistant turn awaiting tool results
 	toolResults    map[string]json.RawMessage // collected but unmatched tool results
 	toolErrors     map[string]bool            // error flags for unmatched tool results
 	toolTimestamps map[string]time.Time       // timestamp of user turn delivering each tool result
-	offset         int64                      // file position after last-read line
-	topic          string                     // first real user message text
+	toolAgents     map[string]string          // sub-agent ID reported by each tool result
+	turnOf         map[string]string          // entry uuid → UUID of the turn it belongs to
+	offset         int64                      // file position after last complete line
+	info           os.FileInfo                // file identity at last read, for truncation detection
+}
+
+func newTranscriptCache() *TranscriptCache {
+	return &TranscriptCache{
+		toolResults:    make(map[string]json.RawMessage),
+		toolErrors:     make(map[string]bool),
+		toolTimestamps: make(map[string]time.Time),
+		toolAgents:     make(map[string]string),
+		turnOf:         make(map[string]string),
+	}
 }
 
 // Offset returns the current file read position.
 func (c *TranscriptCache) Offset() int64 { return c.offset }
 
 // Turns returns all turns including a snapshot of the pending assistant turn.
+// The returned slice is a copy and is not modified by later parsing.
 func (c *TranscriptCache) Turns() []Turn {
+	turns := make([]Turn, len(c.committed), len(c.committed)+1)
+	copy(turns, c.committed)
 	if c.pending == nil {
-		return c.committed
+		return turns
 	}
 	snapshot := *c.pending
 	snapshot.ToolCalls = make([]ToolCall, len(c.pending.ToolCalls))
 	copy(snapshot.ToolCalls, c.pending.ToolCalls)
-	matchToolResults(snapshot.ToolCalls, c.toolResults, c.toolErrors, c.toolTimestamps)
-	return append(c.committed, snapshot)
+	matchToolResults(snapshot.ToolCalls, c.toolResults, c.toolErrors, c.toolTimestamps, c.toolAgents)
+	return appendOrReplaceTurn(turns, snapshot)
 }
 
-// ParseFileIncremental reads a JSONL file from the stored offset,
-// processes new lines into turns, and returns the updated cache.
-// If cache is nil, a new TranscriptCache is created (reading from offset 0).
-func ParseFileIncremental(path string, cache *TranscriptCache) (*TranscriptCache, error) {
-	if cache == nil {
-		cache = &TranscriptCache{
-			toolResults:    make(map[string]json.RawMessage),
-			toolErrors:     make(map[string]bool),
-			toolTimestamps: make(map[string]time.Time),
-		}
+// flushPending matches tool results into the pending assistant turn and commits it.
+// toolTimestamps maps tool_use_id -> timestamp of the user turn that delivered the result;
+// used to compute per-tool-call Duration.
+func (c *TranscriptCache) flushPending() {
+	if c.pending == nil {
+		return
 	}
+	matchToolResults(c.pending.ToolCalls, c.toolResults, c.toolErrors, c.toolTimestamps, c.toolAgents)
+	c.committed = appendOrReplaceTurn(c.committed, *c.pending)
+	c.pending = nil
+}
 
-	f, err := os.Open(path)
-	if err != nil {
-		return cache, err
-	}
-	defer func() { _ = f.Close() }()
+// parentTurn resolves an event's parent entry to the turn containing it.
+// Entries that start no turn of their own (tool results, progress) resolve
+// through to their parent's turn, so turns link directly to each other.
+func (c *TranscriptCache) parentTurn(ev Event) string {
+	return c.turnOf[ev.ParentUUID]
+}
 
-	if cache.offset > 0 {
-		if _, err := f.Seek(cache.offset, io.SeekStart); err != nil {
-			return cache, err
-		}
+// link records that the entry ev belongs to the turn identified by turnUUID.
+func (c *TranscriptCache) link(ev Event, turnUUID string) {
+	if ev.UUID != "" && turnUUID != "" {
+		c.turnOf[ev.UUID] = turnUUID
 	}
+}
 
-	scanner := newJSONLScanner(f)
+// newTurn stamps turn with the tree identity of the entry that starts it.
+func (c *TranscriptCache) newTurn(turn Turn, ev Event) Turn {
+	turn.UUID = ev.UUID
+	turn.ParentUUID = c.parentTurn(ev)
+	turn.Sidechain = ev.Sidechain
+	c.link(ev, turn.UUID)
+	return turn
+}
 
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+// HandleEvent implements Sink.
+func (c *TranscriptCache) HandleEvent(ev Event) {
+	switch ev.Kind {
+	case EventUser:
+		// Collect tool results to match with pending tool calls
+		for _, r := range ev.ToolResults {
+			c.toolResults[r.ToolUseID] = r.Content
+			c.toolErrors[r.ToolUseID] = r.IsError
+			c.toolTimestamps[r.ToolUseID] = ev.Timestamp
+			if r.AgentID != "" {
+				c.toolAgents[r.ToolUseID] = r.AgentID
+			}
 		}
-
-		var e entry
-		if err := json.Unmarshal(line, &e); err != nil {
-			continue
+		// Flush pending assistant turn with matched results
+		c.flushPending()
+		// Add user text turns
+		if ev.Text != "" {
+			c.committed = append(c.committed, c.newTurn(Turn{Role: "user", Text: ev.Text, Timestamp: ev.Timestamp}, ev))
+		} else {
+			c.link(ev, c.parentTurn(ev))
 		}
 
-		ts, _ := time.Parse(time.RFC3339Nano, e.Timestamp)
-
-		switch e.Type {
-		case "user":
-			var msg userMessage
-			if err := json.Unmarshal(e.Message, &msg); err != nil {
-				continue
-			}
-			// Collect tool results to match with pending tool calls
-			collectToolResults(msg, ts, cache.toolResults, cache.toolErrors, cache.toolTimestamps)
-			// Flush pending assistant turn with matched results
-			if cache.pending != nil {
-				matchToolResults(cache.pending.ToolCalls, cache.toolResults, cache.toolErrors, cache.toolTimestamps)
-				// Streaming dedup: same requestId as last committed → replace instead of append.
-				if cache.pending.RequestID != "" && len(cache.committed) > 0 &&
-					cache.committed[len(cache.committed)-1].RequestID == cache.pending.RequestID {
-					cache.committed[len(cache.committed)-1] = *cache.pending
-				} else {
-					cache.committed = append(cache.committed, *cache.pending)
-				}
-				cache.pending = nil
-			}
-			// Add user text turns
-			turn := Turn{Role: "user", Timestamp: ts}
-			turn.Text = msg.textContent()
-			if turn.Text != "" {
-				if cache.topic == "" && !strings.HasPrefix(turn.Text, "Base directory for this skill:") {
-					cache.topic = extractTopic(turn.Text)
-				}
-				cache.committed = append(cache.committed, turn)
-			}
-
-		case "assistant":
-			var msg assistantMessage
-			if err := json.Unmarshal(e.Message, &msg); err != nil {
-				continue
-			}
-			turn := buildAssistantTurn(msg, ts)
-			turn.RequestID = e.RequestID
-			if cache.pending != nil {
-				// Merge consecutive assistant entries.
-				// mergeAssistantTurn replaces instead of merging when same requestId.
-				mergeAssistantTurn(cache.pending, turn)
-			} else {
-				cache.pending = &turn
-			}
-
-		case "system":
-			if e.Subtype == "compact_boundary" {
-				if cache.pending != nil {
-					matchToolResults(cache.pending.ToolCalls, cache.toolResults, cache.toolErrors, cache.toolTimestamps)
-					cache.committed = append(cache.committed, *cache.pending)
-					cache.pending = nil
-				}
-				cache.committed = append(cache.committed, Turn{
-					Role: "system", Text: formatCompactText(e), Timestamp: ts,
-				})
+	case EventAssistant:
+		// An entry whose parent is a turn other than the pending one starts a
+		// new branch (e.g. a retried response) and must not be merged into it.
+		if c.pending != nil && c.pending.UUID != "" {
+			if parent := c.parentTurn(ev); parent != "" && parent != c.pending.UUID {
+				c.flushPending()
 			}
 		}
-	}
+		if c.pending != nil {
+			// Merge consecutive assistant entries (e.g. text-only followed by tool-only).
+			// mergeAssistantTurn replaces instead of merging when same requestId.
+			mergeAssistantTurn(c.pending, ev.Turn)
+			c.link(ev, c.pending.UUID)
+		} else {
+			turn := c.newTurn(ev.Turn, ev)
+			c.pending = &turn
+		}
 
-	if err := scanner.Err(); err != nil {
-		return cache, err
-	}
+	case EventCompact:
+		c.flushPending()
+		c.committed = append(c.committed, c.newTurn(Turn{Role: "system", Text: ev.Text, Timestamp: ev.Timestamp}, ev))
 
-	// Update offset to end of file
-	if pos, err := f.Seek(0, io.SeekEnd); err == nil {
-		cache.offset = pos
+	default:
+		c.link(ev, c.parentTurn(ev))
 	}
+}
 
-	return cache, nil
+// ParseFileIncremental reads complete lines appended to a JSONL file since the
+// stored offset, processes them into turns, and returns the updated cache.
+// If cache is nil, a new TranscriptCache is created (reading from offset 0).
+// If the file was truncated or replaced, the cache is reset and rebuilt.
+func ParseFileIncremental(path string, cache *TranscriptCache) (*TranscriptCache, error) {
+	if cache == nil {
+		cache = newTranscriptCache()
+	}
+	reset := func() { *cache = *newTranscriptCache() }
+	err := tailFile(path, &cache.offset, &cache.info, reset, cache)
+	return cache, err
 }
 
-// SessionAggregates holds cached session-level metrics for incremental parsing.
+// SessionAggregates is the aggregate counter sink: it holds cached
+// session-level metrics for incremental parsing.
 type SessionAggregates struct {
 	Topic          string
 	Branch         string
 	Slug           string
 	TokensByModel  map[string]Usage
 	TotalToolCalls int
+	TotalCost      float64
 	DurationMS     int64
 	NumTurns       int
-	Offset         int64 // next read start position
+	Activity       Activity    // state at the end of the transcript, for live status
+	Offset         int64       // next read start position (always at a line boundary)
+	info           os.FileInfo // file identity at last read, for truncation detection
 	// streaming dedup state: tracks the last assistant entry to undo it when
 	// the same requestId appears again (streaming duplicate events).
 	lastRequestID     string
@@ -464,122 +378,96 @@ type SessionAggregates struct {
 	lastToolCallDelta int
 }
 
-// ParseAggregatesIncremental reads a JSONL file from the stored offset,
-// accumulates metrics into agg, and returns the updated aggregates.
-// If agg is nil, a new SessionAggregates is created (reading from offset 0).
-func ParseAggregatesIncremental(path string, agg *SessionAggregates) (*SessionAggregates, error) {
-	if agg == nil {
-		agg = &SessionAggregates{
-			TokensByModel: make(map[string]Usage),
-		}
+func newSessionAggregates() *SessionAggregates {
+	return &SessionAggregates{
+		TokensByModel: make(map[string]Usage),
 	}
+}
 
-	f, err := os.Open(path)
-	if err != nil {
-		return agg, err
+// HandleEvent implements Sink.
+func (agg *SessionAggregates) HandleEvent(ev Event) {
+	// Capture git branch and slug from the first entry that has them
+	if agg.Branch == "" && ev.GitBranch != "" {
+		agg.Branch = ev.GitBranch
 	}
-	defer func() { _ = f.Close() }()
-
-	if agg.Offset > 0 {
-		if _, err := f.Seek(agg.Offset, io.SeekStart); err != nil {
-			return agg, err
-		}
+	if agg.Slug == "" && ev.Slug != "" {
+		agg.Slug = ev.Slug
 	}
+	agg.Activity.handle(ev)
 
-	scanner := newJSONLScanner(f)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		if len(line) == 0 {
-			continue
+	switch ev.Kind {
+	case EventUser:
+		if agg.Topic == "" && isTopicCandidate(ev.Text) {
+			agg.Topic = extractTopic(ev.Text)
 		}
 
-		var entry entry
-		if err := json.Unmarshal(line, &entry); err != nil {
-			continue
+	case EventAssistant:
+		if agg.TokensByModel == nil {
+			agg.TokensByModel = make(map[string]Usage)
 		}
-
-		// Capture git branch from first entry that has it
-		if agg.Branch == "" && entry.GitBranch != "" {
-			agg.Branch = entry.GitBranch
+		turn := ev.Turn
+		// Streaming dedup: same requestId as last assistant entry → undo previous accumulation.
+		if ev.RequestID != "" && ev.RequestID == agg.lastRequestID {
+			u := agg.TokensByModel[agg.lastRequestModel]
+			u.InputTokens -= agg.lastRequestUsage.InputTokens
+			u.CacheCreationInputTokens -= agg.lastRequestUsage.CacheCreationInputTokens
+			u.CacheReadInputTokens -= agg.lastRequestUsage.CacheReadInputTokens
+			u.OutputTokens -= agg.lastRequestUsage.OutputTokens
+			agg.TokensByModel[agg.lastRequestModel] = u
+			agg.TotalToolCalls -= agg.lastToolCallDelta
+			agg.NumTurns--
 		}
-
-		// Capture slug from first entry that has it
-		if agg.Slug == "" && entry.Slug != "" {
-			agg.Slug = entry.Slug
+		// Accumulate the current (possibly replacement) entry.
+		u := agg.TokensByModel[turn.Model]
+		u.InputTokens += turn.Usage.InputTokens
+		u.CacheCreationInputTokens += turn.Usage.CacheCreationInputTokens
+		u.CacheReadInputTokens += turn.Usage.CacheReadInputTokens
+		u.OutputTokens += turn.Usage.OutputTokens
+		agg.TokensByModel[turn.Model] = u
+		toolCallDelta := len(turn.ToolCalls)
+		agg.TotalToolCalls += toolCallDelta
+		agg.NumTurns++
+		// Update last-request state for potential future dedup.
+		if ev.RequestID != "" {
+			agg.lastRequestID = ev.RequestID
+			agg.lastRequestModel = turn.Model
+			agg.lastRequestUsage = turn.Usage
+			agg.lastToolCallDelta = toolCallDelta
 		}
 
-		switch entry.Type {
-		case "user":
-			var msg userMessage
-			if err := json.Unmarshal(entry.Message, &msg); err != nil {
-				break
-			}
-			// Set topic from first real user message, matching claude -r display (skip skill prefix lines)
-			if agg.Topic == "" {
-				if text := msg.textContent(); text != "" {
-					if !strings.HasPrefix(text, "Base directory for this skill:") {
-						agg.Topic = extractTopic(text)
-					}
-				}
-			}
-
-		case "assistant":
-			var msg assistantMessage
-			if err := json.Unmarshal(entry.Message, &msg); err != nil {
-				break
-			}
-			// Streaming dedup: same requestId as last assistant entry → undo previous accumulation.
-			if entry.RequestID != "" && entry.RequestID == agg.lastRequestID {
-				u := agg.TokensByModel[agg.lastRequestModel]
-				u.InputTokens -= agg.lastRequestUsage.NewInputTokens()
-				u.CacheReadInputTokens -= agg.lastRequestUsage.CacheReadInputTokens
-				u.OutputTokens -= agg.lastRequestUsage.OutputTokens
-				agg.TokensByModel[agg.lastRequestModel] = u
-				agg.TotalToolCalls -= agg.lastToolCallDelta
-				agg.NumTurns--
-			}
-			// Accumulate the current (possibly replacement) entry.
-			u := agg.TokensByModel[msg.Model]
-			u.InputTokens += msg.Usage.NewInputTokens()
-			u.CacheReadInputTokens += msg.Usage.CacheReadInputTokens
-			u.OutputTokens += msg.Usage.OutputTokens
-			agg.TokensByModel[msg.Model] = u
-			toolCallDelta := 0
-			for _, c := range msg.Content {
-				if c.Type == "tool_use" {
-					toolCallDelta++
-				}
-			}
-			agg.TotalToolCalls += toolCallDelta
-			agg.NumTurns++
-			// Update last-request state for potential future dedup.
-			if entry.RequestID != "" {
-				agg.lastRequestID = entry.RequestID
-				agg.lastRequestModel = msg.Model
-				agg.lastRequestUsage = msg.Usage
-				agg.lastToolCallDelta = toolCallDelta
-			}
-
-		case "system":
-			dur, turns, _ := parseSystemDuration(entry.Message, line)
-			agg.DurationMS += dur
-			if turns > 0 {
-				agg.NumTurns = turns
-			}
+	case EventTurnDuration:
+		agg.DurationMS += ev.DurationMS
+		if ev.NumTurns > 0 {
+			agg.NumTurns = ev.NumTurns
+		}
+		if ev.CostUSD > 0 {
+			agg.TotalCost = ev.CostUSD
 		}
 	}
+}
 
-	if err := scanner.Err(); err != nil {
-		return agg, err
+// clone returns a deep copy of agg that is safe to hand to other goroutines.
+func (agg *SessionAggregates) clone() *SessionAggregates {
+	c := *agg
+	c.Activity = agg.Activity.clone()
+	c.TokensByModel = make(map[string]Usage, len(agg.TokensByModel))
+	for m, u := range agg.TokensByModel {
+		c.TokensByModel[m] = u
 	}
+	return &c
+}
 
-	// Update offset to end of file
-	if pos, err := f.Seek(0, io.SeekEnd); err == nil {
-		agg.Offset = pos
+// ParseAggregatesIncremental reads complete lines appended to a JSONL file since
+// the stored offset, accumulates metrics into agg, and returns the updated aggregates.
+// If agg is nil, a new SessionAggregates is created (reading from offset 0).
+// If the file was truncated or replaced, agg is reset and rebuilt.
+func ParseAggregatesIncremental(path string, agg *SessionAggregates) (*SessionAggregates, error) {
+	if agg == nil {
+		agg = newSessionAggregates()
 	}
-
-	return agg, nil
+	reset := func() { *agg = *newSessionAggregates() }
+	err := tailFile(path, &agg.Offset, &agg.info, reset, agg)
+	return agg, err
 }
 
 // extractTopic normalizes raw user message text for use as a session topic.
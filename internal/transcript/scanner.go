@@ -155,6 +155,12 @@ func ScanSubagents(subagentDir string) ([]SessionInfo, error) {
 	return scanJSONLFiles(subagentDir, false, false)
 }
 
+// SubagentID returns the agent ID of a subagent transcript, which Claude Code
+// names agent-<agentId>.jsonl. This is the ID Agent/Task results report.
+func SubagentID(si SessionInfo) string {
+	return strings.TrimPrefix(si.ID, "agent-")
+}
+
 // CountSubagents returns the number of subagent JSONL files in the given directory.
 func CountSubagents(subagentDir string) int {
 	if subagentDir == "" {
@@ -0,0 +1,59 @@
+package transcript
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// tailFile decodes the complete lines appended to path since *offset and
+// feeds them to sinks.
+//
+// Only newline-terminated lines are consumed: a trailing partial line (one
+// Claude Code is still writing) is left unread and *offset stays at its start,
+// so the line is decoded in full on the next call. Unlike Parse, this holds
+// for a final line that is already whole but lacks its newline.
+//
+// *info records the identity of the file at the last read. When the file has
+// shrunk below *offset or was replaced by a different file (inode change),
+// reset is called so the caller can discard state built from the old content,
+// and decoding restarts from the beginning of the file.
+func tailFile(path string, offset *int64, info *os.FileInfo, reset func(), sinks ...Sink) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() < *offset || (*info != nil && !os.SameFile(*info, fi)) {
+		reset()
+		*offset = 0
+	}
+	*info = fi
+
+	if *offset > 0 {
+		if _, err := f.Seek(*offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	scanner := newJSONLScanner(f)
+	consumed := int64(0)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			// No complete line buffered: ask for more data, or stop at EOF
+			// without consuming the partial line.
+			return 0, nil, nil
+		}
+		consumed += int64(i + 1)
+		return i + 1, data[:i], nil
+	})
+	err = decodeLines(scanner, sinks)
+	*offset += consumed
+	return err
+}
@@ -0,0 +1,187 @@
+package transcript_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/transcript"
+)
+
+const (
+	tailUserLine      = `{"type":"user","timestamp":"2025-01-01T10:00:00Z","message":{"role":"user","content":"Hello"}}` + "\n"
+	tailAssistantLine = `{"type":"assistant","timestamp":"2025-01-01T10:00:01Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"t1","name":"Read","input":{}}],"model":"claude-opus-4-6","usage":{"input_tokens":100,"output_tokens":20}}}` + "\n"
+)
+
+func appendToFile(t *testing.T, path, s string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(s); err != nil {
+		_ = f.Close()
+		t.Fatal(err)
+	}
+	_ = f.Close()
+}
+
+func TestIncrementalRereadsPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial.jsonl")
+	half := len(tailAssistantLine) / 2
+	if err := os.WriteFile(path, []byte(tailUserLine+tailAssistantLine[:half]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	agg, err := transcript.ParseAggregatesIncremental(path, nil)
+	if err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if agg.Offset != int64(len(tailUserLine)) {
+		t.Errorf("offset should stop at the last newline: got %d, want %d", agg.Offset, len(tailUserLine))
+	}
+	if agg.TotalToolCalls != 0 {
+		t.Errorf("partial line must not be decoded, got %d tool calls", agg.TotalToolCalls)
+	}
+
+	appendToFile(t, path, tailAssistantLine[half:])
+	agg, err = transcript.ParseAggregatesIncremental(path, agg)
+	if err != nil {
+		t.Fatalf("second call failed: %v", err)
+	}
+	if agg.TotalToolCalls != 1 {
+		t.Errorf("completed line should be decoded on the next call, got %d tool calls", agg.TotalToolCalls)
+	}
+	if got := agg.TokensByModel["claude-opus-4-6"].InputTokens; got != 100 {
+		t.Errorf("InputTokens = %d, want 100", got)
+	}
+	if agg.Offset != int64(len(tailUserLine)+len(tailAssistantLine)) {
+		t.Errorf("offset = %d, want end of file", agg.Offset)
+	}
+}
+
+func TestIncrementalWaitsForFinalNewline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unterminated.jsonl")
+	last := tailAssistantLine[:len(tailAssistantLine)-1]
+	if err := os.WriteFile(path, []byte(tailUserLine+last), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The full parse decodes a final line without a newline.
+	pt, err := transcript.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pt.Turns) != 2 {
+		t.Errorf("ParseFile: %d turns, want 2", len(pt.Turns))
+	}
+
+	// The tailer commits offsets only at newlines, so it waits for one.
+	agg, err := transcript.ParseAggregatesIncremental(path, nil)
+	if err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if agg.TotalToolCalls != 0 || agg.Offset != int64(len(tailUserLine)) {
+		t.Errorf("unterminated line: %d tool calls, offset %d; want 0 at %d", agg.TotalToolCalls, agg.Offset, len(tailUserLine))
+	}
+	appendToFile(t, path, "\n")
+	agg, err = transcript.ParseAggregatesIncremental(path, agg)
+	if err != nil {
+		t.Fatalf("second call failed: %v", err)
+	}
+	if agg.TotalToolCalls != 1 || agg.Offset != int64(len(tailUserLine)+len(tailAssistantLine)) {
+		t.Errorf("after the newline: %d tool calls, offset %d; want 1 at end of file", agg.TotalToolCalls, agg.Offset)
+	}
+}
+
+func TestIncrementalTurnsRereadPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial-turns.jsonl")
+	half := len(tailAssistantLine) / 2
+	if err := os.WriteFile(path, []byte(tailUserLine+tailAssistantLine[:half]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := transcript.ParseFileIncremental(path, nil)
+	if err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if n := len(cache.Turns()); n != 1 {
+		t.Fatalf("expected 1 turn before the line completes, got %d", n)
+	}
+
+	appendToFile(t, path, tailAssistantLine[half:])
+	cache, err = transcript.ParseFileIncremental(path, cache)
+	if err != nil {
+		t.Fatalf("second call failed: %v", err)
+	}
+	turns := cache.Turns()
+	if len(turns) != 2 || len(turns[1].ToolCalls) != 1 {
+		t.Fatalf("expected user + assistant with 1 tool call, got %+v", turns)
+	}
+}
+
+func TestIncrementalResetsOnTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "truncate.jsonl")
+	if err := os.WriteFile(path, []byte(tailUserLine+tailAssistantLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+	agg, err := transcript.ParseAggregatesIncremental(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.TotalToolCalls != 1 {
+		t.Fatalf("expected 1 tool call, got %d", agg.TotalToolCalls)
+	}
+
+	// Rewrite the file with shorter content.
+	if err := os.WriteFile(path, []byte(`{"type":"user","message":{"role":"user","content":"Other"}}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	agg, err = transcript.ParseAggregatesIncremental(path, agg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.TotalToolCalls != 0 || len(agg.TokensByModel) != 0 {
+		t.Errorf("aggregates should be rebuilt after truncation, got %d tool calls, %d models", agg.TotalToolCalls, len(agg.TokensByModel))
+	}
+	if agg.Topic != "Other" {
+		t.Errorf("expected topic from new content, got %q", agg.Topic)
+	}
+}
+
+func TestFileCacheResetsOnReplacement(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "replace.jsonl")
+	if err := os.WriteFile(path, []byte(tailUserLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fc := transcript.NewFileCache(path)
+	if turns, err := fc.Turns(); err != nil || len(turns) != 1 {
+		t.Fatalf("expected 1 turn, got %d (err %v)", len(turns), err)
+	}
+
+	// Atomically replace the file with a longer one (new inode, larger size).
+	tmp := filepath.Join(dir, "replace.tmp")
+	other := `{"type":"user","message":{"role":"user","content":"Replaced"}}` + "\n" + tailAssistantLine
+	if err := os.WriteFile(tmp, []byte(other), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatal(err)
+	}
+
+	turns, err := fc.Turns()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(turns) != 2 || turns[0].Text != "Replaced" {
+		t.Fatalf("turns should be rebuilt from the replacement file, got %+v", turns)
+	}
+	agg, err := fc.Aggregates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agg.Topic != "Replaced" || agg.TotalToolCalls != 1 {
+		t.Errorf("aggregates should be rebuilt: topic=%q tool calls=%d", agg.Topic, agg.TotalToolCalls)
+	}
+}
@@ -1,6 +1,9 @@
 package transcript
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"regexp"
+)
 
 // entry represents a single JSONL line in a transcript file.
 type entry struct {
@@ -8,11 +11,15 @@ type entry struct {
 	Subtype         string           `json:"subtype"`
 	Timestamp       string           `json:"timestamp"`
 	UUID            string           `json:"uuid"`
+	ParentUUID      string           `json:"parentUuid"`
+	LogicalParent   string           `json:"logicalParentUuid"` // set when parentUuid is cut, e.g. at a compact boundary
+	IsSidechain     bool             `json:"isSidechain"`
 	SessionID       string           `json:"sessionId"`
 	Slug            string           `json:"slug"`
 	GitBranch       string           `json:"gitBranch"`
 	RequestID       string           `json:"requestId"`
 	Message         json.RawMessage  `json:"message"`
+	ToolUseResult   json.RawMessage  `json:"toolUseResult"` // structured result of the tool call a user entry answers
 	CompactMetadata *compactMetadata `json:"compactMetadata,omitempty"`
 }
 
@@ -46,19 +53,12 @@ type Usage struct {
 }
 
 // TotalInputTokens returns the sum of all input-side tokens including cache.
-// This is valid on both raw and merged Usage values, provided mergeAssistantTurn
-// accumulates each field separately (not via NewInputTokens()).
+// This is valid on raw, merged and aggregated Usage values, since every field
+// is accumulated separately.
 func (u Usage) TotalInputTokens() int {
 	return u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
 }
 
-// NewInputTokens returns the count of tokens newly processed this turn —
-// InputTokens plus CacheCreationInputTokens — excluding CacheReadInputTokens,
-// which represent context served from cache rather than freshly processed.
-func (u Usage) NewInputTokens() int {
-	return u.InputTokens + u.CacheCreationInputTokens
-}
-
 // assistantMessage is the message field when type=="assistant".
 type assistantMessage struct {
 	Role    string           `json:"role"`
@@ -100,6 +100,26 @@ func (m *userMessage) textContent() string {
 	return text
 }
 
+// agentIDPattern matches the agent ID line Claude Code appends to Agent/Task results.
+var agentIDPattern = regexp.MustCompile(`agentId:\s*([\w-]+)`)
+
+// resultAgentID returns the ID of the sub-agent that produced a tool result:
+// the agentId field of the entry's toolUseResult, or failing that the
+// "agentId: …" line in the result text. Returns "" when neither is present.
+func resultAgentID(toolUseResult, content json.RawMessage) string {
+	var meta struct {
+		AgentID string `json:"agentId"`
+	}
+	if json.Unmarshal(toolUseResult, &meta) == nil && meta.AgentID != "" {
+		return meta.AgentID
+	}
+	text := (&userMessage{Content: content}).textContent()
+	if m := agentIDPattern.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
 // toolResults returns tool_result blocks from the content array.
 // Returns nil if content is a plain string.
 func (m *userMessage) toolResults() []messageContent {
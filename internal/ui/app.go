@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/search"
 )
 
 // TickMsg is sent on each timer tick for animations.
@@ -36,6 +38,7 @@ type AppModel struct {
 	Crumbs CrumbsModel
 	Flash  FlashModel
 	Filter FilterModel
+	Search FilterModel // query input of the search view
 
 	// Content
 	Resource      model.ResourceType
@@ -52,23 +55,41 @@ type AppModel struct {
 	// Session chat data (set on drill-down into session-chat)
 	SelectedTurns              []model.Turn
 	SubagentTurns              [][]model.Turn
-	SubagentTypes              []model.AgentType
-	ChatFollow                 bool   // true = auto-scroll to bottom (tail -f mode)
-	SelectedSessionSlug        string // slug for the selected session (shown in header)
-	SelectedSessionFilePath    string // for async reload
-	SelectedSessionSubagentDir string // for async subagent reload
+	SubagentIDs                []string     // agent ID of each SubagentTurns entry
+	ChatFollow                 bool         // true = auto-scroll to bottom (tail -f mode)
+	SelectedSessionSlug        string       // slug for the selected session (shown in header)
+	SelectedSessionFilePath    string       // for async reload
+	SelectedSessionSubagentDir string       // for async subagent reload
+	SelectedAgent              *model.Agent // agent whose history is shown (nil = whole session)
+
+	// Conversation branch shown for a solo session. branchPinned is false
+	// while following the active branch.
+	selectedBranch int
+	branchPinned   bool
+	branchCount    int
 
 	// Slug group: all sessions in the selected slug group (len > 1 when merged)
 	SlugSessions      []*model.Session
-	slugGroupTurns    [][]model.Turn      // per-session turns for slug group
-	slugGroupSubTurns [][][]model.Turn    // per-session subagent turns
-	slugGroupSubTypes [][]model.AgentType // per-session subagent types
+	slugGroupTurns    [][]model.Turn   // per-session turns for slug group
+	slugGroupSubTurns [][][]model.Turn // per-session subagent turns
+	slugGroupSubIDs   [][]string       // per-session subagent agent IDs
 
 	// Chat table state
-	ChatItems        []ChatItem      // flattened selectable items
-	SelectedChatItem int             // index of expanded item in detail view
-	ExpandedItems    map[string]bool // ChatItemKey → expanded (tool call sub-rows visible)
-	SelectedToolCall *ToolCallRow    // for tool-call-detail view
+	ChatItems        []ChatItem        // flattened selectable items
+	SelectedChatItem int               // index of expanded item in detail view
+	ExpandedItems    map[string]bool   // ChatItemKey → expanded (tool call sub-rows visible)
+	SelectedToolCall *ToolCallRow      // for tool-call-detail view
+	sessionToolCalls []*model.ToolCall // the session's tool calls when SelectedToolCall was chosen, for its diff
+
+	// Sort chosen for each table view; kept across navigation.
+	Sorts map[model.ResourceType]SortState
+
+	// Global search. The caller runs SearchQuery against its index and
+	// reports the index's progress in SearchStatus.
+	SearchQuery  string
+	SearchStatus string
+	searchJump   string     // ChatItemKey the history view selects once after opening a hit
+	searchMark   searchMark // hit highlighted in the history view
 
 	// Data providers (injected from outside)
 	DataProvider DataProvider
@@ -79,6 +100,24 @@ type AppModel struct {
 	// Filter mode flag
 	inFilter bool
 
+	// Search input mode flag
+	inSearch bool
+
+	// In-content search of the current content view
+	contentSearch contentSearchState
+	// Rendered content view and its search matches; shared by copies
+	content *contentCache
+
+	// Clipboard copies yanked text; NewAppModel sets OSC 52 on stdout.
+	Clipboard func(text string) error
+
+	// Exec runs the editor or pager `e` opens, suspending the program;
+	// NewAppModel sets tea.ExecProcess.
+	Exec func(cmd *exec.Cmd, fn tea.ExecCallback) tea.Cmd
+
+	// yankOpts lists what an open `y` prompt can copy; nil when closed.
+	yankOpts []yankOption
+
 	// filterStack saves parent-view filters across drill-downs
 	filterStack []string
 
@@ -86,7 +125,14 @@ type AppModel struct {
 	jumpFrom *jumpFromState
 }
 
-// jumpFromState holds the navigation state before a t/p/m resource jump.
+// searchMark identifies the ChatItem of an opened search hit and the words
+// to highlight in its row.
+type searchMark struct {
+	key   string
+	terms []string
+}
+
+// jumpFromState holds the navigation state before a p/m/F resource jump.
 type jumpFromState struct {
 	Resource            model.ResourceType
 	SelectedProjectHash string
@@ -107,6 +153,13 @@ func isSubView(rt model.ResourceType) bool {
 		rt == model.ResourceToolCallDetail
 }
 
+// canSearch returns true for views F opens the search view from. Like p/m,
+// it is blocked in sub-views and in the other jump targets so that esc can
+// still return to where the jump started.
+func canSearch(rt model.ResourceType) bool {
+	return !isSubView(rt) && rt != model.ResourcePlugins && rt != model.ResourceMemory
+}
+
 // isContentView returns true for views that render flat text (not a table).
 // These views use ContentOffset for scrolling instead of Table navigation.
 func isContentView(rt model.ResourceType) bool {
@@ -132,12 +185,16 @@ func NewAppModel(dp DataProvider, initialResource model.ResourceType) AppModel {
 	m := AppModel{
 		DataProvider: dp,
 		Resource:     initialResource,
+		Clipboard:    copyToTerminal,
+		Exec:         tea.ExecProcess,
+		content:      &contentCache{},
 	}
 	m.Info = InfoModel{}
 	m.refreshMenu()
 	m.Crumbs = CrumbsModel{Items: []string{string(initialResource)}}
 	m.Flash = FlashModel{}
 	m.Filter = FilterModel{}
+	m.Search = FilterModel{Prompt: "search: "}
 	return m
 }
 
@@ -174,15 +231,25 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.Menu.ClearHighlight()
 		return m, nil
 
+	case YankedMsg:
+		m.yankedFlash(msg)
+		return m, nil
+
+	case EditorClosedMsg:
+		if msg.Err != nil {
+			m.Flash = FlashModel{Message: "open: " + msg.Err.Error(), Level: FlashError, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		// Ctrl+C always quits
 		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
 
-		// Highlight menu items only when not in filter input mode.
+		// Highlight menu items only when not in filter or search input mode.
 		var highlightCmd tea.Cmd
-		if !m.inFilter {
+		if !m.inFilter && !m.inSearch {
 			highlightKey := msg.String()
 			if highlightKey == " " {
 				highlightKey = "space"
@@ -199,11 +266,23 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return model, tea.Batch(cmd, highlightCmd)
 		}
 
+		// Search input mode
+		if m.inSearch {
+			return m.updateSearch(msg)
+		}
+
+		// Yank prompt
+		if m.yankOpts != nil {
+			model, cmd := m.updateYank(msg)
+			return model, tea.Batch(cmd, highlightCmd)
+		}
+
 		// Global keys (work in all view modes)
 		switch msg.String() {
 		case "/":
-			if m.Resource != model.ResourceMemoryDetail && m.Resource != model.ResourcePluginItemDetail &&
-				m.Resource != model.ResourceHistoryDetail && m.Resource != model.ResourceToolCallDetail {
+			if isContentView(m.Resource) {
+				m.openContentSearch()
+			} else {
 				m.inFilter = true
 				m.Filter.Activate()
 				m.refreshMenu()
@@ -219,6 +298,20 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.jumpTo(model.ResourceMemory)
 			}
 			return m, highlightCmd
+		case "F":
+			if canSearch(m.Resource) {
+				m.openSearch()
+			}
+			return m, highlightCmd
+		case "y":
+			m.startYank()
+			return m, highlightCmd
+		case "e":
+			if isContentView(m.Resource) || m.Resource == model.ResourceToolCalls || m.Resource == model.ResourceHistory {
+				cmd := m.openExternal()
+				return m, tea.Batch(cmd, highlightCmd)
+			}
+			return m, highlightCmd
 		}
 
 		// View-specific keys
@@ -230,6 +323,9 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m AppModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if isContentView(m.Resource) {
+		return m.updateContentSearch(msg)
+	}
 	switch msg.String() {
 	case "esc":
 		m.inFilter = false
@@ -238,6 +334,10 @@ func (m AppModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.Table.Filter = ""
 		m.refreshMenu()
 	case "enter":
+		// An invalid query stays open for editing.
+		if m.Filter.Err != "" {
+			return m, nil
+		}
 		m.inFilter = false
 		m.Filter.Deactivate()
 		m.refreshMenu()
@@ -252,13 +352,62 @@ func (m AppModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.Table.Selected = 0
 		}
 	}
+	m.Filter.Err = ""
+	if err := m.Table.FilterErr(); err != nil {
+		m.Filter.Err = err.Error()
+	}
+	return m, nil
+}
+
+// openSearch jumps to the search view, unless already there, and opens its
+// query input holding the current query.
+func (m *AppModel) openSearch() {
+	if m.Resource != model.ResourceSearch {
+		m.jumpTo(model.ResourceSearch)
+	}
+	m.inSearch = true
+	m.Search.Active = true
+	m.Search.Input = m.SearchQuery
+	m.refreshMenu()
+}
+
+// updateSearch edits the search query. The query is live: SearchQuery
+// follows every key. Closing the input with nothing searched leaves the view.
+func (m AppModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter":
+		m.inSearch = false
+		m.Search.Deactivate()
+		if m.SearchQuery == "" {
+			m.Menu.ClearHighlight()
+			m.navigateBack()
+			return m, nil
+		}
+	case "backspace":
+		m.Search.Backspace()
+	default:
+		if len(msg.Runes) == 1 {
+			m.Search.AddChar(msg.Runes[0])
+		}
+	}
+	if m.Search.Input != m.SearchQuery {
+		m.SearchQuery = m.Search.Input
+		m.Table.Selected = 0
+		m.Table.Offset = 0
+	}
+	m.refreshMenu()
 	return m, nil
 }
 
 func (m AppModel) updateTable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
-		if m.Table.Filter != "" {
+		if isContentView(m.Resource) && m.contentSearch.query != "" {
+			// Search clear: same view stays at the current match.
+			m.clearContentSearch()
+			m.Filter.Input = ""
+			m.refreshMenu()
+		} else if m.Table.Filter != "" {
 			// Filter clear: same view stays — keep highlight, update menu desc.
 			m.Table.Filter = ""
 			m.Filter.Input = ""
@@ -280,6 +429,36 @@ func (m AppModel) updateTable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.Resource == model.ResourceHistory {
 			return m, m.toggleExpansion()
 		}
+	case "a":
+		if m.Resource == model.ResourceSessions {
+			m.Menu.ClearHighlight()
+			m.drillAgents()
+		}
+	case "t":
+		if m.Resource == model.ResourceSessions || m.Resource == model.ResourceAgents {
+			m.Menu.ClearHighlight()
+			m.drillToolCalls()
+		}
+	case "s", "S":
+		if len(m.Table.Sortable) > 0 {
+			return m, m.changeSort(msg.String() == "S")
+		}
+	case "n", "N":
+		if isContentView(m.Resource) {
+			dir := 1
+			if msg.String() == "N" {
+				dir = -1
+			}
+			m.stepContentMatch(dir)
+		}
+	case "b", "B":
+		if m.Resource == model.ResourceHistory {
+			dir := 1
+			if msg.String() == "B" {
+				dir = -1
+			}
+			return m, m.switchBranch(dir)
+		}
 	default:
 		if isContentView(m.Resource) {
 			m.updateContentScroll(msg)
@@ -292,7 +471,9 @@ func (m AppModel) updateTable(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// renderContentForResource renders the content string for the current content-only view.
+// renderContentForResource renders the content string for the current
+// content-only view. Callers that need lines use contentViewLines, which is
+// cached.
 // Returns "" for non-content views (table views).
 func (m AppModel) renderContentForResource() string {
 	switch m.Resource {
@@ -303,18 +484,17 @@ func (m AppModel) renderContentForResource() string {
 	case model.ResourceHistoryDetail:
 		return RenderChatItemDetail(m.ChatItems, m.SelectedChatItem, m.contentWidth())
 	case model.ResourceToolCallDetail:
-		return RenderToolCallDetail(m.SelectedToolCall, m.contentWidth())
+		return RenderToolCallDetail(m.SelectedToolCall, m.sessionToolCalls, m.contentWidth())
 	}
 	return ""
 }
 
 // contentMaxOffset returns the maximum scroll offset for the current content view.
 func (m AppModel) contentMaxOffset() int {
-	contentStr := m.renderContentForResource()
-	if contentStr == "" {
+	lines := m.contentViewLines()
+	if len(lines) == 0 {
 		return 0
 	}
-	lines := strings.Split(strings.TrimRight(contentStr, "\n"), "\n")
 	if max := len(lines) - m.contentHeight(); max > 0 {
 		return max
 	}
@@ -391,9 +571,15 @@ func (m *AppModel) RebuildChatItems() {
 		for i, s := range m.SlugSessions {
 			ids[i] = s.ShortID()
 		}
-		m.ChatItems = BuildMergedChatItems(m.slugGroupTurns, m.slugGroupSubTurns, m.slugGroupSubTypes, ids)
+		m.ChatItems = BuildMergedChatItems(m.slugGroupTurns, m.slugGroupSubTurns, m.slugGroupSubIDs, ids)
 	} else {
-		m.ChatItems = BuildChatItems(m.SelectedTurns, m.SubagentTurns, m.SubagentTypes)
+		tree := model.BuildConversationTree(m.SelectedTurns)
+		m.branchCount = tree.BranchCount()
+		branch := -1
+		if m.branchPinned {
+			branch = m.selectedBranch
+		}
+		m.ChatItems = BuildBranchChatItems(tree, branch, m.SubagentTurns, m.SubagentIDs)
 	}
 
 	// Re-resolve the selected item so it stays on the same turn after regrouping.
@@ -407,33 +593,69 @@ func (m *AppModel) RebuildChatItems() {
 	}
 }
 
+// BranchCount returns the number of conversation branches in the selected
+// solo session (1 when linear, 0 before any turns are loaded).
+func (m AppModel) BranchCount() int {
+	if len(m.SlugSessions) > 1 {
+		return 0
+	}
+	return m.branchCount
+}
+
+// switchBranch shows the next (dir=1) or previous (dir=-1) conversation
+// branch of the selected session and flashes which one is shown.
+func (m *AppModel) switchBranch(dir int) tea.Cmd {
+	n := m.BranchCount()
+	if n < 2 {
+		return nil
+	}
+	cur := n - 1 // the active branch is always the last one
+	if m.branchPinned {
+		cur = m.selectedBranch
+	}
+	m.selectedBranch = (cur + dir + n) % n
+	m.branchPinned = true
+	m.ChatFollow = false
+	m.RebuildChatItems()
+
+	msg := fmt.Sprintf("branch %d/%d", m.selectedBranch+1, n)
+	if m.selectedBranch == n-1 {
+		msg += " · active"
+	} else {
+		msg += " · abandoned"
+	}
+	m.Flash = FlashModel{Message: msg, Level: FlashInfo, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+	m.refreshMenu()
+	return func() tea.Msg { return SyncViewMsg{} }
+}
+
 // loadSlugGroupTurns loads turns from all sessions in the slug group.
 func (m *AppModel) loadSlugGroupTurns() {
 	m.slugGroupTurns = nil
 	m.slugGroupSubTurns = nil
-	m.slugGroupSubTypes = nil
+	m.slugGroupSubIDs = nil
 	for _, s := range m.SlugSessions {
 		turns := m.DataProvider.GetTurns(s.FilePath)
 		m.slugGroupTurns = append(m.slugGroupTurns, turns)
 		agents := m.DataProvider.GetAgents(s.ID)
 		var subTurns [][]model.Turn
-		var subTypes []model.AgentType
+		var subIDs []string
 		for _, a := range agents {
 			if a.IsSubagent && a.FilePath != "" {
 				subTurns = append(subTurns, m.DataProvider.GetTurns(a.FilePath))
-				subTypes = append(subTypes, a.Type)
+				subIDs = append(subIDs, a.AgentID())
 			}
 		}
 		m.slugGroupSubTurns = append(m.slugGroupSubTurns, subTurns)
-		m.slugGroupSubTypes = append(m.slugGroupSubTypes, subTypes)
+		m.slugGroupSubIDs = append(m.slugGroupSubIDs, subIDs)
 	}
 }
 
 // SetSlugGroupData updates the slug group turn data (used by async reload).
-func (m *AppModel) SetSlugGroupData(turns [][]model.Turn, subTurns [][][]model.Turn, subTypes [][]model.AgentType) {
+func (m *AppModel) SetSlugGroupData(turns [][]model.Turn, subTurns [][][]model.Turn, subIDs [][]string) {
 	m.slugGroupTurns = turns
 	m.slugGroupSubTurns = subTurns
-	m.slugGroupSubTypes = subTypes
+	m.slugGroupSubIDs = subIDs
 }
 
 // refreshMenu updates the menu nav and util items based on current state.
@@ -444,6 +666,9 @@ func (m *AppModel) RefreshMenu() { m.refreshMenu() }
 
 func (m *AppModel) refreshMenu() {
 	hasFilter := m.Table.Filter != "" || m.inFilter
+	if isContentView(m.Resource) {
+		hasFilter = m.contentSearch.query != "" || m.inFilter
+	}
 	canExpand := false
 	if row := m.Table.SelectedRow(); row != nil {
 		if ci, ok := row.Data.(ChatItem); ok && !ci.IsDivider && len(ci.AllToolCalls()) > 0 {
@@ -451,7 +676,19 @@ func (m *AppModel) refreshMenu() {
 		}
 	}
 	m.Menu.NavItems = TableNavItems(m.Resource, hasFilter)
-	m.Menu.ActionItems = TableActionItems(m.Resource, hasFilter, canExpand)
+	m.Menu.ActionItems = TableActionItems(m.Resource, hasFilter, canExpand, m.BranchCount() > 1)
+	if !hasFilter && (m.SelectedAgent != nil || m.parentResource() == model.ResourceSearch) {
+		// History opened from the agents or search view returns there.
+		desc := "see agents"
+		if m.SelectedAgent == nil {
+			desc = "see search"
+		}
+		for i, item := range m.Menu.ActionItems {
+			if item.Key == "esc" {
+				m.Menu.ActionItems[i].Desc = desc
+			}
+		}
+	}
 	m.Menu.UtilItems = TableUtilItems(m.Resource, hasFilter)
 }
 
@@ -480,6 +717,7 @@ func (m *AppModel) jumpTo(rt model.ResourceType) {
 	m.Filter.Input = ""
 	m.Table.Filter = ""
 	m.ContentOffset = 0
+	m.clearContentSearch()
 }
 
 // popFilter restores the parent view's filter from filterStack.
@@ -497,7 +735,7 @@ func (m *AppModel) popFilter() {
 func (m *AppModel) navigateBack() {
 	// Flat resources jumped to via t/p/m: restore previous state
 	switch m.Resource {
-	case model.ResourcePlugins, model.ResourceMemory:
+	case model.ResourcePlugins, model.ResourceMemory, model.ResourceSearch:
 		if m.jumpFrom != nil {
 			m.Resource = m.jumpFrom.Resource
 			m.SelectedProjectHash = m.jumpFrom.SelectedProjectHash
@@ -522,26 +760,44 @@ func (m *AppModel) navigateBack() {
 	}
 	// Navigate up the resource hierarchy
 	m.ContentOffset = 0
+	m.clearContentSearch()
 	switch m.Resource {
 	case model.ResourceToolCallDetail:
-		m.switchResource(model.ResourceHistory)
+		m.switchResource(m.parentResource())
+	case model.ResourceToolCalls:
+		m.popFilter()
+		if m.parentResource() == model.ResourceAgents {
+			m.switchResource(model.ResourceAgents)
+			return
+		}
+		m.clearSession()
+		m.switchResource(model.ResourceSessions)
 	case model.ResourceHistoryDetail:
 		m.switchResource(model.ResourceHistory)
 	case model.ResourceHistory:
-		m.ExpandedItems = nil
-		m.SelectedSessionID = ""
-		m.SelectedSessionSlug = ""
-		m.SelectedSessionFilePath = ""
-		m.SelectedSessionSubagentDir = ""
-		m.SelectedTurns = nil
-		m.SubagentTurns = nil
-		m.SubagentTypes = nil
-		m.SlugSessions = nil
-		m.slugGroupTurns = nil
-		m.slugGroupSubTurns = nil
-		m.slugGroupSubTypes = nil
-		m.ChatFollow = false
-		m.ChatItems = nil
+		if m.SelectedAgent != nil {
+			// History opened from the agents view: keep the session selected.
+			m.SelectedAgent = nil
+			m.clearHistory()
+			m.popFilter()
+			m.switchResource(model.ResourceAgents)
+			return
+		}
+		if m.parentResource() == model.ResourceSearch {
+			// History opened from a search hit: the search view has no project.
+			m.SelectedProjectHash = ""
+			m.clearSession()
+			m.clearHistory()
+			m.popFilter()
+			m.switchResource(model.ResourceSearch)
+			return
+		}
+		m.clearSession()
+		m.clearHistory()
+		m.popFilter()
+		m.switchResource(model.ResourceSessions)
+	case model.ResourceAgents:
+		m.clearSession()
 		m.popFilter()
 		m.switchResource(model.ResourceSessions)
 	case model.ResourceSessions:
@@ -560,6 +816,41 @@ func (m *AppModel) navigateBack() {
 	}
 }
 
+// parentResource returns the resource one breadcrumb up, which is where esc
+// returns to from views reachable from more than one parent.
+func (m AppModel) parentResource() model.ResourceType {
+	if n := len(m.Crumbs.Items); n >= 2 {
+		return model.ResourceType(m.Crumbs.Items[n-2])
+	}
+	return model.ResourceHistory
+}
+
+// clearSession forgets the selected session.
+func (m *AppModel) clearSession() {
+	m.SelectedSessionID = ""
+	m.SelectedSessionSlug = ""
+	m.SelectedSessionFilePath = ""
+	m.SelectedSessionSubagentDir = ""
+}
+
+// clearHistory drops the loaded turns and chat state of the history view.
+func (m *AppModel) clearHistory() {
+	m.ExpandedItems = nil
+	m.SelectedTurns = nil
+	m.SubagentTurns = nil
+	m.SubagentIDs = nil
+	m.branchPinned = false
+	m.branchCount = 0
+	m.SlugSessions = nil
+	m.slugGroupTurns = nil
+	m.slugGroupSubTurns = nil
+	m.slugGroupSubIDs = nil
+	m.ChatFollow = false
+	m.ChatItems = nil
+	m.searchJump = ""
+	m.searchMark = searchMark{}
+}
+
 // toggleExpansion expands or collapses the selected ChatItem's tool call sub-rows.
 // No-ops for dividers, user/assistant rows without tool calls, and ToolCallRow sub-rows.
 func (m *AppModel) toggleExpansion() tea.Cmd {
@@ -597,30 +888,30 @@ func (m *AppModel) drillDown() tea.Cmd {
 		m.drillInto(model.ResourceSessions)
 	case model.ResourceSessions:
 		if s, ok := row.Data.(*model.Session); ok {
-			m.SelectedSessionID = s.ID
-			m.SelectedSessionSlug = s.Slug
-			m.SelectedSessionFilePath = s.FilePath
-			m.SelectedSessionSubagentDir = s.SubagentDir
-
-			if s.IsGroupRepresentative() {
-				m.SlugSessions = s.GroupSessions
-				m.loadSlugGroupTurns()
-			} else {
-				m.SlugSessions = nil
-				m.SelectedTurns = m.DataProvider.GetTurns(s.FilePath)
-				agents := m.DataProvider.GetAgents(s.ID)
+			m.loadHistory(s)
+		}
+		m.drillInto(model.ResourceHistory)
+		m.RebuildChatItems()
+	case model.ResourceAgents:
+		if a, ok := row.Data.(*model.Agent); ok {
+			m.SelectedAgent = a
+			m.branchPinned = false
+			m.SlugSessions = nil
+			if a.IsSubagent {
+				m.SelectedTurns = m.DataProvider.GetTurns(a.FilePath)
 				m.SubagentTurns = nil
-				m.SubagentTypes = nil
-				for _, a := range agents {
-					if a.IsSubagent && a.FilePath != "" {
-						m.SubagentTurns = append(m.SubagentTurns, m.DataProvider.GetTurns(a.FilePath))
-						m.SubagentTypes = append(m.SubagentTypes, a.Type)
-					}
-				}
+				m.SubagentIDs = nil
+			} else {
+				m.loadSessionTurns()
 			}
 		}
 		m.drillInto(model.ResourceHistory)
 		m.RebuildChatItems()
+	case model.ResourceToolCalls:
+		if tr, ok := row.Data.(ToolCallRow); ok {
+			m.selectToolCall(tr)
+			m.drillInto(model.ResourceToolCallDetail)
+		}
 	case model.ResourcePlugins:
 		if p, ok := row.Data.(*model.Plugin); ok {
 			m.SelectedPlugin = p
@@ -636,10 +927,168 @@ func (m *AppModel) drillDown() tea.Cmd {
 			m.SelectedMemory = mem
 		}
 		m.drillInto(model.ResourceMemoryDetail)
+	case model.ResourceSearch:
+		if h, ok := row.Data.(search.Hit); ok {
+			m.openSearchHit(h)
+		}
+	}
+	return nil
+}
+
+// loadHistory selects s and loads its turns, merging its whole slug group
+// when s represents one.
+func (m *AppModel) loadHistory(s *model.Session) {
+	m.selectSession(s)
+	m.branchPinned = false
+	if s.IsGroupRepresentative() {
+		m.SlugSessions = s.GroupSessions
+		m.loadSlugGroupTurns()
+	} else {
+		m.SlugSessions = nil
+		m.loadSessionTurns()
+	}
+}
+
+// openSearchHit opens the history of the session a search hit belongs to,
+// with the hit's ChatItem selected and its words highlighted.
+func (m *AppModel) openSearchHit(h search.Hit) {
+	s := m.findSession(h.ProjectHash, h.SessionID)
+	if s == nil {
+		m.Flash = FlashModel{Message: "session " + h.SessionID + " not found", Level: FlashError, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+		return
+	}
+	m.SelectedProjectHash = h.ProjectHash
+	m.SelectedAgent = nil
+	m.loadHistory(s)
+	m.drillInto(model.ResourceHistory)
+	m.ChatFollow = false
+	m.RebuildChatItems()
+	if i := FindChatItem(m.ChatItems, h.TurnUUID, h.Timestamp); i >= 0 {
+		key := ChatItemKey(m.ChatItems[i])
+		m.searchJump = key
+		m.searchMark = searchMark{key: key, terms: h.Terms}
+	}
+}
+
+// findSession returns the listed session with id in a project, or the
+// representative of the slug group it belongs to.
+func (m *AppModel) findSession(projectHash, id string) *model.Session {
+	for _, s := range m.DataProvider.GetSessions(projectHash) {
+		if s.ID == id {
+			return s
+		}
+		for _, gs := range s.GroupSessions {
+			if gs.ID == id {
+				return s
+			}
+		}
 	}
 	return nil
 }
 
+// TakeSearchJump returns the ChatItemKey the history view should select after
+// a search hit was opened, once; "" when there is none.
+func (m *AppModel) TakeSearchJump() string {
+	key := m.searchJump
+	m.searchJump = ""
+	return key
+}
+
+// ApplySearchHighlight marks the words of the opened search hit in its row of
+// the history table.
+func (m *AppModel) ApplySearchHighlight() {
+	if m.searchMark.key == "" {
+		return
+	}
+	for i, row := range m.Table.Rows {
+		if ci, ok := row.Data.(ChatItem); ok && ChatItemKey(ci) == m.searchMark.key {
+			m.Table.Rows[i].Highlight = m.searchMark.terms
+		}
+	}
+}
+
+// selectSession records s as the selected session.
+func (m *AppModel) selectSession(s *model.Session) {
+	m.SelectedSessionID = s.ID
+	m.SelectedSessionSlug = s.Slug
+	m.SelectedSessionFilePath = s.FilePath
+	m.SelectedSessionSubagentDir = s.SubagentDir
+}
+
+// loadSessionTurns loads the selected solo session's turns and the turns of
+// its subagents.
+func (m *AppModel) loadSessionTurns() {
+	m.SelectedTurns = m.DataProvider.GetTurns(m.SelectedSessionFilePath)
+	m.SubagentTurns = nil
+	m.SubagentIDs = nil
+	for _, a := range m.DataProvider.GetAgents(m.SelectedSessionID) {
+		if a.IsSubagent && a.FilePath != "" {
+			m.SubagentTurns = append(m.SubagentTurns, m.DataProvider.GetTurns(a.FilePath))
+			m.SubagentIDs = append(m.SubagentIDs, a.AgentID())
+		}
+	}
+}
+
+// drillAgents opens the agents view of the highlighted session. In a slug
+// group this is the representative (newest) session.
+func (m *AppModel) drillAgents() {
+	row := m.Table.SelectedRow()
+	if row == nil {
+		return
+	}
+	s, ok := row.Data.(*model.Session)
+	if !ok {
+		return
+	}
+	m.selectSession(s)
+	m.drillInto(model.ResourceAgents)
+}
+
+// drillToolCalls opens the tool-calls view of the highlighted session, or of
+// the selected session from the agents view.
+func (m *AppModel) drillToolCalls() {
+	if m.Resource == model.ResourceSessions {
+		row := m.Table.SelectedRow()
+		if row == nil {
+			return
+		}
+		s, ok := row.Data.(*model.Session)
+		if !ok {
+			return
+		}
+		m.selectSession(s)
+	}
+	m.drillInto(model.ResourceToolCalls)
+}
+
+// SortFor returns the sort chosen for a resource's table (zero = provider order).
+func (m AppModel) SortFor(rt model.ResourceType) SortState {
+	return m.Sorts[rt]
+}
+
+// changeSort moves the current table to its next sortable column, or
+// reverses the current direction, and flashes the result.
+func (m *AppModel) changeSort(reverse bool) tea.Cmd {
+	cur := m.Sorts[m.Resource]
+	next := cur.Next(m.Table.Sortable)
+	if reverse {
+		next = cur.Reversed()
+	}
+	if m.Sorts == nil {
+		m.Sorts = make(map[model.ResourceType]SortState)
+	}
+	m.Sorts[m.Resource] = next
+	m.Table.Selected = 0
+	m.Table.Offset = 0
+
+	msg := "sorted by " + next.Column + " " + next.indicator()
+	if next.Column == "" {
+		msg = "default order"
+	}
+	m.Flash = FlashModel{Message: msg, Level: FlashInfo, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+	return func() tea.Msg { return SyncViewMsg{} }
+}
+
 // drillDetail navigates to the full history detail view for the given ChatItem.
 func (m *AppModel) drillDetail(ci ChatItem) {
 	ciKey := ChatItemKey(ci)
@@ -665,11 +1114,39 @@ func (m *AppModel) drillDetailFromRow() {
 			m.drillDetail(v)
 		}
 	case ToolCallRow:
-		m.SelectedToolCall = &v
+		m.selectToolCall(v)
 		m.drillInto(model.ResourceToolCallDetail)
 	}
 }
 
+// selectToolCall selects tr for the tool-call-detail view, along with every
+// tool call of its session: the tool-calls table's rows, or the calls in the
+// loaded history turns (of every session of a slug group).
+func (m *AppModel) selectToolCall(tr ToolCallRow) {
+	m.SelectedToolCall = &tr
+	m.sessionToolCalls = nil
+	if m.Resource == model.ResourceToolCalls {
+		for _, row := range m.Table.Rows {
+			if r, ok := row.Data.(ToolCallRow); ok {
+				m.sessionToolCalls = append(m.sessionToolCalls, r.ToolCall)
+			}
+		}
+		return
+	}
+	all := append([][]model.Turn{m.SelectedTurns}, m.SubagentTurns...)
+	if len(m.SlugSessions) > 1 {
+		all = append([][]model.Turn(nil), m.slugGroupTurns...)
+		for _, sub := range m.slugGroupSubTurns {
+			all = append(all, sub...)
+		}
+	}
+	for _, turns := range all {
+		for _, t := range turns {
+			m.sessionToolCalls = append(m.sessionToolCalls, t.ToolCalls...)
+		}
+	}
+}
+
 // ApplyExpansion inserts ToolCallRow sub-rows after each expanded ChatItem row
 // in the current Table and adds [+]/[-] indicators to expandable rows.
 // It preserves the cursor position by key.
@@ -772,9 +1249,9 @@ func buildToolCallSubRow(tr ToolCallRow) Row {
 
 	var modelTok string
 	if m := model.ShortModelName(tr.ParentTurn.ModelName); m != "" {
-		in, cache, out := tr.ParentTurn.InputTokens, tr.ParentTurn.CacheReadTokens, tr.ParentTurn.OutputTokens
-		if in > 0 || cache > 0 || out > 0 {
-			modelTok = m + ":" + model.FormatTokenInOutCache(in, cache, out)
+		pt := tr.ParentTurn
+		if pt.InputTokens > 0 || pt.CacheWriteTokens > 0 || pt.CacheReadTokens > 0 || pt.OutputTokens > 0 {
+			modelTok = m + ":" + model.FormatTokenInOutCache(pt.InputTokens, pt.CacheWriteTokens, pt.CacheReadTokens, pt.OutputTokens)
 		} else {
 			modelTok = m
 		}
@@ -799,6 +1276,7 @@ func (m *AppModel) drillInto(rt model.ResourceType) {
 	m.Filter.Deactivate()
 	m.Filter.Input = ""
 	m.ContentOffset = 0
+	m.clearContentSearch()
 	if rt == model.ResourceHistory {
 		m.ChatFollow = true
 	}
@@ -812,6 +1290,7 @@ func (m *AppModel) updateSizes() {
 	m.Crumbs.Width = w
 	m.Flash.Width = w
 	m.Filter.Width = w
+	m.Search.Width = w
 	m.Table.Width = w
 	m.Table.Height = h
 }
@@ -856,15 +1335,14 @@ func (m AppModel) View() string {
 	titleStr := m.renderTitleBar()
 
 	// --- 3. Content ---
-	var contentStr string
+	var rawLines []string
 	limit := m.contentHeight()
 	if isContentView(m.Resource) {
-		contentStr = m.renderContentForResource()
+		rawLines = m.contentViewLines()
 	} else {
-		contentStr = m.Table.View()
+		rawLines = contentLines(m.Table.View())
 	}
-	rawLines := strings.Split(strings.TrimRight(contentStr, "\n"), "\n")
-	// For content-only views, apply scroll offset (capped to actual max).
+	// For content-only views, apply scroll offset (capped to actual max) and mark search matches.
 	if isContentView(m.Resource) {
 		maxOffset := len(rawLines) - limit
 		if maxOffset < 0 {
@@ -874,9 +1352,9 @@ func (m AppModel) View() string {
 		if offset > maxOffset {
 			offset = maxOffset
 		}
-		if offset > 0 {
-			rawLines = rawLines[offset:]
-		}
+		end := min(offset+limit, len(rawLines))
+		rawLines = rawLines[offset:end:end] // a window of the cache; appends must not write into it
+		rawLines = m.highlightContent(rawLines, offset)
 	}
 	if len(rawLines) > limit {
 		rawLines = rawLines[:limit]
@@ -887,8 +1365,14 @@ func (m AppModel) View() string {
 
 	// --- 4. Status bar ---
 	var statusView string
-	if m.inFilter {
+	if isContentView(m.Resource) && (m.inFilter || m.contentSearch.query != "") {
+		statusView = m.contentSearchBar().View()
+	} else if m.inFilter {
 		statusView = m.Filter.View()
+	} else if m.inSearch {
+		statusView = m.Search.View()
+	} else if m.yankOpts != nil {
+		statusView = m.yankPrompt()
 	} else {
 		statusView = m.Flash.View()
 	}
@@ -917,9 +1401,20 @@ func (m AppModel) renderTitleBar() string {
 	if m.Table.Filter != "" {
 		filter = m.Table.Filter
 	}
+	if m.Resource == model.ResourceSearch {
+		// The search view always shows its query, then any filter on the hits.
+		if filter == "all" {
+			filter = m.SearchQuery
+		} else {
+			filter = m.SearchQuery + " | " + filter
+		}
+	}
 
 	count := m.Table.FilteredCount()
 	title := fmt.Sprintf("%s(%s)[%d]", res, filter, count)
+	if m.Resource == model.ResourceSearch && m.SearchStatus != "" {
+		title += " " + m.SearchStatus
+	}
 	titleStyled := StyleTitle.Render(title)
 	titleVis := lipgloss.Width(titleStyled)
 
@@ -3,13 +3,16 @@ package ui_test
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/search"
 	"github.com/Curt-Park/claudeview/internal/ui"
 )
 
@@ -103,6 +106,39 @@ func TestFilterEscClears(t *testing.T) {
 	}
 }
 
+func TestFilterParseErrorKeepsFilterOpen(t *testing.T) {
+	app := newApp(model.ResourceProjects)
+	app.Table.Fields = map[string]ui.Field{"sessions": func(any) any { return 2 }}
+	app.Table.SetRows(projectRows(3))
+
+	app = updateApp(app, keyMsg("/"))
+	for _, r := range "sessions>" {
+		app = updateApp(app, keyMsg(string(r)))
+	}
+	if app.Filter.Err != "sessions>: missing value" {
+		t.Errorf("Filter.Err = %q, want missing value error", app.Filter.Err)
+	}
+	if !strings.Contains(app.Filter.View(), "missing value") {
+		t.Error("expected parse error in the filter bar")
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.Filter.Active {
+		t.Fatal("expected Enter to keep an invalid filter open")
+	}
+
+	app = updateApp(app, keyMsg("1"))
+	if app.Filter.Err != "" {
+		t.Errorf("Filter.Err = %q after fixing the query, want none", app.Filter.Err)
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Filter.Active {
+		t.Error("expected Enter to close a valid filter")
+	}
+	if got := app.Table.FilteredCount(); got != 3 {
+		t.Errorf("FilteredCount = %d, want 3 for sessions>1", got)
+	}
+}
+
 func TestResizeHandled(t *testing.T) {
 	app := newApp(model.ResourceProjects)
 
@@ -296,8 +332,16 @@ func TestFilterTypingUpdatesPluginDetailView(t *testing.T) {
 	}
 }
 
-func TestFilterKeyIgnoredInMemoryDetail(t *testing.T) {
-	m := &model.Memory{Name: "MEMORY.md", Path: "/tmp/MEMORY.md"}
+func TestContentSearchInMemoryDetail(t *testing.T) {
+	var lines []string
+	for i := range 100 {
+		line := fmt.Sprintf("line %d", i)
+		if i == 40 || i == 60 || i == 90 {
+			line += " needle"
+		}
+		lines = append(lines, line)
+	}
+	m := &model.Memory{Name: "MEMORY.md", Content: strings.Join(lines, "\n")}
 	app := newApp(model.ResourceMemory)
 	app.Table.SetRows([]ui.Row{{
 		Cells: []string{"MEMORY.md", "", "1 KB", "1h"},
@@ -306,9 +350,95 @@ func TestFilterKeyIgnoredInMemoryDetail(t *testing.T) {
 	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter}) // drill into memory-detail
 
 	app = updateApp(app, keyMsg("/"))
+	for _, r := range "NEEDLE" {
+		app = updateApp(app, keyMsg(string(r)))
+	}
+	if app.Table.Filter != "" {
+		t.Errorf("content search set Table.Filter=%q", app.Table.Filter)
+	}
+	first := app.ContentOffset
+	if first == 0 || !strings.Contains(app.View(), "1/3") {
+		t.Fatalf("typing: ContentOffset=%d, want the first match scrolled into view with 1/3 in the bar", first)
+	}
 
-	if app.Filter.Active {
-		t.Error("expected filter to remain inactive in memory-detail, but it was activated")
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	app = updateApp(app, keyMsg("n"))
+	if app.ContentOffset <= first || !strings.Contains(app.View(), "2/3") {
+		t.Errorf("n: ContentOffset=%d (first %d), want the second match with 2/3 in the bar", app.ContentOffset, first)
+	}
+	app = updateApp(app, keyMsg("N"))
+	app = updateApp(app, keyMsg("N")) // wraps to the last match
+	if !strings.Contains(app.View(), "3/3") {
+		t.Errorf("N twice from 2/3: want 3/3 in the bar")
+	}
+
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceMemoryDetail || strings.Contains(app.View(), "3/3") {
+		t.Errorf("first esc should clear the search and stay, got resource %s", app.Resource)
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceMemory {
+		t.Errorf("second esc should go back, got resource %s", app.Resource)
+	}
+}
+
+func TestContentSearchCachesRenderedContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "MEMORY.md")
+	if err := os.WriteFile(path, []byte("needle\nneedle"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := &model.Memory{Name: "MEMORY.md", Path: path}
+	app := newApp(model.ResourceMemory)
+	app.Table.SetRows([]ui.Row{{Cells: []string{"MEMORY.md", "", "1 KB", "1h"}, Data: m}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	app = updateApp(app, keyMsg("/"))
+	app = updateApp(app, keyMsg("n"))
+	if !strings.Contains(app.View(), "1/2") {
+		t.Fatal("expected 1/2 in the search bar")
+	}
+
+	// Keys, frames and ticks reuse the rendered content while the item and
+	// its file's size and mtime are unchanged.
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("needle\nhaystk"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, fi.ModTime(), fi.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	app = updateApp(app, keyMsg("e"))
+	app = updateApp(app, ui.TickMsg(time.Now()))
+	if !strings.Contains(app.View(), "1/2") {
+		t.Error("content rendered again without a change")
+	}
+
+	// A changed file renders again.
+	if err := os.WriteFile(path, []byte("needle\nneedle\nneedle"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(app.View(), "1/3") {
+		t.Error("a changed memory file should render again: want 1/3")
+	}
+}
+
+func TestContentSearchWithoutMatchesCloses(t *testing.T) {
+	m := &model.Memory{Name: "MEMORY.md", Content: "nothing to see"}
+	app := newApp(model.ResourceMemory)
+	app.Table.SetRows([]ui.Row{{Cells: []string{"MEMORY.md", "", "1 KB", "1h"}, Data: m}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+
+	app = updateApp(app, keyMsg("/"))
+	app = updateApp(app, keyMsg("x"))
+	if !strings.Contains(app.View(), "no matches") {
+		t.Error("expected 'no matches' in the search bar")
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceMemory {
+		t.Errorf("a search without matches should not survive enter; esc went to %s", app.Resource)
 	}
 }
 
@@ -346,7 +476,7 @@ func TestEscFromPluginItemDetailReturnsToPluginDetail(t *testing.T) {
 	}
 }
 
-func TestFilterKeyIgnoredInPluginItemDetail(t *testing.T) {
+func TestSlashSearchesContentInPluginItemDetail(t *testing.T) {
 	pi := &model.PluginItem{Name: "my-skill", Category: "skill", CacheDir: "/tmp"}
 	app := newApp(model.ResourcePluginDetail)
 	app.Table.SetRows([]ui.Row{{
@@ -357,8 +487,11 @@ func TestFilterKeyIgnoredInPluginItemDetail(t *testing.T) {
 
 	app = updateApp(app, keyMsg("/"))
 
-	if app.Filter.Active {
-		t.Error("expected filter to remain inactive in plugin-item-detail, but it was activated")
+	app = updateApp(app, keyMsg("x"))
+
+	if !app.Filter.Active || app.Table.Filter != "" {
+		t.Errorf("expected / to open the content search without filtering: Active=%v Table.Filter=%q",
+			app.Filter.Active, app.Table.Filter)
 	}
 }
 
@@ -455,6 +588,125 @@ func TestSessionChatEscReturnsToSessions(t *testing.T) {
 	}
 }
 
+func TestAgentsDrillDownAndBack(t *testing.T) {
+	s := &model.Session{ID: "sess-abc123", FilePath: "/tmp/fake.jsonl", SubagentDir: "/tmp/subagents"}
+	sub := &model.Agent{ID: "agent-a1", SessionID: s.ID, IsSubagent: true, FilePath: "/tmp/subagents/agent-a1.jsonl"}
+	dp := &mockDP{
+		agents: []*model.Agent{{SessionID: s.ID, Type: model.AgentTypeMain, FilePath: s.FilePath}, sub},
+		turns:  []model.Turn{{Role: "user", Text: "hi"}},
+	}
+	app := ui.NewAppModel(dp, model.ResourceSessions)
+	app.Width, app.Height = termWidth, termHeight
+	app.Table.SetRows([]ui.Row{{Cells: []string{"", s.ShortID(), "topic"}, Data: s}})
+
+	app = updateApp(app, keyMsg("a"))
+	if app.Resource != model.ResourceAgents || app.SelectedSessionID != s.ID {
+		t.Fatalf("after a: resource=%s session=%q, want agents of %s", app.Resource, app.SelectedSessionID, s.ID)
+	}
+
+	// Enter on a subagent shows that agent's own history.
+	app.Table.SetRows([]ui.Row{{Cells: []string{"sub"}, Data: sub}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Resource != model.ResourceHistory || app.SelectedAgent != sub {
+		t.Fatalf("after enter: resource=%s agent=%v, want history of %s", app.Resource, app.SelectedAgent, sub.ID)
+	}
+	if len(app.SubagentTurns) != 0 {
+		t.Errorf("subagent history should not nest other subagents, got %d", len(app.SubagentTurns))
+	}
+
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceAgents || app.SelectedAgent != nil {
+		t.Errorf("esc from agent history: resource=%s agent=%v, want agents", app.Resource, app.SelectedAgent)
+	}
+	if app.SelectedSessionFilePath != s.FilePath {
+		t.Errorf("esc from agent history should keep the session, got %q", app.SelectedSessionFilePath)
+	}
+
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceSessions || app.SelectedSessionID != "" {
+		t.Errorf("esc from agents: resource=%s session=%q, want sessions with none selected", app.Resource, app.SelectedSessionID)
+	}
+}
+
+func TestToolCallsDrillDownAndBack(t *testing.T) {
+	s := &model.Session{ID: "sess-abc123", FilePath: "/tmp/fake.jsonl"}
+	app := newApp(model.ResourceSessions)
+	app.Table.SetRows([]ui.Row{{Cells: []string{"", s.ShortID(), "topic"}, Data: s}})
+
+	app = updateApp(app, keyMsg("t"))
+	if app.Resource != model.ResourceToolCalls || app.SelectedSessionID != s.ID {
+		t.Fatalf("after t: resource=%s session=%q, want tool-calls of %s", app.Resource, app.SelectedSessionID, s.ID)
+	}
+
+	tc := &model.ToolCall{Name: "Read"}
+	app.Table.SetRows([]ui.Row{{Cells: []string{"", "", "Read"}, Data: ui.ToolCallRow{ToolCall: tc}}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Resource != model.ResourceToolCallDetail || app.SelectedToolCall == nil || app.SelectedToolCall.ToolCall != tc {
+		t.Fatalf("after enter: resource=%s, want tool-call-detail of the selected call", app.Resource)
+	}
+
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceToolCalls {
+		t.Errorf("esc from detail: resource=%s, want tool-calls", app.Resource)
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceSessions || app.SelectedSessionID != "" {
+		t.Errorf("esc from tool-calls: resource=%s session=%q, want sessions", app.Resource, app.SelectedSessionID)
+	}
+}
+
+func TestToolCallsFromAgentsReturnsToAgents(t *testing.T) {
+	s := &model.Session{ID: "sess-abc123"}
+	app := newApp(model.ResourceSessions)
+	app.Table.SetRows([]ui.Row{{Cells: []string{"", s.ShortID(), "topic"}, Data: s}})
+	app = updateApp(app, keyMsg("a"))
+	app = updateApp(app, keyMsg("t"))
+	if app.Resource != model.ResourceToolCalls {
+		t.Fatalf("t in agents: resource=%s, want tool-calls", app.Resource)
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceAgents || app.SelectedSessionID != s.ID {
+		t.Errorf("esc: resource=%s session=%q, want agents of %s", app.Resource, app.SelectedSessionID, s.ID)
+	}
+}
+
+func TestSortKeysCycleAndPersist(t *testing.T) {
+	app := newApp(model.ResourceSessions)
+	app.Table.Sortable = []string{"TURNS", "COST"}
+
+	app = updateApp(app, keyMsg("s"))
+	if got := app.SortFor(model.ResourceSessions); got != (ui.SortState{Column: "TURNS", Desc: true}) {
+		t.Errorf("s: sort = %+v, want TURNS descending", got)
+	}
+	app = updateApp(app, keyMsg("S"))
+	if got := app.SortFor(model.ResourceSessions); got != (ui.SortState{Column: "TURNS"}) {
+		t.Errorf("S: sort = %+v, want TURNS ascending", got)
+	}
+	app = updateApp(app, keyMsg("s"))
+	app = updateApp(app, keyMsg("s"))
+	if got := app.SortFor(model.ResourceSessions); got != (ui.SortState{}) {
+		t.Errorf("cycling past the last column should restore provider order, got %+v", got)
+	}
+
+	app = updateApp(app, keyMsg("s"))
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.SortFor(model.ResourceSessions).Column != "TURNS" {
+		t.Error("sort should be remembered after leaving the view")
+	}
+	if app.SortFor(model.ResourceProjects).Column != "" {
+		t.Error("sort should be per resource")
+	}
+}
+
+func TestAgentsKeyIgnoredOutsideSessions(t *testing.T) {
+	app := newApp(model.ResourceProjects)
+	app.Table.SetRows(projectRows(1))
+	app = updateApp(app, keyMsg("a"))
+	if app.Resource != model.ResourceProjects {
+		t.Errorf("a in projects should be a no-op, got %s", app.Resource)
+	}
+}
+
 func TestSessionChatIsTableView(t *testing.T) {
 	app := newApp(model.ResourceHistory)
 	app.Width = termWidth
@@ -795,6 +1047,36 @@ func TestSessionChat_NavigateBack_ClearsState(t *testing.T) {
 	}
 }
 
+func TestSessionChatBranchSwitching(t *testing.T) {
+	dp := &mockDP{turns: []model.Turn{
+		{Role: "user", Text: "q", UUID: "u1"},
+		{Role: "assistant", Text: "a", UUID: "a1", ParentUUID: "u1"},
+		{Role: "user", Text: "old", UUID: "u2", ParentUUID: "a1"},
+		{Role: "user", Text: "new", UUID: "u3", ParentUUID: "a1"},
+	}}
+	s := &model.Session{ID: "sess-abc123", FilePath: "/tmp/fake.jsonl"}
+	app := ui.NewAppModel(dp, model.ResourceSessions)
+	app.Width, app.Height = termWidth, termHeight
+	app.Table.SetRows([]ui.Row{{Cells: []string{"", s.ShortID(), "topic", "2", "10", "1k", "1h"}, Data: s}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+
+	last := func() string { return app.ChatItems[len(app.ChatItems)-1].Turn.Text }
+	if app.BranchCount() != 2 || last() != "new" {
+		t.Fatalf("expected 2 branches showing the active one, got %d ending in %q", app.BranchCount(), last())
+	}
+	app = updateApp(app, keyMsg("b"))
+	if last() != "old" {
+		t.Errorf("b should switch to the abandoned branch, got %q", last())
+	}
+	if !strings.Contains(app.Flash.Message, "abandoned") {
+		t.Errorf("expected flash to mark the branch abandoned, got %q", app.Flash.Message)
+	}
+	app = updateApp(app, keyMsg("B"))
+	if last() != "new" {
+		t.Errorf("B should switch back to the active branch, got %q", last())
+	}
+}
+
 func TestSlugGroupDrillDown(t *testing.T) {
 	s1 := &model.Session{ID: "sess-1", Slug: "fizzy-stallman", FilePath: "/tmp/s1.jsonl"}
 	s2 := &model.Session{ID: "sess-2", Slug: "fizzy-stallman", FilePath: "/tmp/s2.jsonl"}
@@ -917,3 +1199,255 @@ func TestPluginItemDetailViewHidesJumpHints(t *testing.T) {
 		t.Error("expected '<m> memories' hint to be hidden in plugin-item-detail view")
 	}
 }
+
+func TestSearchOpenHitAndBack(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := &model.Session{ID: "sess-abc123", FilePath: "/tmp/fake.jsonl"}
+	dp := &mockDP{
+		sessions: []*model.Session{s},
+		turns: []model.Turn{
+			{Role: "user", UUID: "u1", Text: "fix the watcher", Timestamp: ts},
+			{Role: "assistant", UUID: "a1", Text: "the watcher is fixed", Timestamp: ts.Add(time.Minute)},
+			{Role: "user", UUID: "u2", Text: "thanks", Timestamp: ts.Add(2 * time.Minute)},
+		},
+	}
+	app := ui.NewAppModel(dp, model.ResourceSessions)
+	app.Width, app.Height = termWidth, termHeight
+	app.SelectedProjectHash = "proj-1"
+
+	app = updateApp(app, keyMsg("F"))
+	if app.Resource != model.ResourceSearch {
+		t.Fatalf("after F: resource=%s, want search", app.Resource)
+	}
+	for _, k := range "watcher" {
+		app = updateApp(app, keyMsg(string(k)))
+	}
+	if app.SearchQuery != "watcher" {
+		t.Errorf("SearchQuery = %q, want it to follow typing", app.SearchQuery)
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+
+	hit := search.Hit{
+		Doc:   search.Doc{Source: search.Source{ProjectHash: "proj-2", SessionID: s.ID}, TurnUUID: "a1", Timestamp: ts.Add(time.Minute)},
+		Terms: []string{"watcher"},
+	}
+	app.Table.SetRows([]ui.Row{{Cells: []string{"", "proj-2"}, Data: hit}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter})
+	if app.Resource != model.ResourceHistory || app.SelectedSessionID != s.ID || app.SelectedProjectHash != "proj-2" {
+		t.Fatalf("after enter: resource=%s session=%q project=%q, want history of the hit",
+			app.Resource, app.SelectedSessionID, app.SelectedProjectHash)
+	}
+	if app.ChatFollow {
+		t.Error("a search hit should not follow the tail")
+	}
+	key := app.TakeSearchJump()
+	if i := ui.FindChatItem(app.ChatItems, "a1", time.Time{}); i < 0 || key != ui.ChatItemKey(app.ChatItems[i]) {
+		t.Errorf("TakeSearchJump = %q, want the hit's item", key)
+	}
+	if app.TakeSearchJump() != "" {
+		t.Error("the jump should be taken once")
+	}
+
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceSearch || app.SelectedSessionID != "" || app.SearchQuery != "watcher" {
+		t.Fatalf("esc from history: resource=%s session=%q query=%q, want search with the query kept",
+			app.Resource, app.SelectedSessionID, app.SearchQuery)
+	}
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceSessions || app.SelectedProjectHash != "proj-1" {
+		t.Errorf("esc from search: resource=%s project=%q, want the sessions view it was opened from",
+			app.Resource, app.SelectedProjectHash)
+	}
+}
+
+func TestSearchInputEscWithoutQueryLeaves(t *testing.T) {
+	app := newApp(model.ResourceProjects)
+	app = updateApp(app, keyMsg("F"))
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEsc})
+	if app.Resource != model.ResourceProjects {
+		t.Errorf("esc with an empty query: resource=%s, want projects", app.Resource)
+	}
+}
+
+func TestSearchKeyBlockedInJumpTargets(t *testing.T) {
+	for _, rt := range []model.ResourceType{model.ResourcePlugins, model.ResourceMemory, model.ResourceHistoryDetail} {
+		app := updateApp(newApp(rt), keyMsg("F"))
+		if app.Resource != rt {
+			t.Errorf("F in %s switched to %s", rt, app.Resource)
+		}
+	}
+}
+
+// yank presses the keys of a yank and returns the app after the copy
+// finished, with what was copied.
+func yank(t *testing.T, app ui.AppModel, keys ...string) (ui.AppModel, string) {
+	t.Helper()
+	var copied string
+	app.Clipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	var cmd tea.Cmd
+	for _, k := range keys {
+		msg := keyMsg(k)
+		if k == "esc" {
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		}
+		var m tea.Model
+		m, cmd = app.Update(msg)
+		app = m.(ui.AppModel)
+	}
+	for _, msg := range runCmd(cmd) {
+		if y, ok := msg.(ui.YankedMsg); ok {
+			app = updateApp(app, y)
+		}
+	}
+	return app, copied
+}
+
+// runCmd runs cmd and the commands of a batch it returns, skipping ticks.
+func runCmd(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	select {
+	case msg := <-done:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var out []tea.Msg
+			for _, c := range batch {
+				out = append(out, runCmd(c)...)
+			}
+			return out
+		}
+		return []tea.Msg{msg}
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestYankSessionResumeCommand(t *testing.T) {
+	dp := &mockDP{projects: []*model.Project{{Hash: "h1", Path: "/work/my app"}}}
+	app := ui.NewAppModel(dp, model.ResourceSessions)
+	app.Width, app.Height = termWidth, termHeight
+	s := &model.Session{ID: "abc-123", ProjectHash: "h1", FilePath: "/tmp/abc-123.jsonl"}
+	app.Table.SetRows([]ui.Row{{Cells: []string{"abc-123"}, Data: s}})
+
+	m, _ := app.Update(keyMsg("y"))
+	if view := m.(ui.AppModel).View(); !strings.Contains(view, "r resume command") {
+		t.Fatalf("yank prompt missing from status bar:\n%s", view)
+	}
+
+	app, copied := yank(t, app, "y", "r")
+	if want := "cd '/work/my app' && claude -r abc-123"; copied != want {
+		t.Errorf("copied %q, want %q", copied, want)
+	}
+	if !strings.Contains(app.Flash.Message, "copied resume command") {
+		t.Errorf("flash = %q, want a copy confirmation", app.Flash.Message)
+	}
+
+	_, copied = yank(t, app, "y", "y")
+	if copied != "abc-123" {
+		t.Errorf("y y copied %q, want the session ID", copied)
+	}
+}
+
+func TestYankToolCallInputAndEscCancels(t *testing.T) {
+	app := newApp(model.ResourceToolCalls)
+	tc := &model.ToolCall{Name: "Read", Input: []byte(`{"file_path":"/a/b.go"}`)}
+	app.Table.SetRows([]ui.Row{{Cells: []string{"Read"}, Data: ui.ToolCallRow{ToolCall: tc}}})
+
+	app, copied := yank(t, app, "y", "esc")
+	if copied != "" {
+		t.Errorf("esc copied %q", copied)
+	}
+	if app.Resource != model.ResourceToolCalls {
+		t.Errorf("esc in the yank prompt navigated to %s", app.Resource)
+	}
+
+	_, copied = yank(t, app, "y", "p")
+	if copied != "/a/b.go" {
+		t.Errorf("y p copied %q, want the file path", copied)
+	}
+	_, copied = yank(t, app, "y", "j")
+	if copied != "{\n  \"file_path\": \"/a/b.go\"\n}" {
+		t.Errorf("y j copied %q, want indented input JSON", copied)
+	}
+}
+
+func TestYankWithNothingToCopyFlashes(t *testing.T) {
+	app := newApp(model.ResourceProjects)
+	app, copied := yank(t, app, "y")
+	if copied != "" || !strings.Contains(app.Flash.Message, "nothing to copy") {
+		t.Errorf("copied %q, flash %q; want a nothing-to-copy flash", copied, app.Flash.Message)
+	}
+}
+
+// openWith presses e with a fake Exec and returns the command it ran, the
+// content of the file it opened, and the app after the command finished.
+func openWith(t *testing.T, app ui.AppModel) (args []string, content string, after ui.AppModel) {
+	t.Helper()
+	app.Exec = func(cmd *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+		args = cmd.Args
+		data, err := os.ReadFile(cmd.Args[len(cmd.Args)-1])
+		if err != nil {
+			t.Fatalf("opened file: %v", err)
+		}
+		content = string(data)
+		return func() tea.Msg { return fn(nil) }
+	}
+	m, cmd := app.Update(keyMsg("e"))
+	after = m.(ui.AppModel)
+	for _, msg := range runCmd(cmd) {
+		if closed, ok := msg.(ui.EditorClosedMsg); ok {
+			after = updateApp(after, closed)
+		}
+	}
+	return args, content, after
+}
+
+func TestOpenEditLineInEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nvim -u NONE")
+	path := filepath.Join(t.TempDir(), "a.go")
+	if err := os.WriteFile(path, []byte("package a\n\nfunc f() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	app := newApp(model.ResourceToolCalls)
+	tc := &model.ToolCall{Name: "Edit", Input: []byte(`{"file_path":"` + path + `","old_string":"x","new_string":"func f() {}"}`)}
+	app.Table.SetRows([]ui.Row{{Cells: []string{"Edit"}, Data: ui.ToolCallRow{ToolCall: tc}}})
+
+	args, _, _ := openWith(t, app)
+	want := []string{"nvim", "-u", "NONE", "+3", path}
+	if strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("ran %q, want %q", args, want)
+	}
+}
+
+func TestOpenToolResultInPagerRemovesTempFile(t *testing.T) {
+	t.Setenv("PAGER", "less -R")
+	app := newApp(model.ResourceToolCalls)
+	tc := &model.ToolCall{Name: "Bash", Input: []byte(`{"command":"ls"}`), Result: []byte(`"` + strings.Repeat("a very long line ", 50) + `"`)}
+	app.Table.SetRows([]ui.Row{{Cells: []string{"Bash"}, Data: ui.ToolCallRow{ToolCall: tc}}})
+	app = updateApp(app, tea.KeyMsg{Type: tea.KeyEnter}) // tool-call-detail
+
+	args, content, _ := openWith(t, app)
+	if len(args) != 3 || args[0] != "less" || args[1] != "-R" {
+		t.Fatalf("ran %q, want less -R <file>", args)
+	}
+	if !strings.Contains(content, strings.Repeat("a very long line ", 50)) || !strings.Contains(content, `"command": "ls"`) {
+		t.Errorf("pager content lacks the unwrapped result or input:\n%s", content)
+	}
+	if _, err := os.Stat(args[2]); !os.IsNotExist(err) {
+		t.Errorf("temporary file %s left behind", args[2])
+	}
+}
+
+func TestOpenWithNothingToOpenFlashes(t *testing.T) {
+	app := newApp(model.ResourceToolCalls)
+	_, _, app = openWith(t, app)
+	if !strings.Contains(app.Flash.Message, "nothing to open") {
+		t.Errorf("flash = %q, want nothing to open", app.Flash.Message)
+	}
+}
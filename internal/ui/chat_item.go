@@ -10,11 +10,13 @@ import (
 	"github.com/Curt-Park/claudeview/internal/stringutil"
 )
 
-// ToolCallRow is the Data payload for an expanded tool-call sub-row in the history table.
+// ToolCallRow is the Data payload for an expanded tool-call sub-row in the
+// history table and for a row of the flat tool-calls table.
 type ToolCallRow struct {
 	ToolCall    *model.ToolCall
 	ParentTurn  model.Turn
-	ChatItemKey string // key of the parent ChatItem
+	ChatItemKey string       // key of the parent ChatItem (history sub-rows only)
+	Agent       *model.Agent // agent that made the call (tool-calls table only)
 }
 
 // ChatItem represents a single selectable item in the chat table.
@@ -275,7 +277,7 @@ func (c ChatItem) ModelTokenLabel() string {
 	if c.IsDivider {
 		return ""
 	}
-	type tokenPair struct{ in, cache, out int }
+	type tokenPair struct{ in, write, read, out int }
 	byModel := make(map[string]tokenPair)
 	addTurn := func(t model.Turn) {
 		if t.ModelName == "" {
@@ -283,7 +285,8 @@ func (c ChatItem) ModelTokenLabel() string {
 		}
 		p := byModel[t.ModelName]
 		p.in += t.InputTokens
-		p.cache += t.CacheReadTokens
+		p.write += t.CacheWriteTokens
+		p.read += t.CacheReadTokens
 		p.out += t.OutputTokens
 		byModel[t.ModelName] = p
 	}
@@ -302,7 +305,7 @@ func (c ChatItem) ModelTokenLabel() string {
 	var parts []string
 	for _, m := range models {
 		p := byModel[m]
-		parts = append(parts, model.ShortModelName(m)+":"+model.FormatTokenInOutCache(p.in, p.cache, p.out))
+		parts = append(parts, model.ShortModelName(m)+":"+model.FormatTokenInOutCache(p.in, p.write, p.read, p.out))
 	}
 	return strings.Join(parts, " ")
 }
@@ -328,72 +331,66 @@ func (c ChatItem) TimeLabel(prev *ChatItem) string {
 // BuildChatItems flattens main turns and subagent turns into a single
 // selectable list. Consecutive assistant turns where the 2nd+ has no text
 // and no thinking are grouped into the preceding ChatItem's ExtraTurns.
-// For each assistant turn's Task tool call, subagent turns are interleaved.
-func BuildChatItems(turns []model.Turn, subagentTurns [][]model.Turn, subagentTypes []model.AgentType) []ChatItem {
+// Each Agent/Task tool call is followed by the subagent it spawned, matched by
+// agent ID (subagentIDs, parallel to subagentTurns) or, for calls without one,
+// by position. Subagents no call claimed are listed at the end.
+func BuildChatItems(turns []model.Turn, subagentTurns [][]model.Turn, subagentIDs []string) []ChatItem {
+	links, unmatched := subagentLinks(turns, subagentTurns, subagentIDs)
+	return buildChatItems(turns, subagentTurns, links, unmatched)
+}
+
+// subagentLinks maps each Agent/Task call in turns to the index of the
+// subagent transcript it spawned, and returns the transcripts left unclaimed.
+func subagentLinks(turns []model.Turn, subagentTurns [][]model.Turn, subagentIDs []string) (map[*model.ToolCall]int, []int) {
+	ids := make([]string, len(subagentTurns))
+	copy(ids, subagentIDs)
+	byCall, unmatched := model.MatchSubagents(model.SubagentCalls(turns), ids)
+	links := make(map[*model.ToolCall]int, len(byCall))
+	k := 0
+	for _, t := range turns {
+		if t.Role != "assistant" {
+			continue
+		}
+		for _, tc := range t.ToolCalls {
+			if !model.IsAgentCall(tc.Name) {
+				continue
+			}
+			if byCall[k] >= 0 {
+				links[tc] = byCall[k]
+			}
+			k++
+		}
+	}
+	return links, unmatched
+}
+
+// buildChatItems implements BuildChatItems for a precomputed call→subagent matching.
+func buildChatItems(turns []model.Turn, subagentTurns [][]model.Turn, links map[*model.ToolCall]int, unmatched []int) []ChatItem {
 	var items []ChatItem
-	subIdx := 0
 
-	// interleaveSubagents appends one collapsed ChatItem per subagent for each Agent/Task tool call.
-	// The first assistant turn becomes the primary Turn; remaining assistant turns go into ExtraTurns.
-	interleaveSubagents := func(toolCalls []*model.ToolCall) {
-		// Pre-count eligible agent calls in this batch.
-		batchSize := 0
-		for _, tc := range toolCalls {
-			if (tc.Name == "Task" || tc.Name == "Agent") && subIdx+batchSize < len(subagentTurns) {
-				batchSize++
+	// appendSubagents appends one collapsed ChatItem per subagent, connected as one batch.
+	appendSubagents := func(idxs []int, types []model.AgentType) {
+		for pos, idx := range idxs {
+			connector := "├─"
+			if pos == len(idxs)-1 {
+				connector = "└─"
+			}
+			if item, ok := subagentItem(subagentTurns[idx], idx, types[pos], connector); ok {
+				items = append(items, item)
 			}
 		}
-		batchPos := 0
+	}
+	// interleaveSubagents appends the subagents spawned by one turn's Agent/Task calls.
+	interleaveSubagents := func(toolCalls []*model.ToolCall) {
+		var idxs []int
+		var types []model.AgentType
 		for _, tc := range toolCalls {
-			if (tc.Name == "Task" || tc.Name == "Agent") && subIdx < len(subagentTurns) {
-				agentType := model.AgentTypeGeneral
-				if subIdx < len(subagentTypes) {
-					agentType = subagentTypes[subIdx]
-				}
-				connector := "├─"
-				if batchPos == batchSize-1 {
-					connector = "└─"
-				}
-				batchPos++
-
-				// Collect assistant turns, preferring one with text or tool calls as primary.
-				var allAssistant []model.Turn
-				for _, st := range subagentTurns[subIdx] {
-					if st.Role != "assistant" {
-						continue
-					}
-					allAssistant = append(allAssistant, st)
-				}
-				var first *model.Turn
-				var extra []model.Turn
-				for i := range allAssistant {
-					if first == nil {
-						if allAssistant[i].Text != "" || len(allAssistant[i].ToolCalls) > 0 {
-							first = &allAssistant[i]
-						} else {
-							extra = append(extra, allAssistant[i])
-						}
-					} else {
-						extra = append(extra, allAssistant[i])
-					}
-				}
-				if first == nil && len(allAssistant) > 0 {
-					first = &allAssistant[0]
-					extra = allAssistant[1:]
-				}
-				if first != nil {
-					items = append(items, ChatItem{
-						Turn:          *first,
-						ExtraTurns:    extra,
-						IsSubagent:    true,
-						AgentType:     agentType,
-						SubagentIdx:   subIdx,
-						TreeConnector: connector,
-					})
-				}
-				subIdx++
+			if idx, ok := links[tc]; ok {
+				idxs = append(idxs, idx)
+				types = append(types, model.AgentTypeFromInput(tc.Input))
 			}
 		}
+		appendSubagents(idxs, types)
 	}
 
 	for _, turn := range turns {
@@ -413,47 +410,151 @@ func BuildChatItems(turns []model.Turn, subagentTurns [][]model.Turn, subagentTy
 			interleaveSubagents(turn.ToolCalls)
 		}
 	}
+
+	if len(unmatched) > 0 {
+		items = append(items, ChatItem{IsDivider: true, DividerLabel: "── unmatched sub-agents ──", SubagentIdx: -1})
+		types := make([]model.AgentType, len(unmatched))
+		for i := range types {
+			types[i] = model.AgentTypeGeneral
+		}
+		appendSubagents(unmatched, types)
+	}
 	return items
 }
 
+// subagentItem collapses a subagent's assistant turns into one ChatItem,
+// preferring a turn with text or tool calls as the primary Turn.
+// Returns false when the subagent has no assistant turns yet.
+func subagentItem(subTurns []model.Turn, idx int, agentType model.AgentType, connector string) (ChatItem, bool) {
+	var allAssistant []model.Turn
+	for _, st := range subTurns {
+		if st.Role == "assistant" {
+			allAssistant = append(allAssistant, st)
+		}
+	}
+	if len(allAssistant) == 0 {
+		return ChatItem{}, false
+	}
+	primary := 0
+	for i, t := range allAssistant {
+		if t.Text != "" || len(t.ToolCalls) > 0 {
+			primary = i
+			break
+		}
+	}
+	// Content-less turns before the primary one go first in ExtraTurns.
+	extra := make([]model.Turn, 0, len(allAssistant)-1)
+	extra = append(extra, allAssistant[:primary]...)
+	extra = append(extra, allAssistant[primary+1:]...)
+	return ChatItem{
+		Turn:          allAssistant[primary],
+		ExtraTurns:    extra,
+		IsSubagent:    true,
+		AgentType:     agentType,
+		SubagentIdx:   idx,
+		TreeConnector: connector,
+	}, true
+}
+
+// BuildBranchChatItems builds ChatItems for branch i of tree (the active
+// branch when i is out of range). Each branch point is followed by a divider
+// naming the continuation shown, e.g. "── ⑂ branch 1/2 · abandoned ──".
+// Subagents are matched against the whole tree, so those spawned on other
+// branches are hidden rather than reported as unmatched.
+func BuildBranchChatItems(tree *model.ConversationTree, i int, subagentTurns [][]model.Turn, subagentIDs []string) []ChatItem {
+	if i < 0 || i >= tree.BranchCount() {
+		i = tree.ActiveBranch()
+	}
+	turns, forks := tree.Branch(i)
+	links, unmatched := subagentLinks(tree.Turns(), subagentTurns, subagentIDs)
+	items := buildChatItems(turns, subagentTurns, links, unmatched)
+	if len(forks) == 0 {
+		return items
+	}
+	labels := make(map[string]string, len(forks))
+	for _, f := range forks {
+		label := fmt.Sprintf("── ⑂ branch %d/%d", f.Choice+1, f.Count)
+		if f.Abandoned {
+			label += " · abandoned"
+		}
+		labels[f.UUID] = label + " ──"
+	}
+	forkLabel := func(c ChatItem) string {
+		if l, ok := labels[c.Turn.UUID]; ok && c.Turn.UUID != "" {
+			return l
+		}
+		for _, et := range c.ExtraTurns {
+			if l, ok := labels[et.UUID]; ok && et.UUID != "" {
+				return l
+			}
+		}
+		return ""
+	}
+	divider := func(label string) ChatItem {
+		return ChatItem{IsDivider: true, DividerLabel: label, SubagentIdx: -1}
+	}
+
+	// Dividers go before the next main-conversation item so sub-agent rows
+	// stay attached to the turn that spawned them.
+	out := make([]ChatItem, 0, len(items)+len(forks))
+	var pending string
+	for _, item := range items {
+		if pending != "" && !item.IsSubagent {
+			out = append(out, divider(pending))
+			pending = ""
+		}
+		out = append(out, item)
+		if !item.IsSubagent {
+			if l := forkLabel(item); l != "" {
+				pending = l
+			}
+		}
+	}
+	if pending != "" {
+		out = append(out, divider(pending))
+	}
+	return out
+}
+
 // BuildMergedChatItems merges turns from multiple sessions into a single ChatItem list
 // with divider rows at session boundaries.
 // sessionIDs provides the short session ID for each session (used in divider labels).
-// For a single session, it delegates to BuildChatItems.
+// Each session shows the active branch of its conversation tree.
+// For a single session, it delegates to BuildBranchChatItems.
 func BuildMergedChatItems(
 	sessionTurns [][]model.Turn,
 	subTurnsBySession [][][]model.Turn,
-	subTypesBySession [][]model.AgentType,
+	subIDsBySession [][]string,
 	sessionIDs []string,
 ) []ChatItem {
 	if len(sessionTurns) <= 1 {
 		var subTurns [][]model.Turn
-		var subTypes []model.AgentType
+		var subIDs []string
 		if len(subTurnsBySession) > 0 {
 			subTurns = subTurnsBySession[0]
 		}
-		if len(subTypesBySession) > 0 {
-			subTypes = subTypesBySession[0]
+		if len(subIDsBySession) > 0 {
+			subIDs = subIDsBySession[0]
 		}
 		var turns []model.Turn
 		if len(sessionTurns) > 0 {
 			turns = sessionTurns[0]
 		}
-		return BuildChatItems(turns, subTurns, subTypes)
+		return BuildBranchChatItems(model.BuildConversationTree(turns), -1, subTurns, subIDs)
 	}
 
 	total := len(sessionTurns)
 	var all []ChatItem
 	for i, turns := range sessionTurns {
 		var subTurns [][]model.Turn
-		var subTypes []model.AgentType
+		var subIDs []string
 		if i < len(subTurnsBySession) {
 			subTurns = subTurnsBySession[i]
 		}
-		if i < len(subTypesBySession) {
-			subTypes = subTypesBySession[i]
+		if i < len(subIDsBySession) {
+			subIDs = subIDsBySession[i]
 		}
-		items := BuildChatItems(turns, subTurns, subTypes)
+		items := BuildBranchChatItems(model.BuildConversationTree(turns), -1, subTurns, subIDs)
 		if i > 0 {
 			label := fmt.Sprintf("── session %d/%d", i+1, total)
 			if i < len(sessionIDs) && sessionIDs[i] != "" {
@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -114,8 +117,8 @@ func TestDividerChatItem_Labels(t *testing.T) {
 func TestBuildChatItems_SubagentCollapsed(t *testing.T) {
 	mainTurns := []model.Turn{
 		{Role: "user", Text: "hello"},
-		{Role: "assistant", Text: "delegating", ToolCalls: []*model.ToolCall{{Name: "Task"}}},
-		{Role: "assistant", Text: "delegating again", ToolCalls: []*model.ToolCall{{Name: "Task"}}},
+		{Role: "assistant", Text: "delegating", ToolCalls: []*model.ToolCall{{Name: "Task", Input: json.RawMessage(`{"subagent_type":"Explore"}`)}}},
+		{Role: "assistant", Text: "delegating again", ToolCalls: []*model.ToolCall{{Name: "Task", Input: json.RawMessage(`{"subagent_type":"Plan"}`)}}},
 	}
 	sub0 := []model.Turn{
 		{Role: "assistant", Text: "sub0-turn1"},
@@ -124,8 +127,7 @@ func TestBuildChatItems_SubagentCollapsed(t *testing.T) {
 	sub1 := []model.Turn{
 		{Role: "assistant", Text: "sub1-turn1"},
 	}
-	subTypes := []model.AgentType{model.AgentTypeExplore, model.AgentTypePlan}
-	items := BuildChatItems(mainTurns, [][]model.Turn{sub0, sub1}, subTypes)
+	items := BuildChatItems(mainTurns, [][]model.Turn{sub0, sub1}, nil)
 
 	// Verify non-subagent items have SubagentIdx = -1
 	for _, item := range items {
@@ -180,7 +182,7 @@ func TestBuildChatItems_AgentToolName(t *testing.T) {
 		{Role: "assistant", Text: "agent-turn1"},
 		{Role: "assistant", Text: "agent-turn2"},
 	}
-	items := BuildChatItems(mainTurns, [][]model.Turn{sub}, []model.AgentType{model.AgentTypeExplore})
+	items := BuildChatItems(mainTurns, [][]model.Turn{sub}, nil)
 
 	var subItems []ChatItem
 	for _, item := range items {
@@ -213,7 +215,7 @@ func TestBuildChatItems_SubagentSkipsContentlessPrimary(t *testing.T) {
 		{Role: "assistant", Text: "found the files", ToolCalls: []*model.ToolCall{{Name: "Grep"}}},
 		{Role: "assistant", Text: "done"},
 	}
-	items := BuildChatItems(mainTurns, [][]model.Turn{sub}, []model.AgentType{model.AgentTypeExplore})
+	items := BuildChatItems(mainTurns, [][]model.Turn{sub}, nil)
 
 	var subItem *ChatItem
 	for i := range items {
@@ -362,7 +364,7 @@ func TestBuildChatItems_TreeConnectors_Single(t *testing.T) {
 		{Role: "assistant", Text: "delegating", ToolCalls: []*model.ToolCall{{Name: "Agent"}}},
 	}
 	sub := []model.Turn{{Role: "assistant", Text: "done"}}
-	items := BuildChatItems(mainTurns, [][]model.Turn{sub}, []model.AgentType{model.AgentTypeExplore})
+	items := BuildChatItems(mainTurns, [][]model.Turn{sub}, nil)
 
 	var subItems []ChatItem
 	for _, it := range items {
@@ -392,7 +394,7 @@ func TestBuildChatItems_TreeConnectors_Multiple(t *testing.T) {
 	items := BuildChatItems(
 		mainTurns,
 		[][]model.Turn{sub0, sub1},
-		[]model.AgentType{model.AgentTypeExplore, model.AgentTypePlan},
+		nil,
 	)
 
 	var subItems []ChatItem
@@ -424,7 +426,7 @@ func TestBuildChatItems_TreeConnectors_SeparateTurns(t *testing.T) {
 	items := BuildChatItems(
 		mainTurns,
 		[][]model.Turn{sub0, sub1},
-		[]model.AgentType{model.AgentTypeExplore, model.AgentTypePlan},
+		nil,
 	)
 
 	var subItems []ChatItem
@@ -454,3 +456,70 @@ func TestTimeLabel_NegativeDuration(t *testing.T) {
 		t.Errorf("expected '-' for negative duration, got %q", got)
 	}
 }
+
+func TestBuildBranchChatItems_ForkDividers(t *testing.T) {
+	turns := []model.Turn{
+		{Role: "user", Text: "q", UUID: "u1"},
+		{Role: "assistant", Text: "a", UUID: "a1", ParentUUID: "u1"},
+		{Role: "user", Text: "old", UUID: "u2", ParentUUID: "a1"},
+		{Role: "user", Text: "new", UUID: "u3", ParentUUID: "a1"},
+	}
+	tree := model.BuildConversationTree(turns)
+
+	items := BuildBranchChatItems(tree, -1, nil, nil)
+	if len(items) != 4 {
+		t.Fatalf("expected 4 items (q, a, divider, new), got %d", len(items))
+	}
+	if !items[2].IsDivider || items[2].DividerLabel != "── ⑂ branch 2/2 ──" {
+		t.Errorf("expected active fork divider at index 2, got %+v", items[2])
+	}
+	if items[3].Turn.Text != "new" {
+		t.Errorf("active branch should end with the edited prompt, got %q", items[3].Turn.Text)
+	}
+
+	items = BuildBranchChatItems(tree, 0, nil, nil)
+	if items[2].DividerLabel != "── ⑂ branch 1/2 · abandoned ──" || items[3].Turn.Text != "old" {
+		t.Errorf("abandoned branch: got divider %q and last turn %q", items[2].DividerLabel, items[3].Turn.Text)
+	}
+}
+
+func TestBuildChatItems_MatchesSubagentsByID(t *testing.T) {
+	// Two parallel agents whose transcripts sort in the opposite order, plus
+	// one transcript no call claims.
+	mainTurns := []model.Turn{
+		{Role: "user", Text: "go"},
+		{Role: "assistant", Text: "delegating", ToolCalls: []*model.ToolCall{
+			{Name: "Agent", Input: json.RawMessage(`{"subagent_type":"Explore"}`), SubagentID: "aaa"},
+			{Name: "Agent", Input: json.RawMessage(`{"subagent_type":"Plan"}`), SubagentID: "bbb"},
+		}},
+	}
+	subs := [][]model.Turn{
+		{{Role: "assistant", Text: "plan"}},
+		{{Role: "assistant", Text: "stray"}},
+		{{Role: "assistant", Text: "explore"}},
+	}
+	items := BuildChatItems(mainTurns, subs, []string{"bbb", "ccc", "aaa"})
+
+	var got []string
+	for _, it := range items {
+		switch {
+		case it.IsDivider:
+			got = append(got, it.DividerLabel)
+		case it.IsSubagent:
+			got = append(got, fmt.Sprintf("%s %s %s %d", it.TreeConnector, it.AgentType, it.Turn.Text, it.SubagentIdx))
+		default:
+			got = append(got, it.Turn.Text)
+		}
+	}
+	want := []string{
+		"go",
+		"delegating",
+		"├─ Explore explore 2",
+		"└─ Plan plan 0",
+		"── unmatched sub-agents ──",
+		"└─ general-purpose stray 1",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("items = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// contentSearchState is the in-content search of a content view: `/` edits
+// the query, n/N step through its matches.
+type contentSearchState struct {
+	query   string
+	current int // index of the match the view is on, counted across all lines
+	from    int // ContentOffset when the input opened; typing searches from there
+}
+
+// openContentSearch opens the filter bar as the search input of a content view.
+func (m *AppModel) openContentSearch() {
+	m.inFilter = true
+	m.Filter.Activate()
+	m.contentSearch = contentSearchState{from: m.ContentOffset}
+	m.refreshMenu()
+}
+
+// updateContentSearch edits the in-content search query. Every key searches
+// again and scrolls to the first match at or below where the input opened.
+func (m AppModel) updateContentSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.inFilter = false
+		m.Filter.Deactivate()
+		m.Filter.Input = ""
+		m.ContentOffset = m.contentSearch.from
+		m.contentSearch = contentSearchState{}
+	case "enter":
+		m.inFilter = false
+		m.Filter.Deactivate()
+		if len(m.contentMatchLines()) == 0 {
+			m.Filter.Input = ""
+			m.contentSearch = contentSearchState{}
+		}
+	case "backspace":
+		m.Filter.Backspace()
+		m.setContentSearch(m.Filter.Input)
+	default:
+		if len(msg.Runes) == 1 {
+			m.Filter.AddChar(msg.Runes[0])
+			m.setContentSearch(m.Filter.Input)
+		}
+	}
+	m.refreshMenu()
+	return m, nil
+}
+
+// setContentSearch searches the content for q from where the input opened.
+func (m *AppModel) setContentSearch(q string) {
+	m.contentSearch.query = q
+	m.ContentOffset = m.contentSearch.from
+	lines := m.contentMatchLines()
+	next := 0
+	for i, line := range lines {
+		if line >= m.contentSearch.from {
+			next = i
+			break
+		}
+	}
+	m.gotoContentMatch(next, lines)
+}
+
+// stepContentMatch moves to the next (dir 1) or previous (dir -1) match,
+// wrapping around the content.
+func (m *AppModel) stepContentMatch(dir int) {
+	lines := m.contentMatchLines()
+	if len(lines) == 0 {
+		return
+	}
+	m.gotoContentMatch((m.contentSearch.current+dir+len(lines))%len(lines), lines)
+}
+
+// gotoContentMatch makes match i current and scrolls it to the middle of the
+// view. lines holds the line of every match.
+func (m *AppModel) gotoContentMatch(i int, lines []int) {
+	m.contentSearch.current = i
+	if i >= len(lines) {
+		return
+	}
+	m.ContentOffset = min(max(lines[i]-m.contentHeight()/2, 0), m.contentMaxOffset())
+}
+
+// clearContentSearch ends the in-content search; called when leaving a view.
+func (m *AppModel) clearContentSearch() {
+	m.contentSearch = contentSearchState{}
+}
+
+// contentCache keeps the rendered lines of a content view, and the matches
+// of the in-content search in them, so typing a query or stepping through
+// matches does not render markdown and highlighted code again each time.
+type contentCache struct {
+	key   contentKey
+	file  string // file the content was read from, "" when none
+	lines []string
+	query string
+	match []int // line of every match of query in lines
+}
+
+// contentKey identifies what a content view renders: the view, the width,
+// the selected item, which refreshes replace rather than modify, and the
+// size and mtime of a memory or plugin item file, which change in place.
+type contentKey struct {
+	resource model.ResourceType
+	width    int
+	item     any // selected plugin item, memory or tool call; first chat item
+	index    int // selected chat item
+	n        int // number of chat items or session tool calls
+	size     int64
+	modTime  time.Time
+}
+
+// contentKey returns the key of the current content view.
+func (m AppModel) contentKey() contentKey {
+	k := contentKey{resource: m.Resource, width: m.contentWidth()}
+	switch m.Resource {
+	case model.ResourcePluginItemDetail:
+		k.item = m.SelectedPluginItem
+	case model.ResourceMemoryDetail:
+		k.item = m.SelectedMemory
+	case model.ResourceHistoryDetail:
+		if len(m.ChatItems) > 0 {
+			k.item = &m.ChatItems[0]
+		}
+		k.index, k.n = m.SelectedChatItem, len(m.ChatItems)
+	case model.ResourceToolCallDetail:
+		k.item, k.n = m.SelectedToolCall, len(m.sessionToolCalls)
+	}
+	if fi, err := os.Stat(m.contentFile()); err == nil {
+		k.size, k.modTime = fi.Size(), fi.ModTime()
+	}
+	return k
+}
+
+// contentFile returns the file the current memory or plugin item is read
+// from, "" for pre-filled content and other views. A plugin item's file is
+// looked up once per item and then taken from the cache.
+func (m AppModel) contentFile() string {
+	switch m.Resource {
+	case model.ResourceMemoryDetail:
+		if mem := m.SelectedMemory; mem != nil && mem.Content == "" {
+			return mem.Path
+		}
+	case model.ResourcePluginItemDetail:
+		item := m.SelectedPluginItem
+		if item == nil {
+			return ""
+		}
+		if c := m.content; c != nil && c.lines != nil && c.key.item == any(item) {
+			return c.file
+		}
+		path, _ := model.PluginItemFile(item)
+		return path
+	}
+	return ""
+}
+
+// contentViewLines returns the rendered lines of the current content view,
+// rendering only when the view, its width, its item or the item's file
+// changed. The caller must not modify them.
+func (m AppModel) contentViewLines() []string {
+	c := m.content
+	if c == nil {
+		return contentLines(m.renderContentForResource())
+	}
+	if k := m.contentKey(); c.lines == nil || c.key != k {
+		file := m.contentFile()
+		*c = contentCache{key: k, file: file, lines: contentLines(m.renderContentForResource())}
+	}
+	return c.lines
+}
+
+// contentMatchLines returns the line of every match of the in-content search
+// in the rendered content, in order; a line with several matches repeats.
+func (m AppModel) contentMatchLines() []int {
+	q := m.contentSearch.query
+	if q == "" {
+		return nil
+	}
+	lines := m.contentViewLines()
+	if c := m.content; c != nil && c.match != nil && c.query == q {
+		return c.match
+	}
+	match := []int{}
+	for i, line := range lines {
+		for range matchSpans(ansi.Strip(line), []string{q}) {
+			match = append(match, i)
+		}
+	}
+	if c := m.content; c != nil {
+		c.query, c.match = q, match
+	}
+	return match
+}
+
+// highlightContent marks the in-content search matches in lines, which start
+// at line from of the content. A line with a match loses its own styling;
+// the current match stands out from the rest.
+func (m AppModel) highlightContent(lines []string, from int) []string {
+	if m.contentSearch.query == "" {
+		return lines
+	}
+	out := make([]string, len(lines))
+	n := 0 // matches before the current line
+	for _, line := range m.contentMatchLines() {
+		if line < from {
+			n++
+		}
+	}
+	for i, line := range lines {
+		plain := ansi.Strip(line)
+		spans := matchSpans(plain, []string{m.contentSearch.query})
+		if len(spans) == 0 {
+			out[i] = line
+			continue
+		}
+		first := n
+		out[i] = renderSpans(plain, spans, StyleNormal, func(j int) lipgloss.Style {
+			if first+j == m.contentSearch.current {
+				return StyleSearchCurrent
+			}
+			return StyleSearchMatch
+		})
+		n += len(spans)
+	}
+	return out
+}
+
+// contentSearchBar returns the filter bar with the match position, e.g.
+// "3/17", or "no matches".
+func (m AppModel) contentSearchBar() FilterModel {
+	f := m.Filter
+	f.Input = m.contentSearch.query
+	if f.Input == "" {
+		return f
+	}
+	if total := len(m.contentMatchLines()); total > 0 {
+		f.Count = fmt.Sprintf("%d/%d", min(m.contentSearch.current, total-1)+1, total)
+	} else {
+		f.Err = "no matches"
+	}
+	return f
+}
+
+// contentLines splits rendered content into lines the way View displays it.
+func contentLines(content string) []string {
+	return strings.Split(strings.TrimRight(content, "\n"), "\n")
+}
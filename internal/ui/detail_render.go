@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/x/ansi"
 
@@ -13,7 +14,7 @@ import (
 // renderTurnBoundary renders a lightweight API-call boundary marker shown between
 // ExtraTurns in both Claude and sub-agent detail views:
 //
-//	── sonnet  06:14  300 tok ──
+//	── sonnet  06:14  300 tok  $0.02 ──
 func renderTurnBoundary(t model.Turn, width int) string {
 	var parts []string
 	if m := model.ShortModelName(t.ModelName); m != "" {
@@ -22,8 +23,11 @@ func renderTurnBoundary(t model.Turn, width int) string {
 	if !t.Timestamp.IsZero() {
 		parts = append(parts, StyleChatTimestamp.Render(t.Timestamp.Format("15:04")))
 	}
-	if t.InputTokens > 0 || t.CacheReadTokens > 0 || t.OutputTokens > 0 {
-		parts = append(parts, StyleChatTokens.Render(model.FormatTokenInOutCache(t.InputTokens, t.CacheReadTokens, t.OutputTokens)+" tok"))
+	if t.InputTokens > 0 || t.CacheWriteTokens > 0 || t.CacheReadTokens > 0 || t.OutputTokens > 0 {
+		parts = append(parts, StyleChatTokens.Render(model.FormatTokenInOutCache(t.InputTokens, t.CacheWriteTokens, t.CacheReadTokens, t.OutputTokens)+" tok"))
+	}
+	if t.CostUSD > 0 {
+		parts = append(parts, StyleChatTokens.Render(model.FormatCost(t.CostUSD)))
 	}
 	inner := strings.Join(parts, "  ")
 	if inner == "" {
@@ -33,8 +37,8 @@ func renderTurnBoundary(t model.Turn, width int) string {
 }
 
 // RenderChatItemDetail renders the detail view for a selected ChatItem.
-// Shows the message text (thinking + text) and inline one-line tool call
-// summaries across all turns.
+// Shows the message text (thinking + text; assistant text as markdown) and
+// inline one-line tool call summaries across all turns.
 func RenderChatItemDetail(items []ChatItem, selectedIdx, width int) string {
 	if selectedIdx < 0 || selectedIdx >= len(items) {
 		return ""
@@ -42,7 +46,7 @@ func RenderChatItemDetail(items []ChatItem, selectedIdx, width int) string {
 	sel := items[selectedIdx]
 	var lines []string
 
-	// Header: WHO · model · time · tokens
+	// Header: WHO · model · time · tokens · cost
 	lines = append(lines, renderChatItemHeader(sel))
 
 	// Render thinking, text, and tool call summaries for a single turn.
@@ -54,7 +58,11 @@ func RenderChatItemDetail(items []ChatItem, selectedIdx, width int) string {
 		}
 		if t.Text != "" {
 			lines = append(lines, "")
-			lines = append(lines, ansi.Wrap(t.Text, width, ""))
+			if t.Role == "user" {
+				lines = append(lines, ansi.Wrap(t.Text, width, ""))
+			} else {
+				lines = append(lines, renderMarkdown(t.Text, width))
+			}
 		}
 		for _, tc := range t.ToolCalls {
 			lines = append(lines, renderToolCallOneliner(tc, width))
@@ -103,7 +111,7 @@ func renderToolCallOneliner(tc *model.ToolCall, maxWidth int) string {
 	return ansi.Wrap(line, maxWidth, "")
 }
 
-// renderChatItemHeader builds the "WHO · model · time · tokens" header line.
+// renderChatItemHeader builds the "WHO · model · time · tokens · cost" header line.
 func renderChatItemHeader(item ChatItem) string {
 	turn := item.Turn
 	var parts []string
@@ -121,15 +129,22 @@ func renderChatItemHeader(item ChatItem) string {
 		parts = append(parts, StyleChatTimestamp.Render(turn.Timestamp.Format("15:04")))
 	}
 	totalIn := turn.InputTokens
-	totalCache := turn.CacheReadTokens
+	totalWrite := turn.CacheWriteTokens
+	totalRead := turn.CacheReadTokens
 	totalOut := turn.OutputTokens
+	totalCost := turn.CostUSD
 	for _, et := range item.ExtraTurns {
 		totalIn += et.InputTokens
-		totalCache += et.CacheReadTokens
+		totalWrite += et.CacheWriteTokens
+		totalRead += et.CacheReadTokens
 		totalOut += et.OutputTokens
+		totalCost += et.CostUSD
 	}
-	if totalIn > 0 || totalCache > 0 || totalOut > 0 {
-		parts = append(parts, StyleChatTokens.Render(model.FormatTokenInOutCache(totalIn, totalCache, totalOut)+" tok"))
+	if totalIn > 0 || totalWrite > 0 || totalRead > 0 || totalOut > 0 {
+		parts = append(parts, StyleChatTokens.Render(model.FormatTokenInOutCache(totalIn, totalWrite, totalRead, totalOut)+" tok"))
+	}
+	if totalCost > 0 {
+		parts = append(parts, StyleChatTokens.Render(model.FormatCost(totalCost)))
 	}
 	return strings.Join(parts, "  ")
 }
@@ -151,13 +166,44 @@ func ChatItemKey(item ChatItem) string {
 	return key
 }
 
+// FindChatItem returns the index of the item holding the turn with uuid, or
+// failing that the last item at or before ts; -1 when there is no item.
+func FindChatItem(items []ChatItem, uuid string, ts time.Time) int {
+	if uuid != "" {
+		for i, item := range items {
+			if item.IsDivider {
+				continue
+			}
+			if item.Turn.UUID == uuid {
+				return i
+			}
+			for _, et := range item.ExtraTurns {
+				if et.UUID == uuid {
+					return i
+				}
+			}
+		}
+	}
+	found := -1
+	for i, item := range items {
+		if item.IsDivider {
+			continue
+		}
+		if found < 0 || !item.Turn.Timestamp.After(ts) {
+			found = i
+		}
+	}
+	return found
+}
+
 // renderExpandedToolCall renders a tool call in two-line Option-1 style:
 //
 //	▸ NAME  model  duration
 //	    input summary  ✓/✗
+//	    diff (file-modifying tools)
 //	    result...
-func renderExpandedToolCall(tc *model.ToolCall, turn model.Turn, maxWidth int) string {
-	// Line 1: name + model + duration + tokens
+func renderExpandedToolCall(tc *model.ToolCall, turn model.Turn, diff string, maxWidth int) string {
+	// Line 1: name + model + duration + tokens + cost
 	headerParts := []string{StyleChatToolName.Render("▸ " + tc.Name)}
 	if m := model.ShortModelName(turn.ModelName); m != "" {
 		headerParts = append(headerParts, StyleDim.Render(m))
@@ -165,8 +211,11 @@ func renderExpandedToolCall(tc *model.ToolCall, turn model.Turn, maxWidth int) s
 	if tc.Duration > 0 {
 		headerParts = append(headerParts, StyleChatTimestamp.Render(tc.DurationString()))
 	}
-	if turn.InputTokens > 0 || turn.CacheReadTokens > 0 || turn.OutputTokens > 0 {
-		headerParts = append(headerParts, StyleChatTokens.Render(model.FormatTokenInOutCache(turn.InputTokens, turn.CacheReadTokens, turn.OutputTokens)+" tok"))
+	if turn.InputTokens > 0 || turn.CacheWriteTokens > 0 || turn.CacheReadTokens > 0 || turn.OutputTokens > 0 {
+		headerParts = append(headerParts, StyleChatTokens.Render(model.FormatTokenInOutCache(turn.InputTokens, turn.CacheWriteTokens, turn.CacheReadTokens, turn.OutputTokens)+" tok"))
+	}
+	if turn.CostUSD > 0 {
+		headerParts = append(headerParts, StyleChatTokens.Render(model.FormatCost(turn.CostUSD)))
 	}
 	headerLine := "  " + strings.Join(headerParts, "  ")
 
@@ -188,16 +237,38 @@ func renderExpandedToolCall(tc *model.ToolCall, turn model.Turn, maxWidth int) s
 	var lines []string
 	lines = append(lines, ansi.Wrap(headerLine, maxWidth, ""))
 	lines = append(lines, inputLine)
+	if diff != "" {
+		lines = append(lines, "")
+		for _, dl := range strings.Split(diff, "\n") {
+			lines = append(lines, "    "+dl)
+		}
+		lines = append(lines, "")
+	}
 
-	// Result lines (indented)
+	// Result lines (indented); Read and Bash output is highlighted as code
+	// when its language is known.
 	if resultStr := expandResult(tc); resultStr != "" {
 		indent := "    "
 		contentWidth := maxWidth - len(indent)
 		if contentWidth < 20 {
 			contentWidth = 20
 		}
-		for _, rl := range strings.Split(resultStr, "\n") {
-			lines = append(lines, indent+ansi.Wrap(StyleDim.Render(rl), contentWidth, ""))
+		resultLines := strings.Split(resultStr, "\n")
+		for i := range resultLines {
+			resultLines[i] = StyleDim.Render(resultLines[i])
+		}
+		if !tc.IsError {
+			switch tc.Name {
+			case "Read":
+				resultLines = strings.Split(highlightReadResult(resultStr, tc.FilePath()), "\n")
+			case "Bash":
+				if lexer := bashResultLexer(tc.InputSummary()); lexer != nil {
+					resultLines = strings.Split(highlightWith(lexer, resultStr), "\n")
+				}
+			}
+		}
+		for _, rl := range resultLines {
+			lines = append(lines, indent+ansi.Wrap(rl, contentWidth, ""))
 		}
 	}
 
@@ -210,44 +281,63 @@ func expandResult(tc *model.ToolCall) string {
 }
 
 // RenderToolCallDetail renders the full detail view for a single tool call.
-func RenderToolCallDetail(tr *ToolCallRow, width int) string {
+// Edit, MultiEdit and Write calls also show their change as a unified diff;
+// calls are the session's tool calls, which show a file's earlier content.
+func RenderToolCallDetail(tr *ToolCallRow, calls []*model.ToolCall, width int) string {
 	if tr == nil {
 		return ""
 	}
-	return renderExpandedToolCall(tr.ToolCall, tr.ParentTurn, width)
+	diff := ""
+	if change, ok := tr.ToolCall.FileChange(); ok {
+		before, known := priorFileContent(calls, tr.ToolCall)
+		diff = renderFileChange(change, before, known, width-4)
+	}
+	return renderExpandedToolCall(tr.ToolCall, tr.ParentTurn, diff, width)
 }
 
-// RenderPluginItemDetail renders the content of a selected plugin item.
+// RenderPluginItemDetail renders the content of a selected plugin item:
+// skills, commands and agents as markdown, hooks and MCP servers as
+// highlighted JSON, hook scripts highlighted by their extension.
 func RenderPluginItemDetail(item *model.PluginItem, width int) string {
 	if item == nil {
 		return ""
 	}
 	header := StyleTitle.Render(item.Name) + "  " + StyleDim.Render(item.Category)
 	content := model.ReadPluginItemContent(item)
-	result := header + "\n\n" + ansi.Wrap(content, width, "")
+	var body string
+	switch item.Category {
+	case "skill", "command", "agent":
+		body = renderMarkdown(content, width)
+	default: // hooks and MCP servers are JSON
+		if t := strings.TrimSpace(content); strings.HasPrefix(t, "{") || strings.HasPrefix(t, "[") {
+			content = highlightCode(content, "json")
+		}
+		body = ansi.Wrap(content, width, "")
+	}
+	result := header + "\n\n" + body
 	if item.Category == "hook" {
 		scripts := model.ReadHookCommandScripts(item)
 		if len(scripts) > 0 {
 			result += "\n\ncommand scripts below:\n"
 			for _, s := range scripts {
-				result += "\n" + StyleDim.Render("--- "+s.Path+" ---") + "\n" + ansi.Wrap(s.Content, width, "")
+				result += "\n" + StyleDim.Render("--- "+s.Path+" ---") + "\n" + ansi.Wrap(highlightFile(s.Content, s.Path), width, "")
 			}
 		}
 	}
 	return result
 }
 
-// RenderMemoryDetail reads and returns the raw content of a memory file.
+// RenderMemoryDetail reads a memory file and renders it as markdown.
 func RenderMemoryDetail(m *model.Memory, width int) string {
 	if m == nil {
 		return ""
 	}
 	if m.Content != "" {
-		return ansi.Wrap(m.Content, width, "")
+		return renderMarkdown(m.Content, width)
 	}
 	data, err := os.ReadFile(m.Path)
 	if err != nil {
 		return fmt.Sprintf("error reading %s: %v", m.Path, err)
 	}
-	return ansi.Wrap(string(data), width, "")
+	return renderMarkdown(string(data), width)
 }
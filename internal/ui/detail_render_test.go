@@ -419,3 +419,33 @@ func TestRenderChatItemDetail_OutOfBounds(t *testing.T) {
 		t.Errorf("expected empty for out-of-range index, got %q", got)
 	}
 }
+
+func TestFindChatItem(t *testing.T) {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	items := []ui.ChatItem{
+		{Turn: model.Turn{Role: "user", UUID: "u1", Timestamp: t0}},
+		{Turn: model.Turn{Role: "assistant", UUID: "a1", Timestamp: t0.Add(time.Minute)},
+			ExtraTurns: []model.Turn{{Role: "assistant", UUID: "a2", Timestamp: t0.Add(2 * time.Minute)}}},
+		{IsDivider: true, Turn: model.Turn{Timestamp: t0.Add(3 * time.Minute)}},
+		{Turn: model.Turn{Role: "user", UUID: "u2", Timestamp: t0.Add(4 * time.Minute)}},
+	}
+	tests := []struct {
+		uuid string
+		ts   time.Time
+		want int
+	}{
+		{"u1", time.Time{}, 0},
+		{"a2", time.Time{}, 1},               // grouped under the first turn's item
+		{"gone", t0.Add(3 * time.Minute), 1}, // falls back to the time, skipping dividers
+		{"", t0.Add(5 * time.Minute), 3},
+		{"", t0.Add(-time.Hour), 0}, // before every item
+	}
+	for _, tt := range tests {
+		if got := ui.FindChatItem(items, tt.uuid, tt.ts); got != tt.want {
+			t.Errorf("FindChatItem(%q, %v) = %d, want %d", tt.uuid, tt.ts, got, tt.want)
+		}
+	}
+	if got := ui.FindChatItem(nil, "u1", t0); got != -1 {
+		t.Errorf("FindChatItem(nil) = %d, want -1", got)
+	}
+}
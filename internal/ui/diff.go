@@ -0,0 +1,317 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// maxDiffEdits bounds the diff search; files changing more lines than this
+// are shown as one replacement of the changed region.
+const maxDiffEdits = 1000
+
+// diffLine is one line of a line diff: op is ' ' (kept), '-' or '+'.
+type diffLine struct {
+	op           byte
+	text         string
+	oldNo, newNo int // 1-based line numbers; 0 for the side the line is not on
+}
+
+// diffLines returns the shortest line diff turning a into b.
+func diffLines(a, b []string) []diffLine {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	var out []diffLine
+	for i := range pre {
+		out = append(out, diffLine{op: ' ', text: a[i], oldNo: i + 1, newNo: i + 1})
+	}
+	for _, d := range myers(a[pre:len(a)-suf], b[pre:len(b)-suf]) {
+		if d.oldNo > 0 {
+			d.oldNo += pre
+		}
+		if d.newNo > 0 {
+			d.newNo += pre
+		}
+		out = append(out, d)
+	}
+	for i := suf; i > 0; i-- {
+		out = append(out, diffLine{op: ' ', text: a[len(a)-i], oldNo: len(a) - i + 1, newNo: len(b) - i + 1})
+	}
+	return out
+}
+
+// myers is Myers' O(ND) diff. It gives up after maxDiffEdits and returns every
+// line of a deleted and every line of b added.
+func myers(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replaceAll(a, b)
+	}
+	off := n + m + 1
+	v := make([]int, 2*off+1)
+	var trace [][]int // trace[d] holds v[k] for k in [-d-1, d+1] before round d
+	for d := 0; d <= min(n+m, maxDiffEdits); d++ {
+		trace = append(trace, append([]int(nil), v[off-d-1:off+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace)
+			}
+		}
+	}
+	return replaceAll(a, b)
+}
+
+// backtrack walks the rounds of myers back from the end of both inputs.
+func backtrack(a, b []string, trace [][]int) []diffLine {
+	var rev []diffLine
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := func(k int) int { return trace[d][k+d+1] }
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			rev = append(rev, diffLine{op: ' ', text: a[x-1], oldNo: x, newNo: y})
+			x--
+			y--
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			rev = append(rev, diffLine{op: '+', text: b[y-1], newNo: y})
+		} else {
+			rev = append(rev, diffLine{op: '-', text: a[x-1], oldNo: x})
+		}
+		x, y = prevX, prevY
+	}
+	out := make([]diffLine, len(rev))
+	for i, d := range rev {
+		out[len(rev)-1-i] = d
+	}
+	return out
+}
+
+// replaceAll diffs a and b as every line of a deleted and every line of b added.
+func replaceAll(a, b []string) []diffLine {
+	out := make([]diffLine, 0, len(a)+len(b))
+	for i, l := range a {
+		out = append(out, diffLine{op: '-', text: l, oldNo: i + 1})
+	}
+	for i, l := range b {
+		out = append(out, diffLine{op: '+', text: l, newNo: i + 1})
+	}
+	return out
+}
+
+// diffHunk is a run of changes with their surrounding context.
+type diffHunk struct {
+	lines                []diffLine
+	oldStart, oldLines   int
+	newStart, newLines   int
+	added, deleted, kept int
+}
+
+// hunks groups a diff into hunks, each change with diffContext lines of
+// context; changes closer than twice that share a hunk.
+func hunks(diff []diffLine) []diffHunk {
+	var out []diffHunk
+	for i := 0; i < len(diff); {
+		if diff[i].op == ' ' {
+			i++
+			continue
+		}
+		start := max(i-diffContext, 0)
+		end := i
+		for end < len(diff) {
+			if diff[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(diff) && diff[next].op == ' ' {
+				next++
+			}
+			if next == len(diff) || next-end > 2*diffContext {
+				end = min(end+diffContext, len(diff))
+				break
+			}
+			end = next
+		}
+		out = append(out, newHunk(diff[start:end]))
+		i = end
+	}
+	return out
+}
+
+func newHunk(lines []diffLine) diffHunk {
+	h := diffHunk{lines: lines}
+	for _, l := range lines {
+		switch l.op {
+		case '+':
+			h.added++
+		case '-':
+			h.deleted++
+		default:
+			h.kept++
+		}
+		if l.oldNo > 0 && h.oldStart == 0 {
+			h.oldStart = l.oldNo
+		}
+		if l.newNo > 0 && h.newStart == 0 {
+			h.newStart = l.newNo
+		}
+	}
+	h.oldLines = h.kept + h.deleted
+	h.newLines = h.kept + h.added
+	return h
+}
+
+// header returns the hunk's "@@ -a,b +c,d @@" line. A side without lines
+// starts at the line before the hunk, as in diff -u.
+func (h diffHunk) header() string {
+	oldStart, newStart := h.oldStart, h.newStart
+	if h.oldLines == 0 {
+		oldStart = max(h.newStart-1, 0)
+	}
+	if h.newLines == 0 {
+		newStart = max(h.oldStart-1, 0)
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, h.oldLines, newStart, h.newLines)
+}
+
+// splitFileLines splits file content into lines; "" has none.
+func splitFileLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// renderFileChange renders what an Edit, MultiEdit or Write call did as a
+// colored unified diff under a "path  +added -deleted" header. before is the
+// file's content ahead of the call when earlier calls show it. Without it an
+// edit is shown as its own old and new strings, and a Write as all added.
+func renderFileChange(change model.FileChange, before string, known bool, width int) string {
+	type section struct {
+		title string // replaces the hunk headers when line numbers are unknown
+		hunks []diffHunk
+	}
+	var sections []section
+	note := ""
+	if after, ok := change.Apply(before); known && ok {
+		sections = append(sections, section{hunks: hunks(diffLines(splitFileLines(before), splitFileLines(after)))})
+	} else if change.IsWrite {
+		sections = append(sections, section{hunks: hunks(diffLines(nil, splitFileLines(change.Content)))})
+		note = "no earlier version in this session"
+	} else {
+		for i, e := range change.Edits {
+			title := fmt.Sprintf("@@ edit %d/%d @@", i+1, len(change.Edits))
+			if e.ReplaceAll {
+				title += " every occurrence"
+			}
+			diff := diffLines(splitFileLines(e.Old), splitFileLines(e.New))
+			sections = append(sections, section{title: title, hunks: []diffHunk{newHunk(diff)}})
+		}
+		note = "line numbers unknown"
+	}
+
+	added, deleted := 0, 0
+	for _, s := range sections {
+		for _, h := range s.hunks {
+			added += h.added
+			deleted += h.deleted
+		}
+	}
+	header := StyleTitle.Render(change.Path) + "  " +
+		StyleDiffAdd.Render(fmt.Sprintf("+%d", added)) + " " + StyleDiffDel.Render(fmt.Sprintf("-%d", deleted))
+	if note != "" {
+		header += "  " + StyleDim.Render("("+note+")")
+	}
+	lines := []string{ansi.Wrap(header, width, "")}
+	if added == 0 && deleted == 0 {
+		lines = append(lines, StyleDim.Render("no changes"))
+	}
+	for _, s := range sections {
+		for _, h := range s.hunks {
+			title := h.header()
+			if s.title != "" {
+				title = s.title
+			}
+			lines = append(lines, StyleDiffHunk.Render(title))
+			for _, l := range h.lines {
+				style := StyleDim
+				switch l.op {
+				case '+':
+					style = StyleDiffAdd
+				case '-':
+					style = StyleDiffDel
+				}
+				lines = append(lines, ansi.Wrap(style.Render(string(l.op)+l.text), width, ""))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// priorFileContent returns the content of the file call changes just before
+// call, as the session's earlier calls show it: the last Write or whole-file
+// Read of the path, with the edits after it applied. calls holds the
+// session's tool calls; known is false when none of them shows the file.
+func priorFileContent(calls []*model.ToolCall, call *model.ToolCall) (content string, known bool) {
+	change, ok := call.FileChange()
+	if !ok {
+		return "", false
+	}
+	sorted := append([]*model.ToolCall(nil), calls...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+	for _, c := range sorted {
+		if c == call || (call.ID != "" && c.ID == call.ID) || c.Timestamp.After(call.Timestamp) {
+			break
+		}
+		if c.IsError || c.FilePath() != change.Path {
+			continue
+		}
+		if _, text, ok := c.ReadContent(); ok {
+			content, known = text, true
+			continue
+		}
+		if ch, ok := c.FileChange(); ok {
+			if ch.IsWrite {
+				content, known = ch.Content, true
+			} else if known {
+				content, known = ch.Apply(content)
+			}
+		}
+	}
+	return content, known
+}
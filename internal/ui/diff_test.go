@@ -0,0 +1,104 @@
+package ui_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+func fileCall(id, name string, at int, input map[string]any, result string) *model.ToolCall {
+	in, _ := json.Marshal(input)
+	res, _ := json.Marshal(result)
+	return &model.ToolCall{ID: id, Name: name, Input: in, Result: res,
+		Timestamp: time.Date(2026, 3, 1, 12, at, 0, 0, time.UTC)}
+}
+
+// diffOf renders a tool call's detail and returns its plain lines from the
+// file header up to the blank line closing the diff.
+func diffOf(t *testing.T, tc *model.ToolCall, calls []*model.ToolCall) []string {
+	t.Helper()
+	out := ansi.Strip(ui.RenderToolCallDetail(&ui.ToolCallRow{ToolCall: tc}, calls, 120))
+	var lines []string
+	in := false
+	for _, l := range strings.Split(out, "\n") {
+		l = strings.TrimPrefix(l, "    ")
+		if strings.HasPrefix(l, "/src/a.go  +") {
+			in = true
+		}
+		if in && l == "" {
+			break
+		}
+		if in {
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) == 0 {
+		t.Fatalf("no diff in:\n%s", out)
+	}
+	return lines
+}
+
+func TestToolCallDetailEditDiffWithLineNumbers(t *testing.T) {
+	var numbered []string // a Read result of lines "line a" to "line t"
+	for i := range 20 {
+		numbered = append(numbered, fmt.Sprintf("%6d\tline %c", i+1, 'a'+i))
+	}
+	read := fileCall("r", "Read", 1, map[string]any{"file_path": "/src/a.go"}, strings.Join(numbered, "\n"))
+	edit := fileCall("e", "Edit", 2, map[string]any{"file_path": "/src/a.go", "old_string": "line j\n", "new_string": "line J\nline J2\n"}, "ok")
+
+	got := strings.Join(diffOf(t, edit, []*model.ToolCall{read, edit}), "\n")
+	want := strings.Join([]string{
+		"/src/a.go  +2 -1",
+		"@@ -7,7 +7,8 @@",
+		" line g",
+		" line h",
+		" line i",
+		"-line j",
+		"+line J",
+		"+line J2",
+		" line k",
+		" line l",
+		" line m",
+	}, "\n")
+	if got != want {
+		t.Errorf("diff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestToolCallDetailEditWithoutHistory(t *testing.T) {
+	edit := fileCall("e", "MultiEdit", 1, map[string]any{"file_path": "/src/a.go", "edits": []map[string]any{
+		{"old_string": "a\nb", "new_string": "a\nc"},
+		{"old_string": "x", "new_string": "y", "replace_all": true},
+	}}, "ok")
+
+	got := diffOf(t, edit, nil)
+	if got[0] != "/src/a.go  +2 -2  (line numbers unknown)" {
+		t.Errorf("header = %q", got[0])
+	}
+	body := strings.Join(got[1:], "\n")
+	if body != "@@ edit 1/2 @@\n a\n-b\n+c\n@@ edit 2/2 @@ every occurrence\n-x\n+y" {
+		t.Errorf("body =\n%s", body)
+	}
+}
+
+func TestToolCallDetailWriteDiffsAgainstEarlierWrite(t *testing.T) {
+	first := fileCall("w1", "Write", 1, map[string]any{"file_path": "/src/a.go", "content": "one\ntwo\n"}, "ok")
+	edit := fileCall("e", "Edit", 2, map[string]any{"file_path": "/src/a.go", "old_string": "two", "new_string": "2"}, "ok")
+	second := fileCall("w2", "Write", 3, map[string]any{"file_path": "/src/a.go", "content": "one\n2\nthree\n"}, "ok")
+	later := fileCall("w3", "Write", 4, map[string]any{"file_path": "/src/a.go", "content": "gone\n"}, "ok")
+	calls := []*model.ToolCall{later, second, edit, first} // any order
+
+	if got := strings.Join(diffOf(t, second, calls), "\n"); got != "/src/a.go  +1 -0\n@@ -1,2 +1,3 @@\n one\n 2\n+three" {
+		t.Errorf("Write diff =\n%s", got)
+	}
+	if got := diffOf(t, first, calls); got[0] != "/src/a.go  +2 -0  (no earlier version in this session)" || got[1] != "@@ -0,0 +1,2 @@" {
+		t.Errorf("first Write =\n%s", strings.Join(got, "\n"))
+	}
+}
@@ -1,9 +1,13 @@
 package ui
 
-// FilterModel handles the `/` filter mode.
+// FilterModel handles the `/` filter mode. The search input reuses it with
+// its own Prompt, and the in-content search of detail views with a Count.
 type FilterModel struct {
 	Active bool
 	Input  string
+	Err    string // why Input does not parse as a query, shown after it
+	Prompt string // shown before Input; "/" when empty
+	Count  string // match position shown after Input, e.g. "3/17"
 	Width  int
 }
 
@@ -11,6 +15,7 @@ type FilterModel struct {
 func (f *FilterModel) Activate() {
 	f.Active = true
 	f.Input = ""
+	f.Err = ""
 }
 
 // Deactivate stops filter mode.
@@ -33,10 +38,25 @@ func (f *FilterModel) Backspace() {
 // Height returns the number of terminal lines rendered by View.
 func (f FilterModel) Height() int { return 1 }
 
-// View renders the filter bar.
+// View renders the filter bar. With a Count it stays visible after the
+// input closes, without the cursor.
 func (f FilterModel) View() string {
-	if !f.Active {
+	if !f.Active && f.Count == "" {
 		return ""
 	}
-	return StyleFilter.Width(f.Width).Render("/" + f.Input + "█")
+	prompt := f.Prompt
+	if prompt == "" {
+		prompt = "/"
+	}
+	text := prompt + f.Input
+	if f.Active {
+		text += "█"
+	}
+	if f.Count != "" {
+		text += "  " + f.Count
+	}
+	if f.Err != "" {
+		text += "  " + StyleError.Render(f.Err)
+	}
+	return StyleFilter.Width(f.Width).Render(text)
 }
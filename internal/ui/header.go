@@ -19,13 +19,19 @@ type InfoModel struct {
 	MemoriesActive bool               // whether <m> memories jump is available
 	Resource       model.ResourceType // current active resource (hides its own jump hint)
 	UsageLine      string             // rendered usage bar (empty = hidden)
+	Process        string             // running Claude Code process summary (empty = hidden)
 }
 
 // Height returns the number of terminal lines rendered by ViewWithMenu.
 // navCount, actionCount, and utilCount are the number of items in each menu column.
-// Minimum is 5 (1 project row + 4 data rows); expands if more items are needed.
+// Minimum is 5 (1 project row + 4 data rows, 5 with a Process row); expands if
+// more items are needed.
 func (info InfoModel) Height(navCount, actionCount, utilCount int) int {
-	base := max(5, 1+max(navCount, max(actionCount, utilCount)))
+	dataRows := 4
+	if info.Process != "" {
+		dataRows++
+	}
+	base := max(1+dataRows, 1+max(navCount, max(actionCount, utilCount)))
 	if info.UsageLine != "" {
 		return base + strings.Count(info.UsageLine, "\n") + 1 // +1 for the joining newline
 	}
@@ -38,7 +44,7 @@ func (info InfoModel) Height(navCount, actionCount, utilCount int) int {
 //	Col 1 (navColW chars):  nav menu items (movement commands)
 //	Col 2 (actionColW):     action menu items (enter/space/esc)
 //	Col 3 (utilColW chars): util menu items (filter)
-//	Col 4 (rightW chars):   p/m jump shortcuts
+//	Col 4 (rightW chars):   p/m/a/t jump shortcuts
 //	Col 5:                  ctrl+c quit
 //
 // Row 0 (Project) spans the full width. Remaining rows use the 6-column layout.
@@ -72,6 +78,9 @@ func (info InfoModel) ViewWithMenu(menu MenuModel) string {
 		{"Claude Code:", val(info.ClaudeVersion)},
 		{"claudeview:", val(info.AppVersion)},
 	}
+	if info.Process != "" {
+		otherRows = append(otherRows, struct{ label, value string }{"Process:", info.Process})
+	}
 
 	// Within each column, pad keys to the column's max key width so descriptions align.
 	maxNavKeyW := menuMaxKeyW(navItems)
@@ -83,18 +92,24 @@ func (info InfoModel) ViewWithMenu(menu MenuModel) string {
 	actionColW := menuColW(actionItems, maxActionKeyW) + 2
 	utilColW := menuColW(utilItems, maxUtilKeyW) + 2
 
-	// Col 4: p/m jump shortcuts.
-	// Plugins and memories views cannot navigate to each other, so both hints
-	// are hidden when either view is active.
+	// Col 4: p/m/a/t jump shortcuts.
+	// Plugins, memories and search views cannot navigate to each other, so
+	// both hints are hidden when any of them is active.
 	var jumpHints []string
 	inPluginsOrMemories := info.Resource == model.ResourcePlugins || info.Resource == model.ResourceMemory ||
-		isSubView(info.Resource)
+		info.Resource == model.ResourceSearch || isSubView(info.Resource)
 	if !inPluginsOrMemories {
 		jumpHints = append(jumpHints, renderJumpHint(menu, "p", "plugins"))
 	}
 	if info.MemoriesActive && !inPluginsOrMemories {
 		jumpHints = append(jumpHints, renderJumpHint(menu, "m", "memories"))
 	}
+	if info.Resource == model.ResourceSessions {
+		jumpHints = append(jumpHints, renderJumpHint(menu, "a", "agents"))
+	}
+	if info.Resource == model.ResourceSessions || info.Resource == model.ResourceAgents {
+		jumpHints = append(jumpHints, renderJumpHint(menu, "t", "tool calls"))
+	}
 	rightColW := 0
 	for _, h := range jumpHints {
 		if w := lipgloss.Width(h); w > rightColW {
@@ -44,3 +44,23 @@ func TestInfoModelViewNoUsageLine(t *testing.T) {
 		t.Error("should not start with newline when UsageLine is empty")
 	}
 }
+
+func TestInfoModelProcessRow(t *testing.T) {
+	info := ui.InfoModel{Width: 80}
+	base := info.Height(0, 0, 0)
+	if strings.Contains(info.ViewWithMenu(ui.MenuModel{}), "Process:") {
+		t.Error("Process row should be hidden when empty")
+	}
+
+	info.Process = "pid 4242 · up 2h · cpu 1.0%"
+	if got := info.Height(0, 0, 0); got != base+1 {
+		t.Errorf("Height() with process = %d, want %d", got, base+1)
+	}
+	out := info.ViewWithMenu(ui.MenuModel{})
+	if !strings.Contains(out, "Process:") || !strings.Contains(out, "pid 4242") {
+		t.Errorf("expected Process row, got:\n%s", out)
+	}
+	if got := strings.Count(out, "\n") + 1; got != base+1 {
+		t.Errorf("rendered %d lines, want %d", got, base+1)
+	}
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightWords renders plain text s in base, with every occurrence of any
+// of words, ignoring case, in mark.
+func highlightWords(s string, words []string, base, mark lipgloss.Style) string {
+	return renderSpans(s, matchSpans(s, words), base, func(int) lipgloss.Style { return mark })
+}
+
+// matchSpans returns the byte ranges of the occurrences in s of any of words,
+// ignoring case, preferring the longest word at each position. It returns
+// nil when lower-casing s changes its byte offsets.
+func matchSpans(s string, words []string) [][2]int {
+	lower := strings.ToLower(s)
+	if len(lower) != len(s) {
+		return nil
+	}
+	lowerWords := make([]string, 0, len(words))
+	for _, w := range words {
+		if w != "" {
+			lowerWords = append(lowerWords, strings.ToLower(w))
+		}
+	}
+
+	var spans [][2]int
+	for i := 0; i < len(s); {
+		n := 0
+		for _, w := range lowerWords {
+			if len(w) > n && strings.HasPrefix(lower[i:], w) {
+				n = len(w)
+			}
+		}
+		if n == 0 {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			continue
+		}
+		spans = append(spans, [2]int{i, i + n})
+		i += n
+	}
+	return spans
+}
+
+// renderSpans renders plain text s in base, with span i in mark(i). Every
+// segment is rendered on its own so a background in base covers the text
+// between marks.
+func renderSpans(s string, spans [][2]int, base lipgloss.Style, mark func(i int) lipgloss.Style) string {
+	var sb strings.Builder
+	plain := 0 // start of the unmarked text not yet written
+	for i, sp := range spans {
+		if plain < sp[0] {
+			sb.WriteString(base.Render(s[plain:sp[0]]))
+		}
+		sb.WriteString(mark(i).Render(s[sp[0]:sp[1]]))
+		plain = sp[1]
+	}
+	if plain < len(s) {
+		sb.WriteString(base.Render(s[plain:]))
+	}
+	return sb.String()
+}
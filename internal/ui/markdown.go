@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^ {0,3}(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	mdRule      = regexp.MustCompile(`^ {0,3}([-*_])( *[-*_]){2,} *$`)
+	mdListItem  = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])\s+(.*)$`)
+	mdFence     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+	mdTableSep  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdTaskCheck = regexp.MustCompile(`^\[([ xX])\]\s+`)
+)
+
+// renderMarkdown renders markdown for the terminal in lines at most width
+// columns wide: headings, lists, block quotes, rules, tables, fenced code
+// (syntax highlighted by its info string) and inline code, emphasis and
+// links. YAML front matter, as in SKILL.md, is shown as highlighted YAML.
+// Line breaks are kept as written.
+func renderMarkdown(src string, width int) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var out []string
+	i := 0
+	if len(lines) > 1 && strings.TrimSpace(lines[0]) == "---" {
+		for j := 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == "---" {
+				out = append(out, renderCodeBlock(strings.Join(lines[1:j], "\n"), "yaml", width)...)
+				out = append(out, StyleMDRule.Render(strings.Repeat("─", max(width, 1))))
+				i = j + 1
+				break
+			}
+		}
+	}
+	for i < len(lines) {
+		line := lines[i]
+		if m := mdFence.FindStringSubmatch(line); m != nil {
+			var code []string
+			j := i + 1
+			for ; j < len(lines); j++ {
+				if c := mdFence.FindStringSubmatch(lines[j]); c != nil && c[1][0] == m[1][0] && len(c[1]) >= len(m[1]) && c[2] == "" {
+					break
+				}
+				code = append(code, lines[j])
+			}
+			out = append(out, renderCodeBlock(strings.Join(code, "\n"), m[2], width)...)
+			i = j + 1
+			continue
+		}
+		if isTableRow(line) && i+1 < len(lines) && mdTableSep.MatchString(lines[i+1]) && strings.Contains(lines[i+1], "-") {
+			rows := [][]string{splitTableRow(line)}
+			aligns := tableAligns(lines[i+1])
+			j := i + 2
+			for ; j < len(lines) && isTableRow(lines[j]); j++ {
+				rows = append(rows, splitTableRow(lines[j]))
+			}
+			out = append(out, renderTable(rows, aligns, width)...)
+			i = j
+			continue
+		}
+		out = append(out, renderMarkdownLine(line, width)...)
+		i++
+	}
+	return strings.Join(out, "\n")
+}
+
+// renderMarkdownLine renders a line outside code blocks and tables.
+func renderMarkdownLine(line string, width int) []string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return []string{""}
+	case mdRule.MatchString(line):
+		return []string{StyleMDRule.Render(strings.Repeat("─", max(width, 1)))}
+	}
+	if m := mdHeading.FindStringSubmatch(line); m != nil {
+		style := StyleMDHeading
+		if len(m[1]) == 1 {
+			style = StyleMDHeading1
+		}
+		return wrapHanging(renderInline(m[2], style), width, "", "")
+	}
+	if strings.HasPrefix(trimmed, ">") {
+		depth := 0
+		for strings.HasPrefix(trimmed, ">") {
+			depth++
+			trimmed = strings.TrimSpace(trimmed[1:])
+		}
+		bar := StyleMDQuote.Render(strings.Repeat("│ ", depth))
+		return wrapHanging(renderInline(trimmed, StyleMDQuote), width, bar, bar)
+	}
+	if m := mdListItem.FindStringSubmatch(line); m != nil {
+		indent := strings.Repeat(" ", len(strings.ReplaceAll(m[1], "\t", "    ")))
+		marker := m[2]
+		if strings.ContainsAny(marker, "-*+") {
+			marker = "•"
+		}
+		text := m[3]
+		if c := mdTaskCheck.FindStringSubmatch(text); c != nil {
+			marker = "☐"
+			if c[1] != " " {
+				marker = "☑"
+			}
+			text = text[len(c[0]):]
+		}
+		first := indent + marker + " "
+		return wrapHanging(renderInline(text, StyleNormal), width, first, strings.Repeat(" ", ansi.StringWidth(first)))
+	}
+	return strings.Split(ansi.Wrap(renderInline(line, StyleNormal), width, ""), "\n")
+}
+
+// wrapHanging wraps styled text to width, starting the first line with first
+// and the rest with rest, both of the same display width.
+func wrapHanging(text string, width int, first, rest string) []string {
+	lines := strings.Split(ansi.Wrap(text, max(width-ansi.StringWidth(first), 10), ""), "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return lines
+}
+
+// renderCodeBlock renders fenced code highlighted for lang behind a gutter.
+func renderCodeBlock(code, lang string, width int) []string {
+	gutter := StyleMDRule.Render("│ ")
+	var out []string
+	for _, line := range strings.Split(highlightCode(code, lang), "\n") {
+		for _, part := range strings.Split(ansi.Hardwrap(line, max(width-2, 10), false), "\n") {
+			out = append(out, gutter+part)
+		}
+	}
+	return out
+}
+
+// renderInline renders the inline markup of one line, text outside markup
+// in base: `code`, **strong**, *emphasis*, ~~strike~~ and [links](url).
+// Markup without its closing delimiter is shown as written.
+func renderInline(s string, base lipgloss.Style) string {
+	var sb strings.Builder
+	plain := 0 // start of the text not yet written
+	flush := func(end int) {
+		if plain < end {
+			sb.WriteString(base.Render(s[plain:end]))
+		}
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		var rendered string
+		next := -1
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_~[]()#>|-+.!", s[i+1]) >= 0:
+			flush(i)
+			plain = i + 1
+			i += 2
+			continue
+		case c == '`':
+			n := 1
+			for i+n < len(s) && s[i+n] == '`' {
+				n++
+			}
+			ticks := s[i : i+n]
+			if end := strings.Index(s[i+n:], ticks); end >= 0 {
+				code := s[i+n : i+n+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				rendered, next = StyleMDCode.Render(code), i+n+end+n
+			} else {
+				i += n
+				continue
+			}
+		case (c == '*' || c == '_' || c == '~') && i+1 < len(s) && s[i+1] == c && opensEmphasis(s, i, 2):
+			if end := closeEmphasis(s, i+2, s[i:i+2]); end >= 0 {
+				style := base.Bold(true)
+				if c == '~' {
+					style = base.Strikethrough(true)
+				}
+				rendered, next = renderInline(s[i+2:end], style), end+2
+			}
+		case (c == '*' || c == '_') && opensEmphasis(s, i, 1):
+			if end := closeEmphasis(s, i+1, s[i:i+1]); end >= 0 {
+				rendered, next = renderInline(s[i+1:end], base.Italic(true)), end+1
+			}
+		case c == '[':
+			if mid := strings.Index(s[i:], "]("); mid > 0 {
+				if end := strings.IndexByte(s[i+mid+2:], ')'); end >= 0 {
+					text := s[i+1 : i+mid]
+					url := s[i+mid+2 : i+mid+2+end]
+					rendered = StyleMDLink.Render(text)
+					if url != text && url != "" {
+						rendered += StyleDim.Render(" (" + url + ")")
+					}
+					next = i + mid + 2 + end + 1
+				}
+			}
+		}
+		if next < 0 {
+			i++
+			continue
+		}
+		flush(i)
+		sb.WriteString(rendered)
+		i, plain = next, next
+	}
+	flush(len(s))
+	return sb.String()
+}
+
+// opensEmphasis reports whether the n-character delimiter at s[i] can open
+// emphasis: text follows it, and an underscore does not sit inside a word
+// (snake_case stays as written).
+func opensEmphasis(s string, i, n int) bool {
+	if i+n >= len(s) || s[i+n] == ' ' || s[i+n] == s[i] {
+		return false
+	}
+	return s[i] != '_' || i == 0 || !isWordByte(s[i-1])
+}
+
+// closeEmphasis returns the index of the delimiter closing emphasis in s
+// from start, or -1: the delimiter after non-space text, not followed by a
+// word character when it is an underscore.
+func closeEmphasis(s string, start int, delim string) int {
+	for j := start + 1; j+len(delim) <= len(s); j++ {
+		if s[j:j+len(delim)] != delim || s[j-1] == ' ' {
+			continue
+		}
+		after := j + len(delim)
+		if after < len(s) && s[after] == delim[0] {
+			j++ // a longer run of the delimiter closes something else
+			continue
+		}
+		if delim[0] == '_' && after < len(s) && isWordByte(s[after]) {
+			continue
+		}
+		return j
+	}
+	return -1
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= 0x80
+}
+
+// isTableRow reports whether a line looks like a pipe table row.
+func isTableRow(line string) bool {
+	t := strings.TrimSpace(line)
+	return strings.HasPrefix(t, "|") && len(t) > 1
+}
+
+// splitTableRow returns the trimmed cells of a pipe table row.
+func splitTableRow(line string) []string {
+	t := strings.TrimSpace(line)
+	t = strings.TrimPrefix(t, "|")
+	t = strings.TrimSuffix(t, "|")
+	cells := strings.Split(t, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// tableAligns returns the alignment of each column of a table's separator
+// row: lipgloss.Left, Center or Right.
+func tableAligns(sep string) []lipgloss.Position {
+	cells := splitTableRow(sep)
+	aligns := make([]lipgloss.Position, len(cells))
+	for i, c := range cells {
+		switch {
+		case strings.HasPrefix(c, ":") && strings.HasSuffix(c, ":"):
+			aligns[i] = lipgloss.Center
+		case strings.HasSuffix(c, ":"):
+			aligns[i] = lipgloss.Right
+		default:
+			aligns[i] = lipgloss.Left
+		}
+	}
+	return aligns
+}
+
+// renderTable renders a pipe table with aligned columns; rows[0] is the
+// header. Columns of a table wider than width are shortened, widest first,
+// with cells cut off by "…".
+func renderTable(rows [][]string, aligns []lipgloss.Position, width int) []string {
+	cols := len(rows[0])
+	cells := make([][]string, len(rows))
+	widths := make([]int, cols)
+	for r, row := range rows {
+		base := StyleNormal
+		if r == 0 {
+			base = StyleMDTableHead
+		}
+		cells[r] = make([]string, cols)
+		for c := 0; c < cols && c < len(row); c++ {
+			cells[r][c] = renderInline(row[c], base)
+			widths[c] = max(widths[c], ansi.StringWidth(cells[r][c]))
+		}
+	}
+	sepWidth := 3 * (cols - 1)
+	for total(widths)+sepWidth > width {
+		widest := 0
+		for c := range widths {
+			if widths[c] > widths[widest] {
+				widest = c
+			}
+		}
+		if widths[widest] <= 3 {
+			break
+		}
+		widths[widest]--
+	}
+
+	sep := StyleMDRule.Render(" │ ")
+	var out []string
+	for r := range cells {
+		parts := make([]string, cols)
+		for c := range cols {
+			cell := ansi.Truncate(cells[r][c], widths[c], "…")
+			align := lipgloss.Left
+			if c < len(aligns) {
+				align = aligns[c]
+			}
+			parts[c] = lipgloss.PlaceHorizontal(widths[c], align, cell)
+		}
+		out = append(out, strings.Join(parts, sep))
+		if r == 0 {
+			rules := make([]string, cols)
+			for c := range cols {
+				rules[c] = strings.Repeat("─", widths[c])
+			}
+			out = append(out, StyleMDRule.Render(strings.Join(rules, "─┼─")))
+		}
+	}
+	return out
+}
+
+func total(ns []int) int {
+	sum := 0
+	for _, n := range ns {
+		sum += n
+	}
+	return sum
+}
@@ -0,0 +1,90 @@
+package ui_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+func TestRenderMemoryDetailMarkdown(t *testing.T) {
+	src := strings.Join([]string{
+		"# Notes",
+		"Use **bold**, *emphasis*, `code`, snake_case_name and [docs](https://x.dev).",
+		"- first",
+		"  - nested",
+		"- [x] done",
+		"> quoted",
+		"```go",
+		"func main() {}",
+		"```",
+		"| Name | Count |",
+		"|------|------:|",
+		"| a | 1 |",
+		"| bbb | 22 |",
+	}, "\n")
+	got := ui.RenderMemoryDetail(&model.Memory{Content: src}, 80)
+	want := strings.Join([]string{
+		"Notes",
+		"Use bold, emphasis, code, snake_case_name and docs (https://x.dev).",
+		"• first",
+		"  • nested",
+		"☑ done",
+		"│ quoted",
+		"│ func main() {}",
+		"Name │ Count",
+		"─────┼──────",
+		"a    │     1",
+		"bbb  │    22",
+	}, "\n")
+	if got := ansi.Strip(got); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdownWrapsListItemsWithHangingIndent(t *testing.T) {
+	got := ansi.Strip(ui.RenderMemoryDetail(&model.Memory{Content: "- one two three four five six"}, 16))
+	if got != "• one two three\n  four five six" {
+		t.Errorf("got\n%s", got)
+	}
+}
+
+func TestRenderPluginItemDetailSkillFrontMatter(t *testing.T) {
+	item := &model.PluginItem{Name: "tdd", Category: "skill", Content: "---\nname: tdd\n---\n## Steps"}
+	got := ansi.Strip(ui.RenderPluginItemDetail(item, 20))
+	if !strings.Contains(got, "│ name: tdd\n"+strings.Repeat("─", 20)+"\nSteps") {
+		t.Errorf("front matter not rendered as a YAML block:\n%s", got)
+	}
+}
+
+func TestToolCallDetailHighlightsReadAndBashResults(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	call := func(name string, input map[string]any, result string) *ui.ToolCallRow {
+		in, _ := json.Marshal(input)
+		res, _ := json.Marshal(result)
+		return &ui.ToolCallRow{ToolCall: &model.ToolCall{Name: name, Input: in, Result: res}}
+	}
+	keyword := ui.StyleSyntaxKeyword.Render("func")
+
+	read := ui.RenderToolCallDetail(call("Read", map[string]any{"file_path": "/x/main.go"}, "     1\tfunc main() {}"), nil, 80)
+	if !strings.Contains(read, keyword) || !strings.Contains(read, ui.StyleDim.Render("     1\t")) {
+		t.Errorf("Read result of a .go file not highlighted:\n%q", read)
+	}
+	bash := ui.RenderToolCallDetail(call("Bash", map[string]any{"command": "cat main.go"}, "func main() {}"), nil, 80)
+	if !strings.Contains(bash, keyword) {
+		t.Errorf("Bash result of cat main.go not highlighted:\n%q", bash)
+	}
+	plain := ui.RenderToolCallDetail(call("Bash", map[string]any{"command": "ls"}, "func main() {}"), nil, 80)
+	if strings.Contains(plain, keyword) {
+		t.Errorf("Bash result without a known file type highlighted:\n%q", plain)
+	}
+}
@@ -85,7 +85,8 @@ func splitKey(key string) []string {
 
 // TableNavItems returns navigation menu items for the table view with
 // context-specific descriptions for enter and esc.
-// When hasFilter is true, esc is shown as "clear filter" regardless of resource.
+// When hasFilter is true, esc is shown as "clear filter" regardless of resource
+// ("clear search" in content views).
 func TableNavItems(rt model.ResourceType, hasFilter bool) []MenuItem {
 	items := []MenuItem{
 		{Key: "j/k", Desc: "down/up"},
@@ -97,20 +98,32 @@ func TableNavItems(rt model.ResourceType, hasFilter bool) []MenuItem {
 		items = append(items, MenuItem{Key: "enter", Desc: "see sessions"})
 	case model.ResourceSessions:
 		items = append(items, MenuItem{Key: "enter", Desc: "view history"})
+	case model.ResourceAgents:
+		items = append(items, MenuItem{Key: "enter", Desc: "view history"})
+	case model.ResourceToolCalls:
+		items = append(items, MenuItem{Key: "enter", Desc: "detail"})
 	case model.ResourcePlugins:
 		items = append(items, MenuItem{Key: "enter", Desc: "detail"})
 	case model.ResourcePluginDetail:
 		items = append(items, MenuItem{Key: "enter", Desc: "detail"})
 	case model.ResourceMemory:
 		items = append(items, MenuItem{Key: "enter", Desc: "detail"})
+	case model.ResourceSearch:
+		items = append(items, MenuItem{Key: "enter", Desc: "view history"})
 	}
-	if hasFilter && rt != model.ResourceHistory {
+	if hasFilter && isContentView(rt) {
+		items = append(items, MenuItem{Key: "esc", Desc: "clear search"})
+	} else if hasFilter && rt != model.ResourceHistory {
 		items = append(items, MenuItem{Key: "esc", Desc: "clear filter"})
 	} else if !hasFilter {
 		switch rt {
 		case model.ResourceSessions:
 			items = append(items, MenuItem{Key: "esc", Desc: "see projects"})
-		case model.ResourcePlugins, model.ResourceMemory:
+		case model.ResourceAgents:
+			items = append(items, MenuItem{Key: "esc", Desc: "see sessions"})
+		case model.ResourceToolCalls:
+			items = append(items, MenuItem{Key: "esc", Desc: "back"})
+		case model.ResourcePlugins, model.ResourceMemory, model.ResourceSearch:
 			items = append(items, MenuItem{Key: "esc", Desc: "back"})
 		case model.ResourcePluginDetail, model.ResourcePluginItemDetail, model.ResourceMemoryDetail:
 			items = append(items, MenuItem{Key: "esc", Desc: "back"})
@@ -122,9 +135,9 @@ func TableNavItems(rt model.ResourceType, hasFilter bool) []MenuItem {
 	return items
 }
 
-// TableActionItems returns action menu items (enter/space/esc) for views that use
+// TableActionItems returns action menu items (enter/space/b/esc) for views that use
 // the three-column layout. Currently only ResourceHistory uses this column.
-func TableActionItems(rt model.ResourceType, hasFilter, canExpand bool) []MenuItem {
+func TableActionItems(rt model.ResourceType, hasFilter, canExpand, hasBranches bool) []MenuItem {
 	if rt != model.ResourceHistory {
 		return nil
 	}
@@ -138,20 +151,52 @@ func TableActionItems(rt model.ResourceType, hasFilter, canExpand bool) []MenuIt
 	if canExpand {
 		items = append(items, MenuItem{Key: "space", Desc: "expand/collapse"})
 	}
+	if hasBranches {
+		items = append(items, MenuItem{Key: "b/B", Desc: "next/prev branch"})
+	}
 	items = append(items, MenuItem{Key: "esc", Desc: escDesc})
 	return items
 }
 
 // TableUtilItems returns utility menu items for the table view.
-// Content-only detail views (plugin-item-detail, memory-detail) have no filterable table,
-// so the filter key is omitted.
+// Content-only detail views have no filterable table; there `/` searches the
+// content and n/N step through the matches. F (search) is offered wherever it
+// works, e (open in $EDITOR/$PAGER) where there is content, y (copy)
+// everywhere.
 func TableUtilItems(rt model.ResourceType, hasFilter bool) []MenuItem {
+	var items []MenuItem
 	switch rt {
 	case model.ResourceMemoryDetail, model.ResourcePluginItemDetail, model.ResourceHistoryDetail,
 		model.ResourceToolCallDetail:
-		return nil
+		items = []MenuItem{{Key: "/", Desc: "search"}}
+		if hasFilter {
+			items = append(items, MenuItem{Key: "n/N", Desc: "next/prev match"})
+		}
+	case model.ResourcePlugins, model.ResourceMemory:
+		items = []MenuItem{
+			{Key: "/", Desc: "filter"},
+			{Key: "s/S", Desc: "sort/reverse"},
+		}
+	case model.ResourceProjects, model.ResourceSessions, model.ResourceAgents, model.ResourceToolCalls:
+		items = []MenuItem{
+			{Key: "/", Desc: "filter"},
+			{Key: "s/S", Desc: "sort/reverse"},
+			{Key: "F", Desc: "search"},
+		}
+	case model.ResourceSearch:
+		items = []MenuItem{
+			{Key: "/", Desc: "filter"},
+			{Key: "s/S", Desc: "sort/reverse"},
+			{Key: "F", Desc: "edit search"},
+		}
+	default:
+		items = []MenuItem{
+			{Key: "/", Desc: "filter"},
+			{Key: "F", Desc: "search"},
+		}
 	}
-	return []MenuItem{
-		{Key: "/", Desc: "filter"},
+	if isContentView(rt) || rt == model.ResourceToolCalls {
+		items = append(items, MenuItem{Key: "e", Desc: "open"})
 	}
+	return append(items, MenuItem{Key: "y", Desc: "copy"})
 }
@@ -94,20 +94,19 @@ func TestTableUtilItemsHasFilterInPluginDetail(t *testing.T) {
 	}
 }
 
-func TestTableUtilItemsNoFilterInMemoryDetail(t *testing.T) {
-	util := ui.TableUtilItems(model.ResourceMemoryDetail, false)
-	for _, item := range util {
-		if item.Key == "/" {
-			t.Error("TableUtilItems(memory-detail): should not expose '/' filter key")
+func TestTableUtilItemsContentSearchInDetailViews(t *testing.T) {
+	for _, rt := range []model.ResourceType{model.ResourceMemoryDetail, model.ResourcePluginItemDetail} {
+		util := ui.TableUtilItems(rt, false)
+		if len(util) != 3 || util[0].Key != "/" || util[0].Desc != "search" || util[1].Key != "e" || util[2].Key != "y" {
+			t.Errorf("TableUtilItems(%s) = %v, want '/ search', 'e open' and 'y copy'", rt, util)
 		}
-	}
-}
-
-func TestTableUtilItemsNoFilterInPluginItemDetail(t *testing.T) {
-	util := ui.TableUtilItems(model.ResourcePluginItemDetail, false)
-	for _, item := range util {
-		if item.Key == "/" {
-			t.Error("TableUtilItems(plugin-item-detail): should not expose '/' filter key")
+		util = ui.TableUtilItems(rt, true)
+		if len(util) != 4 || util[1].Key != "n/N" {
+			t.Errorf("TableUtilItems(%s, searching) = %v, want n/N after '/'", rt, util)
+		}
+		nav := ui.TableNavItems(rt, true)
+		if last := nav[len(nav)-1]; last.Key != "esc" || last.Desc != "clear search" {
+			t.Errorf("TableNavItems(%s, searching) esc = %v, want 'clear search'", rt, last)
 		}
 	}
 }
@@ -145,7 +144,7 @@ func TestTableNavItemsPluginItemDetailHasEsc(t *testing.T) {
 }
 
 func TestSessionChatMenuHints(t *testing.T) {
-	items := ui.TableActionItems(model.ResourceHistory, false, true)
+	items := ui.TableActionItems(model.ResourceHistory, false, true, false)
 	keys := make(map[string]string)
 	for _, it := range items {
 		keys[it.Key] = it.Desc
@@ -159,6 +158,19 @@ func TestSessionChatMenuHints(t *testing.T) {
 	if _, ok := keys["space"]; !ok {
 		t.Error("session-chat action should include space hint")
 	}
+	if _, ok := keys["b/B"]; ok {
+		t.Error("session-chat action should not include branch hint for a linear session")
+	}
+}
+
+func TestSessionChatBranchHint(t *testing.T) {
+	items := ui.TableActionItems(model.ResourceHistory, false, false, true)
+	for _, it := range items {
+		if it.Key == "b/B" {
+			return
+		}
+	}
+	t.Error("session-chat action should include b/B hint when the session has branches")
 }
 
 func TestSessionsEnterHintIsChat(t *testing.T) {
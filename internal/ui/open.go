@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// openRequest is what `e` opens outside the TUI: a real file in the editor,
+// at Line when known, or else Content in the pager.
+type openRequest struct {
+	Path    string
+	Line    int
+	Content string
+	Ext     string // extension of the temporary file holding Content
+}
+
+// EditorClosedMsg reports that the editor or pager `e` started has exited.
+type EditorClosedMsg struct {
+	Err error
+}
+
+// fileTools are the tools whose file_path `e` opens instead of the call.
+var fileTools = map[string]bool{"Read": true, "Edit": true, "MultiEdit": true, "Write": true}
+
+// openRequest returns what `e` opens from the content view or the selected
+// tool-call row; ok is false when there is nothing.
+func (m AppModel) openRequest() (openRequest, bool) {
+	switch m.Resource {
+	case model.ResourceHistoryDetail:
+		if m.SelectedChatItem >= 0 && m.SelectedChatItem < len(m.ChatItems) {
+			return openRequest{Content: plainChatItem(m.ChatItems[m.SelectedChatItem]), Ext: ".md"}, true
+		}
+	case model.ResourceToolCallDetail:
+		if m.SelectedToolCall != nil {
+			return toolCallOpenRequest(m.SelectedToolCall.ToolCall), true
+		}
+	case model.ResourceMemoryDetail:
+		if mem := m.SelectedMemory; mem != nil {
+			if mem.Content == "" && mem.Path != "" {
+				return openRequest{Path: mem.Path}, true
+			}
+			return openRequest{Content: mem.Content, Ext: ".md"}, true
+		}
+	case model.ResourcePluginItemDetail:
+		if item := m.SelectedPluginItem; item != nil {
+			if path, line := model.PluginItemFile(item); path != "" {
+				return openRequest{Path: path, Line: line}, true
+			}
+			return openRequest{Content: model.ReadPluginItemContent(item), Ext: ".txt"}, true
+		}
+	default:
+		if row := m.Table.SelectedRow(); row != nil {
+			if tr, ok := row.Data.(ToolCallRow); ok && tr.ToolCall != nil {
+				return toolCallOpenRequest(tr.ToolCall), true
+			}
+		}
+	}
+	return openRequest{}, false
+}
+
+// toolCallOpenRequest opens the file of a Read, Edit, MultiEdit or Write call
+// while it exists, else the call's input and result.
+func toolCallOpenRequest(tc *model.ToolCall) openRequest {
+	if path := tc.FilePath(); fileTools[tc.Name] && path != "" {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return openRequest{Path: path, Line: tc.FileLine()}
+		}
+	}
+	return openRequest{Content: plainToolCall(tc), Ext: ".txt"}
+}
+
+// plainChatItem returns a chat item's turns as unwrapped plain text.
+func plainChatItem(ci ChatItem) string {
+	var sb strings.Builder
+	sb.WriteString(ansi.Strip(renderChatItemHeader(ci)) + "\n")
+	for _, t := range append([]model.Turn{ci.Turn}, ci.ExtraTurns...) {
+		if t.Thinking != "" {
+			sb.WriteString("\n── thinking ──\n" + t.Thinking + "\n")
+		}
+		if t.Text != "" {
+			sb.WriteString("\n" + t.Text + "\n")
+		}
+		for _, tc := range t.ToolCalls {
+			sb.WriteString("\n" + plainToolCall(tc))
+		}
+	}
+	return sb.String()
+}
+
+// plainToolCall returns a tool call's name, indented input JSON and result.
+func plainToolCall(tc *model.ToolCall) string {
+	status := "✓"
+	if tc.IsError {
+		status = "✗ error"
+	}
+	out := fmt.Sprintf("▸ %s  %s\n%s\n", tc.Name, status, indentJSON(tc.Input))
+	if r := tc.ResultText(); r != "" {
+		out += "\n" + r + "\n"
+	}
+	return out
+}
+
+// openExternal suspends the TUI and opens the content view or the selected
+// tool call in $EDITOR (files) or $PAGER (content), flashing when there is
+// nothing to open.
+func (m *AppModel) openExternal() tea.Cmd {
+	req, ok := m.openRequest()
+	if !ok {
+		m.Flash = FlashModel{Message: "nothing to open here", Level: FlashError, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+		return nil
+	}
+	path, cleanup := req.Path, func() {}
+	if path == "" {
+		f, err := os.CreateTemp("", "claudeview-*"+req.Ext)
+		if err == nil {
+			_, err = f.WriteString(req.Content)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}
+		if err != nil {
+			m.Flash = FlashModel{Message: "open: " + err.Error(), Level: FlashError, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+			return nil
+		}
+		path = f.Name()
+		cleanup = func() { _ = os.Remove(f.Name()) }
+	}
+	cmd := externalCommand(path, req.Line, req.Path != "", os.Getenv)
+	return m.Exec(cmd, func(err error) tea.Msg {
+		cleanup()
+		return EditorClosedMsg{Err: err}
+	})
+}
+
+// externalCommand returns the command opening path at line (0: the top): a
+// real file in $VISUAL or $EDITOR (vi by default), generated content in
+// $PAGER (less by default). Either variable may carry arguments.
+func externalCommand(path string, line int, edit bool, getenv func(string) string) *exec.Cmd {
+	var fields []string
+	if edit {
+		fields = strings.Fields(getenv("VISUAL"))
+		if len(fields) == 0 {
+			fields = strings.Fields(getenv("EDITOR"))
+		}
+		if len(fields) == 0 {
+			fields = []string{"vi"}
+		}
+	} else {
+		fields = strings.Fields(getenv("PAGER"))
+		if len(fields) == 0 {
+			fields = []string{"less"}
+		}
+	}
+	return exec.Command(fields[0], append(fields[1:], lineArgs(fields[0], path, line)...)...)
+}
+
+// lineArgs returns the arguments opening path at line for program: `+N path`
+// (vi, emacs, nano, less and most others) or path:N for GUI editors.
+func lineArgs(program, path string, line int) []string {
+	if line <= 0 {
+		return []string{path}
+	}
+	switch filepath.Base(program) {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return []string{"-g", fmt.Sprintf("%s:%d", path, line)}
+	case "subl", "zed", "hx":
+		return []string{fmt.Sprintf("%s:%d", path, line)}
+	}
+	return []string{fmt.Sprintf("+%d", line), path}
+}
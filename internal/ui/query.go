@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Field extracts one value of a row's Data for the filter query language. It
+// returns a string, []string (matched when any element matches), int, int64,
+// float64, bool or time.Duration, or nil when the row has no such value.
+type Field func(data any) any
+
+// Query is a parsed table filter. A row matches when every term does.
+//
+// Terms are separated by spaces; double quotes group words into one term,
+// so "fix bug" matches the phrase, and a backslash before a term's first
+// character takes it literally, so \-foo matches "-foo" rather than negating.
+// A term is either free text, matched case-insensitively against the row's
+// cells, or field OP value, evaluated against the row's Data:
+//
+//	tool:Bash      substring (text), equality (number, bool, duration)
+//	name=main      exact match (case-insensitive for text)
+//	tokens>100k    >, >=, <, <= on numbers (k/m/b suffixes) and durations (s/m/h/d/w)
+//	branch:feat/*  glob: * and ? match any text, anchored at both ends
+//	tool:/^(Read|Edit)$/  regular expression, also allowed as free text
+//	-error:true    a leading - or ! negates the term
+type Query struct {
+	terms []queryTerm
+}
+
+type queryTerm struct {
+	negate bool
+	field  Field  // nil for free text
+	op     string // ":", "=", ">", ">=", "<" or "<="
+	text   string // lower-cased value
+	re     *regexp.Regexp
+	num    float64
+	isNum  bool
+	dur    time.Duration
+	isDur  bool
+}
+
+// queryOps lists the operators, longest first so ">=" wins over ">".
+var queryOps = []string{">=", "<=", ":", "=", ">", "<"}
+
+// ParseQuery parses a filter string. fields maps the field names the table
+// supports to their extractors; a field term naming any other field is an
+// error. An empty string parses to a query that matches every row.
+func ParseQuery(s string, fields map[string]Field) (Query, error) {
+	var q Query
+	for _, tok := range tokenizeQuery(s) {
+		t, err := parseTerm(tok, fields)
+		if err != nil {
+			return Query{}, err
+		}
+		q.terms = append(q.terms, t)
+	}
+	return q, nil
+}
+
+// Match reports whether row satisfies every term of the query.
+func (q Query) Match(row Row) bool {
+	for _, t := range q.terms {
+		if t.match(row) == t.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// queryToken is one space-separated word of a query with its quotes removed.
+// plain is the length of the unquoted prefix, the only part that may hold a
+// negation or a field name and operator.
+type queryToken struct {
+	text  string
+	plain int
+}
+
+func tokenizeQuery(s string) []queryToken {
+	var toks []queryToken
+	var cur strings.Builder
+	plain, quoted, inQuote, started, escaped := 0, false, false, false, false
+	flush := func() {
+		if started {
+			if !quoted {
+				plain = cur.Len()
+			}
+			toks = append(toks, queryToken{text: cur.String(), plain: plain})
+		}
+		cur.Reset()
+		plain, quoted, started = 0, false, false
+	}
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && !started:
+			// Like a quote: nothing from here on is a negation or field.
+			quoted, started, escaped = true, true, true
+		case r == '"':
+			if !quoted {
+				plain = cur.Len()
+				quoted = true
+			}
+			inQuote = !inQuote
+			started = true
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			cur.WriteRune(r)
+			started = true
+		}
+	}
+	flush()
+	return toks
+}
+
+func parseTerm(tok queryToken, fields map[string]Field) (queryTerm, error) {
+	var t queryTerm
+	text, plain := tok.text, tok.plain
+	if plain > 0 && len(text) > 1 && (text[0] == '-' || text[0] == '!') {
+		t.negate = true
+		text, plain = text[1:], plain-1
+	}
+	name, op, value := splitFieldTerm(text[:plain])
+	if op == "" {
+		t.op = ":"
+		return t, t.setValue(text)
+	}
+	f, ok := fields[name]
+	if !ok {
+		return t, fmt.Errorf("unknown field %q", name)
+	}
+	t.field, t.op = f, op
+	value += text[plain:]
+	if value == "" {
+		return t, fmt.Errorf("%s%s: missing value", name, op)
+	}
+	if op != ":" && op != "=" {
+		t.num, t.isNum = parseQueryNumber(value)
+		t.dur, t.isDur = parseQueryDuration(value)
+		if !t.isNum && !t.isDur {
+			return t, fmt.Errorf("%s%s%s: not a number or duration", name, op, value)
+		}
+		return t, nil
+	}
+	if err := t.setValue(value); err != nil {
+		return t, fmt.Errorf("%s%s: %v", name, op, err)
+	}
+	return t, nil
+}
+
+// splitFieldTerm splits "name<op>value" where name is lower-case letters and
+// underscores; op is "" when s does not have that shape.
+func splitFieldTerm(s string) (name, op, value string) {
+	i := 0
+	for i < len(s) && (s[i] >= 'a' && s[i] <= 'z' || i > 0 && s[i] == '_') {
+		i++
+	}
+	if i == 0 {
+		return "", "", s
+	}
+	for _, o := range queryOps {
+		if strings.HasPrefix(s[i:], o) {
+			return s[:i], o, s[i+len(o):]
+		}
+	}
+	return "", "", s
+}
+
+// setValue sets the value of a ":" or "=" term: a /regexp/, a glob, or text
+// that may also read as a number or duration.
+func (t *queryTerm) setValue(v string) error {
+	switch {
+	case len(v) >= 2 && v[0] == '/' && v[len(v)-1] == '/':
+		re, err := regexp.Compile("(?i)" + v[1:len(v)-1])
+		if err != nil {
+			return fmt.Errorf("bad regexp %s", v)
+		}
+		t.re = re
+	case strings.ContainsAny(v, "*?"):
+		pattern := regexp.QuoteMeta(v)
+		pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+		pattern = strings.ReplaceAll(pattern, `\?`, ".")
+		t.re = regexp.MustCompile("(?i)^" + pattern + "$")
+	default:
+		t.text = strings.ToLower(v)
+		t.num, t.isNum = parseQueryNumber(v)
+		t.dur, t.isDur = parseQueryDuration(v)
+	}
+	return nil
+}
+
+func (t queryTerm) match(row Row) bool {
+	if t.field == nil {
+		for _, cell := range row.Cells {
+			if t.matchText(ansi.Strip(cell)) {
+				return true
+			}
+		}
+		return false
+	}
+	switch v := t.field(row.Data).(type) {
+	case string:
+		return t.matchText(v)
+	case []string:
+		for _, s := range v {
+			if t.matchText(s) {
+				return true
+			}
+		}
+		return false
+	case int:
+		return t.matchNumber(float64(v))
+	case int64:
+		return t.matchNumber(float64(v))
+	case float64:
+		return t.matchNumber(v)
+	case bool:
+		b, ok := parseQueryBool(t.text)
+		return ok && t.re == nil && (t.op == ":" || t.op == "=") && b == v
+	case time.Duration:
+		return t.isDur && compareOp(t.op, float64(v), float64(t.dur))
+	}
+	return false
+}
+
+func (t queryTerm) matchText(s string) bool {
+	switch {
+	case t.re != nil:
+		return t.re.MatchString(s)
+	case t.op == ":":
+		return strings.Contains(strings.ToLower(s), t.text)
+	case t.op == "=":
+		return strings.EqualFold(s, t.text)
+	}
+	return false
+}
+
+func (t queryTerm) matchNumber(n float64) bool {
+	return t.isNum && compareOp(t.op, n, t.num)
+}
+
+func compareOp(op string, a, b float64) bool {
+	switch op {
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return a == b
+}
+
+// parseQueryNumber parses a number with an optional leading $ and a k, m or b
+// suffix (thousand, million, billion), e.g. "100k" or "$1.5".
+func parseQueryNumber(s string) (float64, bool) {
+	s = strings.TrimPrefix(strings.ToLower(s), "$")
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(s, "k"):
+		mult = 1e3
+	case strings.HasSuffix(s, "m"):
+		mult = 1e6
+	case strings.HasSuffix(s, "b"):
+		mult = 1e9
+	}
+	if mult != 1 {
+		s = s[:len(s)-1]
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n * mult, true
+}
+
+// parseQueryDuration parses a Go duration ("90s", "1h30m") or a number of days
+// or weeks ("2d", "1w").
+func parseQueryDuration(s string) (time.Duration, bool) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, true
+	}
+	unit := 24 * time.Hour
+	n, ok := strings.CutSuffix(s, "d")
+	if !ok {
+		unit *= 7
+		n, ok = strings.CutSuffix(s, "w")
+	}
+	v, err := strconv.ParseFloat(n, 64)
+	if !ok || err != nil {
+		return 0, false
+	}
+	return time.Duration(v * float64(unit)), true
+}
+
+// parseQueryBool parses true/false, yes/no and 1/0.
+func parseQueryBool(s string) (bool, bool) {
+	switch s {
+	case "true", "yes", "1":
+		return true, true
+	case "false", "no", "0":
+		return false, true
+	}
+	return false, false
+}
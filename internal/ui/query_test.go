@@ -0,0 +1,130 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+// queryItem is the row Data of the query tests.
+type queryItem struct {
+	name   string
+	branch string
+	tools  []string
+	tokens int
+	cost   float64
+	error  bool
+	age    time.Duration
+}
+
+var queryFields = map[string]ui.Field{
+	"name":   func(d any) any { return d.(queryItem).name },
+	"branch": func(d any) any { return d.(queryItem).branch },
+	"tool":   func(d any) any { return d.(queryItem).tools },
+	"tokens": func(d any) any { return d.(queryItem).tokens },
+	"cost":   func(d any) any { return d.(queryItem).cost },
+	"error":  func(d any) any { return d.(queryItem).error },
+	"age":    func(d any) any { return d.(queryItem).age },
+}
+
+func queryTable() ui.TableView {
+	items := []queryItem{
+		{name: "alpha", branch: "main", tools: []string{"Bash", "Read"}, tokens: 150_000, cost: 2.5, error: true, age: 30 * time.Minute},
+		{name: "beta", branch: "feat/query", tools: []string{"Edit"}, tokens: 80_000, cost: 0.4, age: 3 * time.Hour},
+		{name: "gamma two", branch: "feat/sort/desc", tools: nil, tokens: 2_000_000, cost: 12, age: 49 * time.Hour},
+	}
+	rows := make([]ui.Row, len(items))
+	for i, it := range items {
+		rows[i] = ui.Row{Cells: []string{it.name, "\x1b[31m" + it.branch + "\x1b[0m"}, Data: it}
+	}
+	tv := ui.NewTableView([]ui.Column{{Title: "NAME", Width: 10}, {Title: "BRANCH", Width: 10}}, 80, 10)
+	tv.Fields = queryFields
+	tv.SetRows(rows)
+	return tv
+}
+
+func filteredNames(tv ui.TableView) string {
+	var names []string
+	for _, r := range tv.FilteredRows() {
+		names = append(names, r.Data.(queryItem).name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestQueryFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"", "alpha,beta,gamma two"},
+		{"ALP", "alpha"},
+		{"feat", "beta,gamma two"},   // free text ignores cell styling
+		{`"gamma two"`, "gamma two"}, // quoted phrase
+		{"gamma two", "gamma two"},   // every word must match
+		{"tool:bash", "alpha"},       // any element of a list
+		{"-tool:bash", "beta,gamma two"},
+		{"!tool:bash", "beta,gamma two"},
+		{`\-tool:bash`, ""}, // escaped: the literal text "-tool:bash"
+		{`"-tool:bash"`, ""},
+		{`\alp`, "alpha"},
+		{"name=alph", ""}, // = is exact
+		{"name=ALPHA", "alpha"},
+		{"tokens>100k", "alpha,gamma two"},
+		{"tokens>=2m", "gamma two"},
+		{"tokens<=80000", "beta"},
+		{"cost>$1", "alpha,gamma two"},
+		{"error:true", "alpha"},
+		{"error:no", "beta,gamma two"},
+		{"age<2h", "alpha"},
+		{"age>1d", "gamma two"},
+		{"branch:feat/*", "beta,gamma two"}, // * spans slashes
+		{"branch:feat/?", ""},
+		{"branch:/^feat/.*y$/", "beta"},
+		{"/^(alpha|beta)$/", "alpha,beta"},
+		{"branch:feat/* -name:gamma", "beta"},
+		{"tool:edit tokens<1m age<4h", "beta"},
+		{`name:"gamma two"`, "gamma two"},
+	}
+	for _, tt := range tests {
+		tv := queryTable()
+		tv.Filter = tt.filter
+		if err := tv.FilterErr(); err != nil {
+			t.Errorf("%q: unexpected error %v", tt.filter, err)
+			continue
+		}
+		if got := filteredNames(tv); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestQueryFilterErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"model:opus", `unknown field "model"`},
+		{"tool:", "tool:: missing value"},
+		{"tokens>lots", "tokens>lots: not a number or duration"},
+		{"name:/(/", "name:: bad regexp /(/"},
+	}
+	for _, tt := range tests {
+		tv := queryTable()
+		tv.Filter = tt.filter
+		err := tv.FilterErr()
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%q: error = %v, want %q", tt.filter, err, tt.want)
+		}
+		// An invalid query leaves the table unfiltered.
+		if got := len(tv.FilteredRows()); got != 3 {
+			t.Errorf("%q: %d rows, want all 3", tt.filter, got)
+		}
+		// The parsed query is cached, but follows a change of Filter.
+		tv.Filter = "alp"
+		if err := tv.FilterErr(); err != nil || filteredNames(tv) != "alpha" {
+			t.Errorf("%q then %q: %v, %q", tt.filter, tv.Filter, err, filteredNames(tv))
+		}
+	}
+}
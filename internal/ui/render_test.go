@@ -8,6 +8,9 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
 
 	"github.com/Curt-Park/claudeview/internal/model"
 	"github.com/Curt-Park/claudeview/internal/ui"
@@ -150,3 +153,26 @@ func TestRenderEscDescWithFilter(t *testing.T) {
 
 // Ensure sendEsc is referenced so the compiler doesn't complain.
 var _ = sendEsc
+
+func TestRenderHighlightedRowMarksWords(t *testing.T) {
+	// Marks are only visible with colors on.
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	tv := ui.NewTableView([]ui.Column{{Title: "MATCH", Width: 40}}, 60, 5)
+	tv.SetRows([]ui.Row{
+		{Cells: []string{"fix the Watcher race"}, Highlight: []string{"watcher"}},
+		{Cells: []string{"watcher"}},
+	})
+	out := tv.View()
+	if !strings.Contains(out, ui.StyleSearchMatch.Render("Watcher")) {
+		t.Errorf("highlighted word not marked in %q", out)
+	}
+	if strings.Count(out, ui.StyleSearchMatch.Render("watcher")) != 0 {
+		t.Errorf("a row without Highlight should not be marked: %q", out)
+	}
+	if !strings.Contains(ansi.Strip(out), "fix the Watcher race") {
+		t.Errorf("highlighted row text changed: %q", out)
+	}
+}
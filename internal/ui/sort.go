@@ -0,0 +1,42 @@
+package ui
+
+// SortState is the sort applied to a table: the title of the column sorted
+// by and its direction. The zero value keeps provider order.
+type SortState struct {
+	Column string
+	Desc   bool
+}
+
+// Next returns the state after s when cycling through a table's sortable
+// columns: each column in order (largest or newest first), then provider
+// order again.
+func (s SortState) Next(columns []string) SortState {
+	if len(columns) == 0 {
+		return SortState{}
+	}
+	if s.Column == "" {
+		return SortState{Column: columns[0], Desc: true}
+	}
+	for i, c := range columns {
+		if c == s.Column && i+1 < len(columns) {
+			return SortState{Column: columns[i+1], Desc: true}
+		}
+	}
+	return SortState{}
+}
+
+// Reversed returns s with the direction flipped; provider order is unchanged.
+func (s SortState) Reversed() SortState {
+	if s.Column == "" {
+		return s
+	}
+	return SortState{Column: s.Column, Desc: !s.Desc}
+}
+
+// indicator returns the header arrow for the sort direction.
+func (s SortState) indicator() string {
+	if s.Desc {
+		return "▼"
+	}
+	return "▲"
+}
@@ -0,0 +1,50 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+func TestSortStateNext(t *testing.T) {
+	cols := []string{"TURNS", "COST"}
+	s := ui.SortState{}
+	var got []ui.SortState
+	for range 3 {
+		s = s.Next(cols)
+		got = append(got, s)
+	}
+	want := []ui.SortState{{Column: "TURNS", Desc: true}, {Column: "COST", Desc: true}, {}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("step %d: %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if s := (ui.SortState{Column: "TURNS", Desc: true}).Next(nil); s != (ui.SortState{}) {
+		t.Errorf("Next without sortable columns = %+v, want zero", s)
+	}
+}
+
+func TestSortStateReversed(t *testing.T) {
+	if s := (ui.SortState{Column: "COST", Desc: true}).Reversed(); s != (ui.SortState{Column: "COST"}) {
+		t.Errorf("Reversed = %+v", s)
+	}
+	if s := (ui.SortState{}).Reversed(); s != (ui.SortState{}) {
+		t.Errorf("Reversed of provider order = %+v, want zero", s)
+	}
+}
+
+func TestTableHeaderSortIndicator(t *testing.T) {
+	tv := ui.NewTableView([]ui.Column{{Title: "NAME", Width: 10}, {Title: "AGENTS", Width: 6}}, 40, 5)
+	tv.Sort = ui.SortState{Column: "AGENTS", Desc: true}
+	header := strings.Split(tv.View(), "\n")[0]
+	if !strings.Contains(header, "AGENT▼") {
+		t.Errorf("header should keep the arrow in a narrow column, got %q", header)
+	}
+	tv.Sort = ui.SortState{Column: "NAME"}
+	header = strings.Split(tv.View(), "\n")[0]
+	if !strings.Contains(header, "NAME▲") {
+		t.Errorf("header should mark ascending sort, got %q", header)
+	}
+}
@@ -19,6 +19,7 @@ var (
 	colorDimGray = lipgloss.Color("238")
 	colorCyan    = lipgloss.Color("51")
 	colorBgSel   = lipgloss.Color("237")
+	colorBlack   = lipgloss.Color("16")
 
 	// Base styles
 	StyleNormal = lipgloss.NewStyle()
@@ -84,6 +85,39 @@ var (
 	StyleFilter = lipgloss.NewStyle().
 			Foreground(colorYellow)
 
+	// StyleSearchMatch marks the words of a search hit.
+	StyleSearchMatch = lipgloss.NewStyle().
+				Background(colorYellow).
+				Foreground(colorBlack)
+
+	// StyleSearchCurrent marks the in-content search match the view is on.
+	StyleSearchCurrent = lipgloss.NewStyle().
+				Background(colorOrange).
+				Foreground(colorBlack).
+				Bold(true)
+
+	// Unified diff of file-modifying tool calls.
+	StyleDiffAdd  = lipgloss.NewStyle().Foreground(colorGreen)
+	StyleDiffDel  = lipgloss.NewStyle().Foreground(colorRed)
+	StyleDiffHunk = lipgloss.NewStyle().Foreground(colorCyan)
+
+	// Syntax highlighting of code blocks and tool results.
+	StyleSyntaxKeyword = lipgloss.NewStyle().Foreground(colorPurple)
+	StyleSyntaxType    = lipgloss.NewStyle().Foreground(colorCyan)
+	StyleSyntaxString  = lipgloss.NewStyle().Foreground(colorGreen)
+	StyleSyntaxNumber  = lipgloss.NewStyle().Foreground(colorOrange)
+	StyleSyntaxFunc    = lipgloss.NewStyle().Foreground(colorBlue)
+	StyleSyntaxComment = lipgloss.NewStyle().Foreground(colorGray).Italic(true)
+
+	// Markdown rendering of assistant text, memories and plugin items.
+	StyleMDHeading1  = lipgloss.NewStyle().Foreground(colorYellow).Bold(true).Reverse(true)
+	StyleMDHeading   = lipgloss.NewStyle().Foreground(colorYellow).Bold(true)
+	StyleMDCode      = lipgloss.NewStyle().Foreground(colorOrange)
+	StyleMDLink      = lipgloss.NewStyle().Foreground(colorBlue)
+	StyleMDQuote     = lipgloss.NewStyle().Foreground(colorGray)
+	StyleMDRule      = lipgloss.NewStyle().Foreground(colorDimGray)
+	StyleMDTableHead = lipgloss.NewStyle().Foreground(colorCyan).Bold(true)
+
 	StyleLogTool   = lipgloss.NewStyle().Foreground(colorBlue)
 	StyleLogText   = lipgloss.NewStyle().Foreground(colorWhite)
 	StyleLogThink  = lipgloss.NewStyle().Foreground(colorPurple)
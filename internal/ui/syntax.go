@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxHighlight caps the text syntax highlighting tokenizes; longer code is
+// shown plain rather than stalling a render.
+const maxHighlight = 256 << 10
+
+// highlightCode returns code with its tokens colored for language lang (a
+// fence info string or alias such as "go" or "py"). Code in an unknown
+// language is returned unchanged. Styles never span a newline, so the result
+// splits into lines like code does.
+func highlightCode(code, lang string) string {
+	if lang == "" {
+		return code
+	}
+	return highlightWith(lexers.Get(lang), code)
+}
+
+// highlightFile is highlightCode with the language inferred from a file name.
+func highlightFile(code, path string) string {
+	return highlightWith(lexerForFile(path), code)
+}
+
+// lexerForFile returns the lexer for a file name's extension, nil for none.
+func lexerForFile(path string) chroma.Lexer {
+	if path == "" {
+		return nil
+	}
+	return lexers.Match(filepath.Base(path))
+}
+
+func highlightWith(lexer chroma.Lexer, code string) string {
+	if lexer == nil || len(code) > maxHighlight {
+		return code
+	}
+	it, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return code
+	}
+	var sb strings.Builder
+	for tok := it(); tok != chroma.EOF; tok = it() {
+		style, ok := syntaxStyle(tok.Type)
+		if !ok {
+			sb.WriteString(tok.Value)
+			continue
+		}
+		for i, part := range strings.Split(tok.Value, "\n") {
+			if i > 0 {
+				sb.WriteByte('\n')
+			}
+			if part != "" {
+				sb.WriteString(style.Render(part))
+			}
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n") + trailingNewline(code)
+}
+
+// trailingNewline returns "\n" when s ends with one; some lexers add a final
+// newline the code did not have.
+func trailingNewline(s string) string {
+	if strings.HasSuffix(s, "\n") {
+		return "\n"
+	}
+	return ""
+}
+
+// syntaxStyle returns the style of a token type; ok is false for text left
+// uncolored (names, punctuation, whitespace).
+func syntaxStyle(t chroma.TokenType) (lipgloss.Style, bool) {
+	switch {
+	case t.InCategory(chroma.Comment):
+		return StyleSyntaxComment, true
+	case t == chroma.KeywordType || t == chroma.NameBuiltin || t == chroma.NameClass:
+		return StyleSyntaxType, true
+	case t.InCategory(chroma.Keyword), t == chroma.NameTag:
+		return StyleSyntaxKeyword, true
+	case t.InSubCategory(chroma.LiteralString):
+		return StyleSyntaxString, true
+	case t.InSubCategory(chroma.LiteralNumber):
+		return StyleSyntaxNumber, true
+	case t == chroma.NameFunction || t == chroma.NameAttribute:
+		return StyleSyntaxFunc, true
+	case t == chroma.GenericInserted:
+		return StyleDiffAdd, true
+	case t == chroma.GenericDeleted:
+		return StyleDiffDel, true
+	case t == chroma.GenericHeading || t == chroma.GenericSubheading:
+		return StyleDiffHunk, true
+	}
+	return lipgloss.Style{}, false
+}
+
+// readLineNumber matches the line number a Read result puts before each line.
+var readLineNumber = regexp.MustCompile(`^ *\d+(\t|→)`)
+
+// highlightReadResult highlights a Read tool result as the file path holds,
+// keeping its line numbers dim. Lines after the numbered ones (reminders
+// appended to the result) are dim too.
+func highlightReadResult(result, path string) string {
+	lines := strings.Split(result, "\n")
+	n := 0
+	var nums, code []string
+	for n < len(lines) {
+		loc := readLineNumber.FindStringIndex(lines[n])
+		if loc == nil {
+			break
+		}
+		nums = append(nums, lines[n][:loc[1]])
+		code = append(code, lines[n][loc[1]:])
+		n++
+	}
+	highlighted := strings.Split(highlightFile(strings.Join(code, "\n"), path), "\n")
+	out := make([]string, 0, len(lines))
+	for i := range nums {
+		line := code[i]
+		if i < len(highlighted) {
+			line = highlighted[i]
+		}
+		out = append(out, StyleDim.Render(nums[i])+line)
+	}
+	for _, l := range lines[n:] {
+		out = append(out, StyleDim.Render(l))
+	}
+	return strings.Join(out, "\n")
+}
+
+// bashResultLexer guesses the language of a Bash command's output: a diff
+// for git diff/show and diff, else the type of the last file argument with a
+// known extension (cat main.go, head -n 20 setup.py).
+func bashResultLexer(command string) chroma.Lexer {
+	fields := strings.Fields(command)
+	if len(fields) == 0 || strings.ContainsAny(command, "|;&>") {
+		return nil
+	}
+	if fields[0] == "diff" || (fields[0] == "git" && len(fields) > 1 && (fields[1] == "diff" || fields[1] == "show")) {
+		return lexers.Get("diff")
+	}
+	for i := len(fields) - 1; i > 0; i-- {
+		if strings.HasPrefix(fields[i], "-") || filepath.Ext(fields[i]) == "" {
+			continue
+		}
+		return lexerForFile(strings.Trim(fields[i], `"'`))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"reflect"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,12 +23,13 @@ type Column struct {
 // align it under a specific column).
 type Row struct {
 	Cells          []string
-	Subtitle       string // optional second line shown in dimmed style
-	SubtitleIndent int    // leading spaces before the subtitle text
-	SubtitlePrefix string // optional leading text before indent spaces (e.g. "│" tree connector)
-	Data           any    // original data object
-	Hot            bool   // true if this row was recently updated (for highlight)
-	Skip           bool   // true if this row should be skipped during navigation (e.g. dividers)
+	Subtitle       string   // optional second line shown in dimmed style
+	SubtitleIndent int      // leading spaces before the subtitle text
+	SubtitlePrefix string   // optional leading text before indent spaces (e.g. "│" tree connector)
+	Data           any      // original data object
+	Hot            bool     // true if this row was recently updated (for highlight)
+	Skip           bool     // true if this row should be skipped during navigation (e.g. dividers)
+	Highlight      []string // words marked wherever they occur in the cells, ignoring case
 }
 
 // rowLineCount returns the number of display lines this row occupies.
@@ -46,7 +48,21 @@ type TableView struct {
 	Offset   int // scroll offset
 	Width    int
 	Height   int
-	Filter   string
+	Filter   string           // query language, see Query
+	Fields   map[string]Field // fields the filter can query, by name
+	Sortable []string         // titles of the columns that can be sorted by
+	Sort     SortState        // current sort, shown as an arrow in the header
+
+	query *queryCache // Filter parsed; shared by copies of the table
+}
+
+// queryCache holds the last Filter parsed against Fields, so rendering and
+// moving the cursor do not parse the query again.
+type queryCache struct {
+	filter string
+	fields uintptr // identity of the Fields map
+	q      Query
+	err    error
 }
 
 // NewTableView creates a new table view.
@@ -55,6 +71,7 @@ func NewTableView(cols []Column, width, height int) TableView {
 		Columns: cols,
 		Width:   width,
 		Height:  height,
+		query:   &queryCache{},
 	}
 }
 
@@ -213,24 +230,46 @@ func (t *TableView) Update(msg tea.Msg) (bool, tea.Cmd) {
 	return false, nil
 }
 
-// FilteredRows returns rows matching the current filter (case-insensitive).
+// FilteredRows returns rows matching the current filter query.
 func (t TableView) FilteredRows() []Row {
 	return t.filteredRows()
 }
 
-// filteredRows returns rows matching the current filter (case-insensitive).
+// FilterErr returns the error parsing the current filter query, or nil.
+func (t TableView) FilterErr() error {
+	_, err := t.parsedFilter()
+	return err
+}
+
+// parsedFilter returns Filter parsed against Fields, parsing it again only
+// when either changed. A table not made by NewTableView parses every time.
+func (t TableView) parsedFilter() (Query, error) {
+	c := t.query
+	if c == nil {
+		return ParseQuery(t.Filter, t.Fields)
+	}
+	fields := reflect.ValueOf(t.Fields).Pointer()
+	if c.filter != t.Filter || c.fields != fields {
+		c.q, c.err = ParseQuery(t.Filter, t.Fields)
+		c.filter, c.fields = t.Filter, fields
+	}
+	return c.q, c.err
+}
+
+// filteredRows returns rows matching the current filter query. A filter that
+// does not parse leaves the rows unfiltered.
 func (t TableView) filteredRows() []Row {
 	if t.Filter == "" {
 		return t.Rows
 	}
-	needle := strings.ToLower(t.Filter)
+	q, err := t.parsedFilter()
+	if err != nil {
+		return t.Rows
+	}
 	var out []Row
 	for _, row := range t.Rows {
-		for _, cell := range row.Cells {
-			if strings.Contains(strings.ToLower(cell), needle) {
-				out = append(out, row)
-				break
-			}
+		if q.Match(row) {
+			out = append(out, row)
 		}
 	}
 	return out
@@ -387,13 +426,21 @@ func (t TableView) calculateWidths() []int {
 func (t TableView) renderHeader(widths []int) string {
 	var parts []string
 	for i, col := range t.Columns {
-		cell := padRight(col.Title, widths[i])
+		title := col.Title
+		if t.Sort.Column != "" && title == t.Sort.Column {
+			// Truncate the title rather than the arrow in narrow columns.
+			title = ansi.Truncate(title, max(widths[i]-1, 0), "") + t.Sort.indicator()
+		}
+		cell := padRight(title, widths[i])
 		parts = append(parts, StyleColumnHeader.Render(cell))
 	}
 	return strings.Join(parts, " ")
 }
 
 func (t TableView) renderRow(row Row, widths []int, selected bool) string {
+	if len(row.Highlight) > 0 {
+		return t.renderHighlightedRow(row, widths, selected)
+	}
 	var parts []string
 	for i := range t.Columns {
 		cell := ""
@@ -422,6 +469,30 @@ func (t TableView) renderRow(row Row, widths []int, selected bool) string {
 	return line
 }
 
+// renderHighlightedRow renders a row with its Highlight words marked. Cell
+// styling is dropped so the marks and the selection background can share
+// the line.
+func (t TableView) renderHighlightedRow(row Row, widths []int, selected bool) string {
+	var parts []string
+	for i := range t.Columns {
+		cell := ""
+		if i < len(row.Cells) {
+			cell = row.Cells[i]
+		}
+		parts = append(parts, ansi.Strip(padRight(cell, widths[i])))
+	}
+	line := strings.Join(parts, " ")
+	base := StyleNormal
+	if selected {
+		base = StyleSelected
+	}
+	out := highlightWords(line, row.Highlight, base, StyleSearchMatch)
+	if pad := t.Width - lipgloss.Width(line); selected && pad > 0 {
+		out += base.Render(strings.Repeat(" ", pad))
+	}
+	return out
+}
+
 // renderSubtitleLine renders the subtitle string as a full-width dimmed line,
 // indented by row.SubtitleIndent spaces to align under a specific column.
 // When selected is true the row's selection background is applied.
@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+)
+
+// BuildToolCallRows flattens every tool call in a session into rows in
+// chronological order. agentTurns[i] holds the turns of agents[i].
+func BuildToolCallRows(agents []*model.Agent, agentTurns [][]model.Turn) []ToolCallRow {
+	var rows []ToolCallRow
+	for i, a := range agents {
+		if i >= len(agentTurns) {
+			break
+		}
+		for _, t := range agentTurns[i] {
+			for _, tc := range t.ToolCalls {
+				rows = append(rows, ToolCallRow{ToolCall: tc, ParentTurn: t, Agent: a})
+			}
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].ToolCall.Timestamp.Before(rows[j].ToolCall.Timestamp)
+	})
+	return rows
+}
@@ -0,0 +1,44 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+func TestBuildToolCallRows(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	main := &model.Agent{Type: model.AgentTypeMain}
+	sub := &model.Agent{ID: "agent-a1", Type: model.AgentTypeExplore, IsSubagent: true}
+	read := &model.ToolCall{Name: "Read", Timestamp: t0, Duration: time.Second}
+	grep := &model.ToolCall{Name: "Grep", Timestamp: t0.Add(time.Minute), Duration: 5 * time.Second}
+	bash := &model.ToolCall{Name: "Bash", Timestamp: t0.Add(2 * time.Minute), Duration: 3 * time.Second}
+	turns := [][]model.Turn{
+		{{Role: "assistant", ToolCalls: []*model.ToolCall{read}}, {Role: "assistant", ToolCalls: []*model.ToolCall{bash}}},
+		{{Role: "assistant", ToolCalls: []*model.ToolCall{grep}}},
+	}
+
+	rows := ui.BuildToolCallRows([]*model.Agent{main, sub}, turns)
+	if got := toolNames(rows); got != "Read Grep Bash" {
+		t.Fatalf("chronological order = %q, want %q", got, "Read Grep Bash")
+	}
+	if rows[1].Agent != sub || rows[0].Agent != main {
+		t.Error("rows should carry the agent that made each call")
+	}
+	if rows[0].ParentTurn.Role != "assistant" {
+		t.Error("rows should carry their parent turn for the detail view")
+	}
+}
+
+func toolNames(rows []ui.ToolCallRow) string {
+	var s string
+	for i, r := range rows {
+		if i > 0 {
+			s += " "
+		}
+		s += r.ToolCall.Name
+	}
+	return s
+}
@@ -0,0 +1,276 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Curt-Park/claudeview/internal/clipboard"
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/search"
+)
+
+// yankOption is one thing `y` can copy from the current row or content view.
+type yankOption struct {
+	Key  string
+	Desc string
+	Text string
+}
+
+// YankedMsg reports a finished copy to the clipboard.
+type YankedMsg struct {
+	Desc string
+	Size int
+	Err  error
+}
+
+// Stdout is the terminal, for tea.WithOutput. Bubble Tea writes each frame
+// in one Write, and writes are serialized, so the OSC 52 sequence of a copy
+// lands between frames rather than inside one.
+var Stdout = &terminal{f: os.Stdout}
+
+// terminal is a file whose writes hold a lock. It keeps Fd, so Bubble Tea
+// still sees a terminal to size.
+type terminal struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+func (t *terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.f.Write(p)
+}
+
+func (t *terminal) Read(p []byte) (int, error) { return t.f.Read(p) }
+func (t *terminal) Close() error               { return t.f.Close() }
+func (t *terminal) Fd() uintptr                { return t.f.Fd() }
+
+// copyToTerminal is the default AppModel.Clipboard: an OSC 52 sequence on
+// Stdout, the terminal Bubble Tea draws on.
+func copyToTerminal(text string) error {
+	return clipboard.Copy(Stdout, text)
+}
+
+// startYank opens the yank prompt listing what the selection offers, or
+// flashes that there is nothing to copy.
+func (m *AppModel) startYank() {
+	opts := m.yankOptions()
+	if len(opts) == 0 {
+		m.Flash = FlashModel{Message: "nothing to copy here", Level: FlashError, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+		return
+	}
+	m.yankOpts = opts
+}
+
+// updateYank picks an option of the yank prompt: its key, or `y` again for
+// the first. Any other key closes the prompt.
+func (m AppModel) updateYank(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	opts := m.yankOpts
+	m.yankOpts = nil
+	key := msg.String()
+	for i, o := range opts {
+		if o.Key == key || (key == "y" && i == 0) {
+			copyFn := m.Clipboard
+			return m, func() tea.Msg {
+				return YankedMsg{Desc: o.Desc, Size: len(o.Text), Err: copyFn(o.Text)}
+			}
+		}
+	}
+	return m, nil
+}
+
+// yankPrompt renders the status bar of an open yank prompt.
+func (m AppModel) yankPrompt() string {
+	parts := make([]string, len(m.yankOpts))
+	for i, o := range m.yankOpts {
+		parts[i] = o.Key + " " + o.Desc
+	}
+	return StyleFilter.Width(m.Flash.Width).Render("copy: " + strings.Join(parts, " · ") + "  (y: " + m.yankOpts[0].Desc + ", esc: cancel)")
+}
+
+// yankedFlash confirms a copy in the flash bar.
+func (m *AppModel) yankedFlash(msg YankedMsg) {
+	if msg.Err != nil {
+		m.Flash = FlashModel{Message: "copy " + msg.Desc + ": " + msg.Err.Error(), Level: FlashError, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+		return
+	}
+	m.Flash = FlashModel{Message: fmt.Sprintf("copied %s (%s)", msg.Desc, model.FormatSize(int64(msg.Size))), Level: FlashInfo, ExpiresAt: time.Now().Add(3 * time.Second), Width: m.Flash.Width}
+}
+
+// yankOptions returns what can be copied from the content view, or from the
+// selected row of a table view.
+func (m AppModel) yankOptions() []yankOption {
+	switch m.Resource {
+	case model.ResourceHistoryDetail:
+		if m.SelectedChatItem >= 0 && m.SelectedChatItem < len(m.ChatItems) {
+			return chatItemYanks(m.ChatItems[m.SelectedChatItem])
+		}
+		return nil
+	case model.ResourceToolCallDetail:
+		if m.SelectedToolCall != nil {
+			return toolCallYanks(m.SelectedToolCall.ToolCall)
+		}
+		return nil
+	case model.ResourceMemoryDetail:
+		return memoryYanks(m.SelectedMemory)
+	case model.ResourcePluginItemDetail:
+		return pluginItemYanks(m.SelectedPluginItem)
+	}
+	row := m.Table.SelectedRow()
+	if row == nil {
+		return nil
+	}
+	switch v := row.Data.(type) {
+	case *model.Project:
+		return nonEmpty(yankOption{"p", "project path", v.Path})
+	case *model.Session:
+		return nonEmpty(
+			yankOption{"i", "session ID", v.ID},
+			yankOption{"r", "resume command", resumeCommand(m.projectPath(v.ProjectHash), v.ID)},
+			yankOption{"p", "transcript path", v.FilePath},
+		)
+	case *model.Agent:
+		return nonEmpty(
+			yankOption{"i", "agent ID", v.AgentID()},
+			yankOption{"p", "transcript path", v.FilePath},
+		)
+	case ChatItem:
+		return chatItemYanks(v)
+	case ToolCallRow:
+		return toolCallYanks(v.ToolCall)
+	case *model.Memory:
+		return memoryYanks(v)
+	case *model.Plugin:
+		return nonEmpty(yankOption{"p", "plugin directory", v.CacheDir})
+	case *model.PluginItem:
+		return pluginItemYanks(v)
+	case search.Hit:
+		return nonEmpty(
+			yankOption{"t", "matched text", search.DocText(v.Doc, m.DataProvider.GetTurns(v.FilePath))},
+			yankOption{"i", "session ID", v.SessionID},
+			yankOption{"r", "resume command", resumeCommand(m.projectPath(v.ProjectHash), v.SessionID)},
+			yankOption{"p", "transcript path", v.FilePath},
+		)
+	}
+	return nil
+}
+
+// chatItemYanks offers the text and thinking of a chat item's turns.
+func chatItemYanks(ci ChatItem) []yankOption {
+	if ci.IsDivider {
+		return nil
+	}
+	var text, thinking []string
+	for _, t := range append([]model.Turn{ci.Turn}, ci.ExtraTurns...) {
+		if t.Text != "" {
+			text = append(text, t.Text)
+		}
+		if t.Thinking != "" {
+			thinking = append(thinking, t.Thinking)
+		}
+	}
+	return nonEmpty(
+		yankOption{"t", "turn text", strings.Join(text, "\n\n")},
+		yankOption{"k", "thinking", strings.Join(thinking, "\n\n")},
+	)
+}
+
+// toolCallYanks offers a tool call's input (indented JSON), result and file.
+func toolCallYanks(tc *model.ToolCall) []yankOption {
+	if tc == nil {
+		return nil
+	}
+	return nonEmpty(
+		yankOption{"j", "tool input JSON", indentJSON(tc.Input)},
+		yankOption{"o", "tool result", tc.ResultText()},
+		yankOption{"p", "file path", tc.FilePath()},
+	)
+}
+
+// indentJSON returns raw indented by two spaces, unchanged when it is not JSON.
+func indentJSON(raw []byte) string {
+	var buf bytes.Buffer
+	if json.Indent(&buf, raw, "", "  ") != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// memoryYanks offers a memory file's content and path.
+func memoryYanks(mem *model.Memory) []yankOption {
+	if mem == nil {
+		return nil
+	}
+	content := mem.Content
+	if content == "" {
+		if data, err := os.ReadFile(mem.Path); err == nil {
+			content = string(data)
+		}
+	}
+	return nonEmpty(
+		yankOption{"c", "memory content", content},
+		yankOption{"p", "memory path", mem.Path},
+	)
+}
+
+// pluginItemYanks offers a plugin item's content.
+func pluginItemYanks(item *model.PluginItem) []yankOption {
+	if item == nil {
+		return nil
+	}
+	return nonEmpty(yankOption{"c", item.Category + " content", model.ReadPluginItemContent(item)})
+}
+
+// nonEmpty drops the options with nothing to copy.
+func nonEmpty(opts ...yankOption) []yankOption {
+	var out []yankOption
+	for _, o := range opts {
+		if o.Text != "" {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// projectPath returns the directory of a project, "" when unknown.
+func (m AppModel) projectPath(hash string) string {
+	if m.DataProvider == nil {
+		return ""
+	}
+	for _, p := range m.DataProvider.GetProjects() {
+		if p.Hash == hash {
+			return p.Path
+		}
+	}
+	return ""
+}
+
+// resumeCommand returns the shell command resuming a session, run from its
+// project directory when known.
+func resumeCommand(dir, sessionID string) string {
+	if sessionID == "" {
+		return ""
+	}
+	cmd := "claude -r " + sessionID
+	if dir != "" {
+		cmd = "cd " + shellQuote(dir) + " && " + cmd
+	}
+	return cmd
+}
+
+// shellQuote quotes s for a POSIX shell when it needs quoting.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("/._-+:@%", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,82 @@
+package view
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+var agentColumns = []ui.Column{
+	{Title: "AGENT", Width: 24, Flex: true, MaxPercent: 0.3},
+	{Title: "STATUS", Width: 9},
+	{Title: "TOOLS", Width: 6},
+	{Title: "MODEL:IN+CACHE/OUT", Width: 20, Flex: true, MaxPercent: 0.25},
+	{Title: "COST", Width: 8},
+	{Title: "LAST ACTIVITY", Width: 20, Flex: true, MaxPercent: 0.3},
+	{Title: "LAST ACTIVE", Width: 11},
+}
+
+// NewAgentsView creates an agents view.
+func NewAgentsView(width, height int) *ResourceView[*model.Agent] {
+	v := NewResourceView[*model.Agent](agentColumns, nil, nil, width, height)
+	// SetData clears an unsortable Sort before building rows, so a column
+	// here means the rows left provider (tree) order.
+	v.rowFunc = func(items []*model.Agent, i int, _ bool) ui.Row {
+		return agentRow(items, i, v.Table.Sort.Column != "")
+	}
+	return v.Sortable(agentSorts).Queryable(agentFields)
+}
+
+var agentSorts = map[string]Compare[*model.Agent]{
+	"TOOLS":              func(a, b *model.Agent) int { return cmp.Compare(len(a.ToolCalls), len(b.ToolCalls)) },
+	"MODEL:IN+CACHE/OUT": func(a, b *model.Agent) int { return cmp.Compare(a.TotalTokens(), b.TotalTokens()) },
+	"COST":               func(a, b *model.Agent) int { return cmp.Compare(a.CostUSD, b.CostUSD) },
+	"LAST ACTIVE":        func(a, b *model.Agent) int { return a.ModTime.Compare(b.ModTime) },
+}
+
+var agentFields = map[string]Field[*model.Agent]{
+	"name":   func(a *model.Agent) any { return a.DisplayName() },
+	"type":   func(a *model.Agent) any { return string(a.Type) },
+	"status": func(a *model.Agent) any { return string(a.Status) },
+	"tool":   func(a *model.Agent) any { return toolNames(a.ToolCalls) },
+	"tools":  func(a *model.Agent) any { return len(a.ToolCalls) },
+	"error":  func(a *model.Agent) any { return anyError(a.ToolCalls) },
+	"model":  func(a *model.Agent) any { return modelNames(a.TokensByModel) },
+	"tokens": func(a *model.Agent) any { return a.TotalTokens() },
+	"cost":   func(a *model.Agent) any { return a.CostUSD },
+	"age":    func(a *model.Agent) any { return age(a.ModTime) },
+}
+
+// agentRow builds an agent's row. Tree connectors follow the neighbouring
+// rows, so sorted rows only indent subagents.
+func agentRow(items []*model.Agent, i int, sorted bool) ui.Row {
+	a := items[i]
+	prefix := a.TreePrefix(i == len(items)-1 || !items[i+1].IsSubagent)
+	if sorted && a.IsSubagent {
+		prefix = "     "
+	}
+	name := prefix + a.Type.Icon() + " " + a.DisplayName()
+	if a.Unmatched {
+		name += " (unmatched)"
+	}
+	activity := a.LastActivity
+	if activity == "" {
+		activity = "-"
+	}
+	return ui.Row{
+		Cells: []string{
+			name,
+			statusCell(a.Status),
+			fmt.Sprintf("%d", len(a.ToolCalls)),
+			a.TokenString(),
+			model.FormatCost(a.CostUSD),
+			activity,
+			a.LastActive(),
+		},
+		Data: a,
+		Hot:  time.Since(a.ModTime) <= 5*time.Second,
+	}
+}
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"github.com/Curt-Park/claudeview/internal/model"
 	"github.com/Curt-Park/claudeview/internal/ui"
 )
 
@@ -12,6 +13,48 @@ var chatColumns = []ui.Column{
 	{Title: "DURATION", Width: 14},
 }
 
+var chatFields = map[string]Field[ui.ChatItem]{
+	"role": func(c ui.ChatItem) any { return c.Turn.Role },
+	"agent": func(c ui.ChatItem) any {
+		if !c.IsSubagent {
+			return nil
+		}
+		return string(c.AgentType)
+	},
+	"model": func(c ui.ChatItem) any {
+		var names []string
+		for _, t := range chatTurns(c) {
+			if t.ModelName != "" {
+				names = append(names, t.ModelName)
+			}
+		}
+		return names
+	},
+	"text":  func(c ui.ChatItem) any { return c.Turn.Text },
+	"tool":  func(c ui.ChatItem) any { return toolNames(c.AllToolCalls()) },
+	"error": func(c ui.ChatItem) any { return anyError(c.AllToolCalls()) },
+	"tokens": func(c ui.ChatItem) any {
+		var n int
+		for _, t := range chatTurns(c) {
+			n += t.InputTokens + t.CacheWriteTokens + t.CacheReadTokens + t.OutputTokens
+		}
+		return n
+	},
+	"cost": func(c ui.ChatItem) any {
+		var usd float64
+		for _, t := range chatTurns(c) {
+			usd += t.CostUSD
+		}
+		return usd
+	},
+	"age": func(c ui.ChatItem) any { return age(c.Turn.Timestamp) },
+}
+
+// chatTurns returns an item's turn followed by its merged tool-only turns.
+func chatTurns(c ui.ChatItem) []model.Turn {
+	return append([]model.Turn{c.Turn}, c.ExtraTurns...)
+}
+
 func chatRow(items []ui.ChatItem, i int, _ bool) ui.Row {
 	c := items[i]
 	if c.IsDivider {
@@ -45,5 +88,5 @@ func chatRow(items []ui.ChatItem, i int, _ bool) ui.Row {
 
 // NewChatView creates a ResourceView for the session chat table.
 func NewChatView(width, height int) *ResourceView[ui.ChatItem] {
-	return NewResourceView(chatColumns, nil, chatRow, width, height)
+	return NewResourceView(chatColumns, nil, chatRow, width, height).Queryable(chatFields)
 }
@@ -1,5 +1,17 @@
 package view
 
+import (
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/model"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+// statusCell renders a status in its StatusStyle color.
+func statusCell(s model.Status) string {
+	return ui.StatusStyle(s).Render(string(s))
+}
+
 // ShortID returns the first n characters of id, or the full id if shorter.
 func ShortID(id string, n int) string {
 	if len(id) > n {
@@ -15,3 +27,39 @@ func truncateHash(hash string) string {
 	}
 	return hash
 }
+
+// age returns the time since t for an "age" query field, or nil when t is unset.
+func age(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return time.Since(t)
+}
+
+// modelNames returns the model names of per-model token counts.
+func modelNames(byModel map[string]model.TokenCount) []string {
+	names := make([]string, 0, len(byModel))
+	for m := range byModel {
+		names = append(names, m)
+	}
+	return names
+}
+
+// toolNames returns the tool name of every call.
+func toolNames(calls []*model.ToolCall) []string {
+	names := make([]string, len(calls))
+	for i, tc := range calls {
+		names[i] = tc.Name
+	}
+	return names
+}
+
+// anyError reports whether any call returned an error.
+func anyError(calls []*model.ToolCall) bool {
+	for _, tc := range calls {
+		if tc.IsError {
+			return true
+		}
+	}
+	return false
+}
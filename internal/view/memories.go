@@ -1,6 +1,8 @@
 package view
 
 import (
+	"cmp"
+
 	"github.com/Curt-Park/claudeview/internal/model"
 	"github.com/Curt-Park/claudeview/internal/ui"
 )
@@ -14,7 +16,19 @@ var memoryColumns = []ui.Column{
 
 // NewMemoriesView creates a memories view.
 func NewMemoriesView(width, height int) *ResourceView[*model.Memory] {
-	return NewResourceView(memoryColumns, nil, memoryRow, width, height)
+	return NewResourceView(memoryColumns, nil, memoryRow, width, height).Sortable(memorySorts).Queryable(memoryFields)
+}
+
+var memorySorts = map[string]Compare[*model.Memory]{
+	"SIZE":     func(a, b *model.Memory) int { return cmp.Compare(a.Size, b.Size) },
+	"MODIFIED": func(a, b *model.Memory) int { return a.ModTime.Compare(b.ModTime) },
+}
+
+var memoryFields = map[string]Field[*model.Memory]{
+	"name":  func(m *model.Memory) any { return m.Name },
+	"title": func(m *model.Memory) any { return m.Title },
+	"size":  func(m *model.Memory) any { return m.Size },
+	"age":   func(m *model.Memory) any { return age(m.ModTime) },
 }
 
 func memoryRow(items []*model.Memory, i int, _ bool) ui.Row {
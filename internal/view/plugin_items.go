@@ -12,7 +12,12 @@ var pluginItemColumns = []ui.Column{
 
 // NewPluginItemsView creates a plugin-item navigable table view.
 func NewPluginItemsView(width, height int) *ResourceView[*model.PluginItem] {
-	return NewResourceView(pluginItemColumns, nil, pluginItemRow, width, height)
+	return NewResourceView(pluginItemColumns, nil, pluginItemRow, width, height).Queryable(pluginItemFields)
+}
+
+var pluginItemFields = map[string]Field[*model.PluginItem]{
+	"name":     func(item *model.PluginItem) any { return item.Name },
+	"category": func(item *model.PluginItem) any { return item.Category },
 }
 
 func pluginItemRow(items []*model.PluginItem, i int, _ bool) ui.Row {
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"cmp"
 	"fmt"
 
 	"github.com/Curt-Park/claudeview/internal/model"
@@ -22,7 +23,29 @@ var pluginColumns = []ui.Column{
 
 // NewPluginsView creates a plugins view.
 func NewPluginsView(width, height int) *ResourceView[*model.Plugin] {
-	return NewResourceView(pluginColumns, nil, pluginRow, width, height)
+	return NewResourceView(pluginColumns, nil, pluginRow, width, height).Sortable(pluginSorts).Queryable(pluginFields)
+}
+
+var pluginSorts = map[string]Compare[*model.Plugin]{
+	"SKILLS":    func(a, b *model.Plugin) int { return cmp.Compare(a.SkillCount, b.SkillCount) },
+	"COMMANDS":  func(a, b *model.Plugin) int { return cmp.Compare(a.CommandCount, b.CommandCount) },
+	"HOOKS":     func(a, b *model.Plugin) int { return cmp.Compare(a.HookCount, b.HookCount) },
+	"AGENTS":    func(a, b *model.Plugin) int { return cmp.Compare(a.AgentCount, b.AgentCount) },
+	"MCPS":      func(a, b *model.Plugin) int { return cmp.Compare(a.MCPCount, b.MCPCount) },
+	"INSTALLED": func(a, b *model.Plugin) int { return cmp.Compare(a.InstalledAt, b.InstalledAt) }, // RFC 3339
+}
+
+var pluginFields = map[string]Field[*model.Plugin]{
+	"name":        func(p *model.Plugin) any { return p.Name },
+	"version":     func(p *model.Plugin) any { return p.Version },
+	"marketplace": func(p *model.Plugin) any { return p.Marketplace },
+	"scope":       func(p *model.Plugin) any { return p.Scope },
+	"enabled":     func(p *model.Plugin) any { return p.Enabled },
+	"skills":      func(p *model.Plugin) any { return p.SkillCount },
+	"commands":    func(p *model.Plugin) any { return p.CommandCount },
+	"hooks":       func(p *model.Plugin) any { return p.HookCount },
+	"agents":      func(p *model.Plugin) any { return p.AgentCount },
+	"mcps":        func(p *model.Plugin) any { return p.MCPCount },
 }
 
 // isoDate extracts the YYYY-MM-DD portion of an ISO timestamp for display.
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"cmp"
 	"fmt"
 	"time"
 
@@ -11,12 +12,26 @@ import (
 var projectColumns = []ui.Column{
 	{Title: "NAME", Width: 20, Flex: true, MaxPercent: 0.55},
 	{Title: "SESSIONS", Width: 8},
+	{Title: "COST", Width: 8},
 	{Title: "LAST ACTIVE", Width: 11},
 }
 
 // NewProjectsView creates a projects view.
 func NewProjectsView(width, height int) *ResourceView[*model.Project] {
-	return NewResourceView(projectColumns, nil, projectRow, width, height)
+	return NewResourceView(projectColumns, nil, projectRow, width, height).Sortable(projectSorts).Queryable(projectFields)
+}
+
+var projectSorts = map[string]Compare[*model.Project]{
+	"SESSIONS":    func(a, b *model.Project) int { return cmp.Compare(a.SessionCount(), b.SessionCount()) },
+	"COST":        func(a, b *model.Project) int { return cmp.Compare(a.Cost(), b.Cost()) },
+	"LAST ACTIVE": func(a, b *model.Project) int { return a.LastSeen.Compare(b.LastSeen) },
+}
+
+var projectFields = map[string]Field[*model.Project]{
+	"name":     func(p *model.Project) any { return p.Hash },
+	"sessions": func(p *model.Project) any { return p.SessionCount() },
+	"cost":     func(p *model.Project) any { return p.Cost() },
+	"age":      func(p *model.Project) any { return age(p.LastSeen) },
 }
 
 func projectRow(items []*model.Project, i int, _ bool) ui.Row {
@@ -25,6 +40,7 @@ func projectRow(items []*model.Project, i int, _ bool) ui.Row {
 		Cells: []string{
 			truncateHash(p.Hash),
 			fmt.Sprintf("%d", p.SessionCount()),
+			model.FormatCost(p.Cost()),
 			model.FormatAge(time.Since(p.LastSeen)),
 		},
 		Data: p,
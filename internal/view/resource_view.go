@@ -1,10 +1,22 @@
 package view
 
-import "github.com/Curt-Park/claudeview/internal/ui"
+import (
+	"sort"
+
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
 
 // RowBuilder builds a table row from a slice of items at a given index.
 type RowBuilder[T any] func(items []T, index int, flatMode bool) ui.Row
 
+// Compare orders two items by one column: negative when a sorts before b in
+// ascending order, zero when they tie.
+type Compare[T any] func(a, b T) int
+
+// Field extracts one value of an item for the filter query language; see
+// ui.Field for the value types it may return.
+type Field[T any] func(item T) any
+
 // ResourceView is a generic scrollable table view for any resource type.
 type ResourceView[T any] struct {
 	Table    ui.TableView
@@ -13,6 +25,7 @@ type ResourceView[T any] struct {
 	cols     []ui.Column
 	flatCols []ui.Column // nil means flat mode uses the same columns
 	rowFunc  RowBuilder[T]
+	sorts    map[string]Compare[T] // column title → comparator
 }
 
 // NewResourceView creates a ResourceView with the given normal columns, optional flat-mode
@@ -26,14 +39,58 @@ func NewResourceView[T any](cols, flatCols []ui.Column, rowFunc RowBuilder[T], w
 	}
 }
 
-// SetData updates the items and rebuilds the table rows.
+// Sortable declares the columns the view can be sorted by, keyed by column
+// title, and returns the view.
+func (v *ResourceView[T]) Sortable(sorts map[string]Compare[T]) *ResourceView[T] {
+	v.sorts = sorts
+	return v
+}
+
+// Queryable declares the fields the filter query language can test, keyed by
+// field name, and returns the view.
+func (v *ResourceView[T]) Queryable(fields map[string]Field[T]) *ResourceView[T] {
+	v.Table.Fields = make(map[string]ui.Field, len(fields))
+	for name, f := range fields {
+		v.Table.Fields[name] = func(data any) any {
+			if item, ok := data.(T); ok {
+				return f(item)
+			}
+			return nil
+		}
+	}
+	return v
+}
+
+// SetData updates the items and rebuilds the table rows, sorted by
+// Table.Sort when it names a sortable column. Ties keep provider order.
 func (v *ResourceView[T]) SetData(items []T) {
-	v.Items = items
 	if v.FlatMode && v.flatCols != nil {
 		v.Table.Columns = v.flatCols
 	} else {
 		v.Table.Columns = v.cols
 	}
+	var sortable []string
+	for _, c := range v.Table.Columns {
+		if _, ok := v.sorts[c.Title]; ok {
+			sortable = append(sortable, c.Title)
+		}
+	}
+	v.Table.Sortable = sortable
+	if cmp, ok := v.sorts[v.Table.Sort.Column]; ok {
+		sorted := make([]T, len(items))
+		copy(sorted, items)
+		desc := v.Table.Sort.Desc
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if desc {
+				return cmp(sorted[i], sorted[j]) > 0
+			}
+			return cmp(sorted[i], sorted[j]) < 0
+		})
+		items = sorted
+	} else {
+		v.Table.Sort = ui.SortState{}
+	}
+	v.Items = items
 	rows := make([]ui.Row, len(items))
 	for i := range items {
 		rows[i] = v.rowFunc(items, i, v.FlatMode)
@@ -41,14 +98,15 @@ func (v *ResourceView[T]) SetData(items []T) {
 	v.Table.SetRows(rows)
 }
 
-// Sync updates dimensions, nav state, flat mode, and data in one call, then returns the
+// Sync updates dimensions, nav state, flat mode, sort, and data in one call, then returns the
 // updated TableView ready to be assigned to AppModel.Table.
-func (v *ResourceView[T]) Sync(items []T, w, h, sel, off int, filter string, flat bool) ui.TableView {
+func (v *ResourceView[T]) Sync(items []T, w, h, sel, off int, filter string, flat bool, order ui.SortState) ui.TableView {
 	v.Table.Width = w
 	v.Table.Height = h
 	v.Table.Selected = sel
 	v.Table.Offset = off
 	v.Table.Filter = filter
+	v.Table.Sort = order
 	v.FlatMode = flat
 	v.SetData(items)
 	return v.Table
@@ -0,0 +1,56 @@
+package view
+
+import (
+	"github.com/Curt-Park/claudeview/internal/search"
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+var searchColumns = []ui.Column{
+	{Title: "TIME", Width: 11},
+	{Title: "PROJECT", Width: 20, Flex: true, MaxPercent: 0.25},
+	{Title: "SESSION", Width: 8},
+	{Title: "KIND", Width: 11},
+	{Title: "MATCH", Width: 30, Flex: true, MaxPercent: 0.60},
+}
+
+// NewSearchView creates the global search hits view.
+func NewSearchView(width, height int) *ResourceView[search.Hit] {
+	return NewResourceView(searchColumns, nil, searchRow, width, height).Sortable(searchSorts).Queryable(searchFields)
+}
+
+var searchSorts = map[string]Compare[search.Hit]{
+	"TIME": func(a, b search.Hit) int { return a.Timestamp.Compare(b.Timestamp) },
+}
+
+var searchFields = map[string]Field[search.Hit]{
+	"project": func(h search.Hit) any { return h.ProjectHash },
+	"session": func(h search.Hit) any { return h.SessionID },
+	"agent":   func(h search.Hit) any { return h.SubagentID },
+	"kind":    func(h search.Hit) any { return string(h.Kind) },
+	"tool":    func(h search.Hit) any { return h.Tool },
+	"text":    func(h search.Hit) any { return h.Text },
+	"age":     func(h search.Hit) any { return age(h.Timestamp) },
+}
+
+func searchRow(items []search.Hit, i int, _ bool) ui.Row {
+	h := items[i]
+	var ts string
+	if !h.Timestamp.IsZero() {
+		ts = h.Timestamp.Local().Format("01-02 15:04")
+	}
+	match := h.Snippet
+	if h.Tool != "" {
+		match = h.Tool + ": " + match
+	}
+	return ui.Row{
+		Cells: []string{
+			ts,
+			truncateHash(h.ProjectHash),
+			ShortID(h.SessionID, 8),
+			string(h.Kind),
+			match,
+		},
+		Data:      h,
+		Highlight: h.Terms,
+	}
+}
@@ -1,6 +1,7 @@
 package view
 
 import (
+	"cmp"
 	"fmt"
 	"time"
 
@@ -15,6 +16,10 @@ var sessionColumnsBase = []ui.Column{
 	{Title: "TURNS", Width: 6},
 	{Title: "AGENTS", Width: 6},
 	{Title: "MODEL:IN+CACHE/OUT", Width: 20, Flex: true, MaxPercent: 0.25},
+	{Title: "COST", Width: 8},
+	{Title: "STATUS", Width: 9},
+	{Title: "PID", Width: 7},
+	{Title: "CPU", Width: 6},
 	{Title: "LAST ACTIVE", Width: 11},
 }
 
@@ -26,12 +31,46 @@ var sessionColumnsFlat = []ui.Column{
 	{Title: "TURNS", Width: 6},
 	{Title: "AGENTS", Width: 6},
 	{Title: "MODEL:IN+CACHE/OUT", Width: 20, Flex: true, MaxPercent: 0.25},
+	{Title: "COST", Width: 8},
+	{Title: "STATUS", Width: 9},
+	{Title: "PID", Width: 7},
+	{Title: "CPU", Width: 6},
 	{Title: "LAST ACTIVE", Width: 11},
 }
 
 // NewSessionsView creates a sessions view.
 func NewSessionsView(width, height int) *ResourceView[*model.Session] {
-	return NewResourceView(sessionColumnsBase, sessionColumnsFlat, sessionRow, width, height)
+	return NewResourceView(sessionColumnsBase, sessionColumnsFlat, sessionRow, width, height).Sortable(sessionSorts).Queryable(sessionFields)
+}
+
+var sessionSorts = map[string]Compare[*model.Session]{
+	"TURNS":              func(a, b *model.Session) int { return cmp.Compare(a.NumTurns, b.NumTurns) },
+	"AGENTS":             func(a, b *model.Session) int { return cmp.Compare(a.AgentCount, b.AgentCount) },
+	"MODEL:IN+CACHE/OUT": func(a, b *model.Session) int { return cmp.Compare(a.TotalTokens(), b.TotalTokens()) },
+	"COST":               func(a, b *model.Session) int { return cmp.Compare(a.Cost(), b.Cost()) },
+	"LAST ACTIVE":        func(a, b *model.Session) int { return a.ModTime.Compare(b.ModTime) },
+}
+
+var sessionFields = map[string]Field[*model.Session]{
+	"id": func(s *model.Session) any {
+		ids := []string{s.ID}
+		for _, gs := range s.GroupSessions {
+			ids = append(ids, gs.ID)
+		}
+		return ids
+	},
+	"slug":    func(s *model.Session) any { return s.Slug },
+	"topic":   func(s *model.Session) any { return s.Topic },
+	"branch":  func(s *model.Session) any { return s.Branch },
+	"model":   func(s *model.Session) any { return modelNames(s.TokensByModel) },
+	"tokens":  func(s *model.Session) any { return s.TotalTokens() },
+	"cost":    func(s *model.Session) any { return s.Cost() },
+	"turns":   func(s *model.Session) any { return s.NumTurns },
+	"agents":  func(s *model.Session) any { return s.AgentCount },
+	"tools":   func(s *model.Session) any { return s.ToolCallCount },
+	"status":  func(s *model.Session) any { return string(s.Status()) },
+	"running": func(s *model.Session) any { return s.Process != nil },
+	"age":     func(s *model.Session) any { return age(s.ModTime) },
 }
 
 func sessionRow(items []*model.Session, i int, flatMode bool) ui.Row {
@@ -50,6 +89,10 @@ func sessionRow(items []*model.Session, i int, flatMode bool) ui.Row {
 		fmt.Sprintf("%d", s.NumTurns),
 		fmt.Sprintf("%d", s.AgentCount),
 		s.TokenString(),
+		model.FormatCost(s.Cost()),
+		statusCell(s.Status()),
+		s.PIDString(),
+		s.CPUString(),
 		s.LastActive(),
 	)
 	row := ui.Row{
@@ -0,0 +1,72 @@
+package view
+
+import (
+	"cmp"
+
+	"github.com/Curt-Park/claudeview/internal/ui"
+)
+
+var toolCallColumns = []ui.Column{
+	{Title: "TIME", Width: 8},
+	{Title: "AGENT", Width: 14},
+	{Title: "TOOL", Width: 12},
+	{Title: "INPUT", Width: 30, Flex: true, MaxPercent: 0.45},
+	{Title: "RESULT", Width: 20, Flex: true, MaxPercent: 0.30},
+	{Title: "ERR", Width: 3},
+	{Title: "DURATION", Width: 9},
+}
+
+// NewToolCallsView creates a flat tool-calls view.
+func NewToolCallsView(width, height int) *ResourceView[ui.ToolCallRow] {
+	return NewResourceView(toolCallColumns, nil, toolCallRow, width, height).Sortable(toolCallSorts).Queryable(toolCallFields)
+}
+
+var toolCallSorts = map[string]Compare[ui.ToolCallRow]{
+	"TIME":     func(a, b ui.ToolCallRow) int { return a.ToolCall.Timestamp.Compare(b.ToolCall.Timestamp) },
+	"DURATION": func(a, b ui.ToolCallRow) int { return cmp.Compare(a.ToolCall.Duration, b.ToolCall.Duration) },
+}
+
+var toolCallFields = map[string]Field[ui.ToolCallRow]{
+	"tool": func(r ui.ToolCallRow) any { return r.ToolCall.Name },
+	"agent": func(r ui.ToolCallRow) any {
+		if r.Agent == nil {
+			return nil
+		}
+		return r.Agent.DisplayName()
+	},
+	"input":    func(r ui.ToolCallRow) any { return r.ToolCall.InputSummary() },
+	"result":   func(r ui.ToolCallRow) any { return r.ToolCall.ResultSummary() },
+	"error":    func(r ui.ToolCallRow) any { return r.ToolCall.IsError },
+	"duration": func(r ui.ToolCallRow) any { return r.ToolCall.Duration },
+	"age":      func(r ui.ToolCallRow) any { return age(r.ToolCall.Timestamp) },
+}
+
+func toolCallRow(items []ui.ToolCallRow, i int, _ bool) ui.Row {
+	tr := items[i]
+	tc := tr.ToolCall
+	var ts, agent, errFlag, dur string
+	if !tc.Timestamp.IsZero() {
+		ts = tc.Timestamp.Local().Format("15:04:05")
+	}
+	if tr.Agent != nil {
+		agent = tr.Agent.Type.Icon() + " " + tr.Agent.DisplayName()
+	}
+	if tc.IsError {
+		errFlag = ui.StyleChatToolErr.Render("✗")
+	}
+	if tc.Duration > 0 {
+		dur = tc.DurationString()
+	}
+	return ui.Row{
+		Cells: []string{
+			ts,
+			agent,
+			tc.Name,
+			tc.InputSummary(),
+			tc.ResultSummary(),
+			errFlag,
+			dur,
+		},
+		Data: tr,
+	}
+}
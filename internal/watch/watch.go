@@ -0,0 +1,119 @@
+// Package watch reports filesystem changes under a directory tree so callers
+// can refresh on demand instead of rescanning on a timer.
+package watch
+
+import (
+	"sync"
+	"time"
+)
+
+// debounce is how long a batch keeps collecting events after the first one
+// before it is delivered. Claude Code appends to a transcript many times per
+// turn; batching keeps the refresh rate bounded.
+const debounce = 200 * time.Millisecond
+
+// Change is a batch of filesystem changes.
+type Change struct {
+	// Paths lists the files and directories that changed, without duplicates.
+	Paths []string
+	// Overflow is set when the kernel dropped events; every path under the
+	// watched tree must be treated as changed.
+	Overflow bool
+}
+
+// rawEvent is a single change reported by the platform backend.
+type rawEvent struct {
+	path     string
+	overflow bool
+}
+
+// Watcher watches a directory tree and delivers batched changes on Changes.
+// Directories created inside the tree are watched automatically, down to
+// the depth given to New.
+type Watcher struct {
+	raw          chan rawEvent
+	changes      chan Change
+	done         chan struct{}
+	closeOnce    sync.Once
+	closeBackend func() error
+}
+
+// New starts watching root and its subdirectories up to maxDepth levels
+// below it. It returns errors.ErrUnsupported on platforms without a backend.
+func New(root string, maxDepth int) (*Watcher, error) {
+	w := &Watcher{
+		raw:     make(chan rawEvent, 64),
+		changes: make(chan Change),
+		done:    make(chan struct{}),
+	}
+	if err := w.start(root, maxDepth); err != nil {
+		return nil, err
+	}
+	go w.batchLoop()
+	return w, nil
+}
+
+// Changes returns the channel of batched changes. It is closed after Close.
+func (w *Watcher) Changes() <-chan Change {
+	return w.changes
+}
+
+// Close stops the watcher and releases its resources.
+func (w *Watcher) Close() error {
+	var err error
+	w.closeOnce.Do(func() {
+		close(w.done)
+		err = w.closeBackend()
+	})
+	return err
+}
+
+// emit hands one event from the backend to the batcher. It returns false
+// once the watcher is closed.
+func (w *Watcher) emit(ev rawEvent) bool {
+	select {
+	case w.raw <- ev:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// batchLoop merges raw events into Change batches. A batch is started by the
+// first event, becomes deliverable after debounce, and keeps absorbing events
+// until the consumer receives it. The backend closes w.raw when it stops.
+func (w *Watcher) batchLoop() {
+	defer close(w.changes)
+	var (
+		batch Change
+		seen  = make(map[string]bool)
+		timer <-chan time.Time
+		out   chan<- Change // non-nil only while a batch is ready to send
+	)
+	for {
+		select {
+		case ev, ok := <-w.raw:
+			if !ok {
+				return
+			}
+			if ev.overflow {
+				batch.Overflow = true
+			} else if !seen[ev.path] {
+				seen[ev.path] = true
+				batch.Paths = append(batch.Paths, ev.path)
+			}
+			if timer == nil && out == nil {
+				timer = time.After(debounce)
+			}
+		case <-timer:
+			timer = nil
+			out = w.changes
+		case out <- batch:
+			batch = Change{}
+			seen = make(map[string]bool)
+			out = nil
+		case <-w.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+//go:build linux
+
+package watch
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+const watchMask = unix.IN_CREATE | unix.IN_MODIFY | unix.IN_DELETE |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF | unix.IN_ONLYDIR
+
+// inotify is the Linux backend: one watch descriptor per directory.
+type inotify struct {
+	fd       int // raw descriptor; file.Fd() would switch the file to blocking mode
+	file     *os.File
+	maxDepth int
+
+	mu     sync.Mutex
+	dirs   map[int]string // watch descriptor → directory path
+	depths map[string]int // directory path → depth below root
+}
+
+func (w *Watcher) start(root string, maxDepth int) error {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return os.NewSyscallError("inotify_init1", err)
+	}
+	in := &inotify{
+		fd: fd,
+		// A non-blocking fd is registered with the runtime poller, so Read
+		// parks the goroutine and Close unblocks it.
+		file:     os.NewFile(uintptr(fd), "inotify"),
+		maxDepth: maxDepth,
+		dirs:     make(map[int]string),
+		depths:   make(map[string]int),
+	}
+	if err := in.add(root, 0); err != nil {
+		_ = in.file.Close()
+		return err
+	}
+	in.addChildren(root, 0)
+	w.closeBackend = in.file.Close
+	go in.readLoop(w)
+	return nil
+}
+
+// add watches a single directory.
+func (in *inotify) add(dir string, depth int) error {
+	wd, err := unix.InotifyAddWatch(in.fd, dir, watchMask)
+	if err != nil {
+		return &os.PathError{Op: "inotify_add_watch", Path: dir, Err: err}
+	}
+	in.mu.Lock()
+	in.dirs[wd] = dir
+	in.depths[dir] = depth
+	in.mu.Unlock()
+	return nil
+}
+
+// addChildren watches the subdirectories of dir down to maxDepth. Errors are
+// ignored: a directory that vanished or cannot be read is simply not watched.
+func (in *inotify) addChildren(dir string, depth int) {
+	if depth >= in.maxDepth {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		child := filepath.Join(dir, e.Name())
+		if in.add(child, depth+1) == nil {
+			in.addChildren(child, depth+1)
+		}
+	}
+}
+
+// readLoop decodes inotify events until the file is closed.
+func (in *inotify) readLoop(w *Watcher) {
+	defer close(w.raw)
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	for {
+		n, err := in.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for off := 0; off+unix.SizeofInotifyEvent <= n; {
+			wd := int(int32(binary.NativeEndian.Uint32(buf[off:])))
+			mask := binary.NativeEndian.Uint32(buf[off+4:])
+			nameLen := int(binary.NativeEndian.Uint32(buf[off+12:]))
+			start := off + unix.SizeofInotifyEvent
+			if start+nameLen > n {
+				break
+			}
+			name := strings.TrimRight(string(buf[start:start+nameLen]), "\x00")
+			off = start + nameLen
+			if !in.handle(w, wd, mask, name) {
+				return
+			}
+		}
+	}
+}
+
+// handle processes one event. It returns false once the watcher is closed.
+func (in *inotify) handle(w *Watcher, wd int, mask uint32, name string) bool {
+	if mask&unix.IN_Q_OVERFLOW != 0 {
+		return w.emit(rawEvent{overflow: true})
+	}
+
+	in.mu.Lock()
+	dir, ok := in.dirs[wd]
+	depth := in.depths[dir]
+	if ok && mask&unix.IN_IGNORED != 0 {
+		delete(in.dirs, wd)
+		delete(in.depths, dir)
+	}
+	in.mu.Unlock()
+	if !ok || mask&unix.IN_IGNORED != 0 {
+		return true
+	}
+
+	path := dir
+	if name != "" {
+		path = filepath.Join(dir, name)
+	}
+	// Watch directories created (or moved) into the tree, including any
+	// children created before the watch was in place.
+	if mask&unix.IN_ISDIR != 0 && mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 && depth < in.maxDepth {
+		if in.add(path, depth+1) == nil {
+			in.addChildren(path, depth+1)
+		}
+	}
+	return w.emit(rawEvent{path: path})
+}
@@ -0,0 +1,118 @@
+//go:build linux
+
+package watch_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Curt-Park/claudeview/internal/watch"
+)
+
+// waitFor receives batches until one contains path or the timeout expires.
+func waitFor(t *testing.T, w *watch.Watcher, path string) {
+	t.Helper()
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case c, ok := <-w.Changes():
+			if !ok {
+				t.Fatalf("changes channel closed while waiting for %s", path)
+			}
+			if slices.Contains(c.Paths, path) {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("timed out waiting for change to %s", path)
+		}
+	}
+}
+
+func TestWatcherReportsFileWrites(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "project")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := watch.New(root, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	// Existing subdirectories are watched from the start.
+	file := filepath.Join(sub, "session.jsonl")
+	if err := os.WriteFile(file, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, w, file)
+}
+
+func TestWatcherFollowsNewDirectories(t *testing.T) {
+	root := t.TempDir()
+	w, err := watch.New(root, 2)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer func() { _ = w.Close() }()
+
+	dir := filepath.Join(root, "session", "subagents")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, w, filepath.Join(root, "session"))
+
+	file := filepath.Join(dir, "agent-1.jsonl")
+	if err := os.WriteFile(file, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, w, file)
+}
+
+func TestWatcherBatchesAndCloses(t *testing.T) {
+	root := t.TempDir()
+	w, err := watch.New(root, 0)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	file := filepath.Join(root, "a.jsonl")
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 5 {
+		if _, err := f.WriteString("{}\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = f.Close()
+
+	select {
+	case c := <-w.Changes():
+		if n := len(c.Paths); n != 1 || c.Paths[0] != file {
+			t.Errorf("expected one deduplicated path, got %v", c.Paths)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for batch")
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	select {
+	case _, ok := <-w.Changes():
+		if ok {
+			// A final in-flight batch is acceptable; the channel must close next.
+			if _, ok := <-w.Changes(); ok {
+				t.Error("expected changes channel to close")
+			}
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("changes channel not closed after Close")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package watch
+
+import "errors"
+
+// start reports that no watcher backend exists on this platform; callers
+// fall back to periodic polling.
+func (w *Watcher) start(string, int) error {
+	return errors.ErrUnsupported
+}